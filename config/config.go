@@ -1,31 +1,55 @@
 package config
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"sync"
+	"sync/atomic"
 
 	"github.com/spf13/viper"
 )
 
-// RecommendationFactors contiene los factores de recomendación para empresas y brokerages
-type RecommendationFactors struct {
-	Companies  map[string]float64 `json:"companies"`
-	Brokerages map[string]float64 `json:"brokerages"`
-}
-
 // Config contiene la configuración de la aplicación.
 type Config struct {
-	Address               string
-	DatabaseURL           string
-	StockAPIURL           string
-	StockAuthTkn          string
-	SyncMaxIterations     int
-	SyncTimeout           int
-	CORSAllowedOrigins    string
-	RecommendationFactors *RecommendationFactors
+	Address             string
+	DatabaseURL         string
+	StockProvider       string
+	StockProviderFile   string
+	StockAPIURL         string
+	StockAuthTkn        string
+	SyncMaxIterations   int
+	SyncTimeout         int
+	SyncRemoveMissing   bool
+	SyncSchedule        string
+	SyncScheduleLimit   int
+	SyncWorkers         int
+	SyncBatchSize       int
+	APIMaxRetries       int
+	APIRetryBaseDelayMs int
+	APIRetryMaxDelayMs  int
+	APIRateLimitRPS     float64
+	APIRateLimitBurst   int
+	CORSAllowedOrigins  string
+	AdminToken          string
+
+	RecommendationFactorsPath        string
+	RecommendationFactorsWatch       bool
+	RecommendationFactorsAutoRescore bool
+
+	// SyncWorkerDisabled evita iniciar el worker de jobs de sincronización. No se lee del entorno:
+	// lo habilitan los comandos de línea (como import) para no retomar los jobs del servidor.
+	SyncWorkerDisabled bool
+
+	// recommendationFactors es el modelo vigente: el del archivo de factores combinado con los factores
+	// de empresas y brokerages administrados en la base de datos. Se reemplaza de forma atómica.
+	recommendationFactors atomic.Pointer[RecommendationFactors]
+
+	// factorsMu serializa las actualizaciones del modelo del archivo y de los factores administrados
+	factorsMu       sync.Mutex
+	fileFactors     *RecommendationFactors
+	factorOverrides *FactorOverrides
 }
 
 // New crea una nueva instancia de Config.
@@ -64,58 +88,150 @@ func setupViper() {
 
 	// Valores por defecto
 	viper.SetDefault("ADDRESS", ":8080")
+	viper.SetDefault("STOCK_PROVIDER", "api")
 	viper.SetDefault("SYNC_MAX_ITERATIONS", 100)
 	viper.SetDefault("SYNC_TIMEOUT", 60)
+	viper.SetDefault("SYNC_REMOVE_MISSING", true)
+	viper.SetDefault("SYNC_SCHEDULE", "")
+	viper.SetDefault("SYNC_SCHEDULE_LIMIT", 0)
+	viper.SetDefault("SYNC_WORKERS", 4)
+	viper.SetDefault("SYNC_BATCH_SIZE", 500)
+	viper.SetDefault("API_MAX_RETRIES", 3)
+	viper.SetDefault("API_RETRY_BASE_DELAY_MS", 500)
+	viper.SetDefault("API_RETRY_MAX_DELAY_MS", 10000)
+	viper.SetDefault("API_RATE_LIMIT_RPS", 5)
+	viper.SetDefault("API_RATE_LIMIT_BURST", 1)
 	viper.SetDefault("CORS_ALLOWED_ORIGINS", "*")
 	viper.SetDefault("RECOMMENDATION_FACTORS_PATH", "recommendation_factors.json")
+	viper.SetDefault("RECOMMENDATION_FACTORS_WATCH", true)
+	viper.SetDefault("RECOMMENDATION_FACTORS_AUTO_RESCORE", false)
 }
 
 // createBaseConfig crea la configuración base de la aplicación
 func createBaseConfig() *Config {
-	return &Config{
-		Address:            viper.GetString("ADDRESS"),
-		DatabaseURL:        viper.GetString("DATABASE_URL"),
-		StockAPIURL:        viper.GetString("STOCK_API_URL"),
-		StockAuthTkn:       viper.GetString("STOCK_AUTH_TKN"),
-		SyncMaxIterations:  viper.GetInt("SYNC_MAX_ITERATIONS"),
-		SyncTimeout:        viper.GetInt("SYNC_TIMEOUT"),
-		CORSAllowedOrigins: viper.GetString("CORS_ALLOWED_ORIGINS"),
+	config := &Config{
+		Address:             viper.GetString("ADDRESS"),
+		DatabaseURL:         viper.GetString("DATABASE_URL"),
+		StockProvider:       viper.GetString("STOCK_PROVIDER"),
+		StockProviderFile:   viper.GetString("STOCK_PROVIDER_FILE"),
+		StockAPIURL:         viper.GetString("STOCK_API_URL"),
+		StockAuthTkn:        viper.GetString("STOCK_AUTH_TKN"),
+		SyncMaxIterations:   viper.GetInt("SYNC_MAX_ITERATIONS"),
+		SyncTimeout:         viper.GetInt("SYNC_TIMEOUT"),
+		SyncRemoveMissing:   viper.GetBool("SYNC_REMOVE_MISSING"),
+		SyncSchedule:        viper.GetString("SYNC_SCHEDULE"),
+		SyncScheduleLimit:   viper.GetInt("SYNC_SCHEDULE_LIMIT"),
+		SyncWorkers:         viper.GetInt("SYNC_WORKERS"),
+		SyncBatchSize:       viper.GetInt("SYNC_BATCH_SIZE"),
+		APIMaxRetries:       viper.GetInt("API_MAX_RETRIES"),
+		APIRetryBaseDelayMs: viper.GetInt("API_RETRY_BASE_DELAY_MS"),
+		APIRetryMaxDelayMs:  viper.GetInt("API_RETRY_MAX_DELAY_MS"),
+		APIRateLimitRPS:     viper.GetFloat64("API_RATE_LIMIT_RPS"),
+		APIRateLimitBurst:   viper.GetInt("API_RATE_LIMIT_BURST"),
+		CORSAllowedOrigins:  viper.GetString("CORS_ALLOWED_ORIGINS"),
+		AdminToken:          viper.GetString("ADMIN_TOKEN"),
+
+		RecommendationFactorsPath:        viper.GetString("RECOMMENDATION_FACTORS_PATH"),
+		RecommendationFactorsWatch:       viper.GetBool("RECOMMENDATION_FACTORS_WATCH"),
+		RecommendationFactorsAutoRescore: viper.GetBool("RECOMMENDATION_FACTORS_AUTO_RESCORE"),
+	}
+
+	// Sin límite explícito, las sincronizaciones programadas usan el máximo de iteraciones
+	if config.SyncScheduleLimit <= 0 {
+		config.SyncScheduleLimit = config.SyncMaxIterations
 	}
+
+	return config
 }
 
-// loadRecommendationFactorsConfig carga los factores de recomendación en la configuración
+// loadRecommendationFactorsConfig carga los factores de recomendación en la configuración.
+// Si el archivo no existe se usa el modelo por defecto; si es inválido la aplicación no inicia.
 func loadRecommendationFactorsConfig(config *Config) {
-	factorsPath := viper.GetString("RECOMMENDATION_FACTORS_PATH")
+	factorsPath := config.RecommendationFactorsPath
 	factors, err := loadRecommendationFactors(factorsPath)
-	if err != nil {
-		log.Printf("ℹ️ Factores de recomendación no disponibles: %v", err)
-	} else {
-		config.RecommendationFactors = factors
-		log.Printf("✅ Factores de recomendación cargados: %d compañías, %d brokerages",
-			len(factors.Companies), len(factors.Brokerages))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Printf("ℹ️ Factores de recomendación no disponibles (%s no encontrado). Se usará el modelo por defecto", factorsPath)
+		config.SetRecommendationFactors(DefaultRecommendationFactors())
+	case err != nil:
+		log.Fatalf("❌ Error en los factores de recomendación (%s): %v", factorsPath, err)
+	default:
+		config.SetRecommendationFactors(factors)
+		log.Printf("✅ Factores de recomendación cargados: %d compañías, %d brokerages, %d calificaciones, %d acciones",
+			len(factors.Companies), len(factors.Brokerages), len(factors.RatingScores), len(factors.ActionPatterns))
 	}
 }
 
-// loadRecommendationFactors carga los factores de recomendación desde un archivo JSON
-func loadRecommendationFactors(path string) (*RecommendationFactors, error) {
-	// Verificar si el archivo existe
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, fmt.Errorf("archivo no encontrado")
+// RecommendationFactors devuelve el modelo de puntuación vigente, o el modelo por defecto si no se cargó ninguno.
+// Es seguro llamarlo mientras otro goroutine recarga los factores.
+func (c *Config) RecommendationFactors() *RecommendationFactors {
+	if factors := c.recommendationFactors.Load(); factors != nil {
+		return factors
 	}
+	return DefaultRecommendationFactors()
+}
 
-	// Leer el archivo
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("error al leer el archivo: %w", err)
+// SetRecommendationFactors reemplaza de forma atómica el modelo de puntuación leído del archivo.
+// Si hay factores administrados (SetFactorOverrides), estos reemplazan a los de empresas y brokerages del archivo.
+// El modelo no debe modificarse después de publicarlo.
+func (c *Config) SetRecommendationFactors(factors *RecommendationFactors) {
+	c.factorsMu.Lock()
+	defer c.factorsMu.Unlock()
+
+	c.fileFactors = factors
+	c.publishRecommendationFactors()
+}
+
+// FileRecommendationFactors devuelve el modelo de puntuación tal como se leyó del archivo,
+// sin los factores administrados, o el modelo por defecto si no se cargó ninguno.
+func (c *Config) FileRecommendationFactors() *RecommendationFactors {
+	c.factorsMu.Lock()
+	defer c.factorsMu.Unlock()
+
+	if c.fileFactors == nil {
+		return DefaultRecommendationFactors()
 	}
+	return c.fileFactors
+}
 
-	// Decodificar JSON
-	var factors RecommendationFactors
-	if err := json.Unmarshal(data, &factors); err != nil {
-		return nil, fmt.Errorf("error al decodificar JSON: %w", err)
+// SetFactorOverrides reemplaza de forma atómica los factores de empresas y brokerages del modelo vigente.
+// Con nil se vuelven a usar los del archivo.
+func (c *Config) SetFactorOverrides(overrides *FactorOverrides) {
+	c.factorsMu.Lock()
+	defer c.factorsMu.Unlock()
+
+	c.factorOverrides = overrides
+	c.publishRecommendationFactors()
+}
+
+// publishRecommendationFactors combina el modelo del archivo con los factores administrados y lo publica.
+// Debe llamarse con factorsMu tomado.
+func (c *Config) publishRecommendationFactors() {
+	factors := c.fileFactors
+	if factors == nil {
+		factors = DefaultRecommendationFactors()
+	}
+
+	if c.factorOverrides != nil {
+		merged := *factors
+		merged.Companies = c.factorOverrides.Companies
+		merged.Brokerages = c.factorOverrides.Brokerages
+		factors = &merged
 	}
 
-	return &factors, nil
+	c.recommendationFactors.Store(factors)
+}
+
+// ReloadRecommendationFactors vuelve a leer el archivo de factores y, si es válido, reemplaza el modelo vigente.
+// Devuelve el modelo vigente resultante. Si el archivo no existe o es inválido, el modelo vigente se conserva
+// y se devuelve el error.
+func (c *Config) ReloadRecommendationFactors() (*RecommendationFactors, error) {
+	factors, err := loadRecommendationFactors(c.RecommendationFactorsPath)
+	if err != nil {
+		return nil, err
+	}
+	c.SetRecommendationFactors(factors)
+	return c.RecommendationFactors(), nil
 }
 
 // validateConfig verifica que los valores críticos no estén vacíos.
@@ -123,15 +239,42 @@ func validateConfig(cfg *Config) error {
 	if cfg.DatabaseURL == "" {
 		return errors.New("DATABASE_URL no puede estar vacío")
 	}
-	if cfg.StockAPIURL == "" {
-		return errors.New("STOCK_API_URL no puede estar vacío")
-	}
-	if cfg.StockAuthTkn == "" {
-		return errors.New("STOCK_AUTH_TKN no puede estar vacío")
+	switch cfg.StockProvider {
+	case "api":
+		if cfg.StockAPIURL == "" {
+			return errors.New("STOCK_API_URL no puede estar vacío")
+		}
+		if cfg.StockAuthTkn == "" {
+			return errors.New("STOCK_AUTH_TKN no puede estar vacío")
+		}
+	case "file":
+		if cfg.StockProviderFile == "" {
+			return errors.New("STOCK_PROVIDER_FILE no puede estar vacío si STOCK_PROVIDER es file")
+		}
+	default:
+		return fmt.Errorf("STOCK_PROVIDER debe ser api o file, se recibió %q", cfg.StockProvider)
 	}
 	if cfg.SyncTimeout <= 0 {
 		return errors.New("SYNC_TIMEOUT debe ser mayor que 0")
 	}
+	if cfg.SyncWorkers <= 0 {
+		return errors.New("SYNC_WORKERS debe ser mayor que 0")
+	}
+	if cfg.SyncBatchSize <= 0 {
+		return errors.New("SYNC_BATCH_SIZE debe ser mayor que 0")
+	}
+	if cfg.APIMaxRetries < 0 {
+		return errors.New("API_MAX_RETRIES no puede ser negativo")
+	}
+	if cfg.APIRetryBaseDelayMs <= 0 || cfg.APIRetryMaxDelayMs < cfg.APIRetryBaseDelayMs {
+		return errors.New("API_RETRY_BASE_DELAY_MS debe ser mayor que 0 y no superar API_RETRY_MAX_DELAY_MS")
+	}
+	if cfg.APIRateLimitRPS < 0 {
+		return errors.New("API_RATE_LIMIT_RPS no puede ser negativo")
+	}
+	if cfg.APIRateLimitRPS > 0 && cfg.APIRateLimitBurst <= 0 {
+		return errors.New("API_RATE_LIMIT_BURST debe ser mayor que 0")
+	}
 	return nil
 }
 
@@ -140,10 +283,32 @@ func logConfig(cfg *Config) {
 	log.Println("📋 Configuración cargada:")
 	log.Printf("   - Servidor: %s", cfg.Address)
 	log.Printf("   - DB: %s", maskString(cfg.DatabaseURL))
-	log.Printf("   - API URL: %s", cfg.StockAPIURL)
+	if cfg.StockProvider == "file" {
+		log.Printf("   - Fuente de stocks: archivo %s", cfg.StockProviderFile)
+	} else {
+		log.Printf("   - Fuente de stocks: API %s", cfg.StockAPIURL)
+	}
 	log.Printf("   - Max Iteraciones: %d", cfg.SyncMaxIterations)
 	log.Printf("   - Timeout: %d segundos", cfg.SyncTimeout)
+	log.Printf("   - Eliminar ausentes: %t", cfg.SyncRemoveMissing)
+	log.Printf("   - Workers de sincronización: %d (lotes de %d stocks)", cfg.SyncWorkers, cfg.SyncBatchSize)
+	if cfg.SyncSchedule != "" {
+		log.Printf("   - Sincronización programada: %s (limit=%d)", cfg.SyncSchedule, cfg.SyncScheduleLimit)
+	}
+	log.Printf("   - Reintentos API: %d (espera %d-%d ms)", cfg.APIMaxRetries, cfg.APIRetryBaseDelayMs, cfg.APIRetryMaxDelayMs)
+	if cfg.APIRateLimitRPS > 0 {
+		log.Printf("   - Límite API: %.2f req/s (ráfaga %d)", cfg.APIRateLimitRPS, cfg.APIRateLimitBurst)
+	} else {
+		log.Println("   - Límite API: deshabilitado")
+	}
+	log.Printf("   - Factores de recomendación: %s (recarga automática: %t, recálculo automático: %t)",
+		cfg.RecommendationFactorsPath, cfg.RecommendationFactorsWatch, cfg.RecommendationFactorsAutoRescore)
 	log.Printf("   - CORS: %s", cfg.CORSAllowedOrigins)
+	if cfg.AdminToken != "" {
+		log.Printf("   - Token de administración: %s", maskString(cfg.AdminToken))
+	} else {
+		log.Println("   - Token de administración: no configurado (rutas /admin deshabilitadas)")
+	}
 }
 
 // maskString oculta parte de una cadena para seguridad.
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/fx"
+)
+
+// factorsReloadDebounce es el tiempo de espera tras el último cambio del archivo antes de recargarlo,
+// para no leer archivos a medio escribir cuando un editor genera varios eventos seguidos
+const factorsReloadDebounce = 250 * time.Millisecond
+
+// FactorsWatcher vigila el archivo de factores de recomendación y recarga el modelo de puntuación
+// cuando cambia. Un archivo inválido se rechaza y se conserva el modelo vigente.
+type FactorsWatcher struct {
+	cfg      *Config
+	debounce time.Duration
+
+	mu        sync.Mutex
+	listeners []func(*RecommendationFactors)
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewFactorsWatcher crea el vigilante del archivo de factores.
+// Se inicia y detiene junto con la aplicación si RECOMMENDATION_FACTORS_WATCH está habilitado.
+func NewFactorsWatcher(lc fx.Lifecycle, cfg *Config) *FactorsWatcher {
+	w := &FactorsWatcher{
+		cfg:      cfg,
+		debounce: factorsReloadDebounce,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if !cfg.RecommendationFactorsWatch {
+				return nil
+			}
+			return w.start()
+		},
+		OnStop: func(ctx context.Context) error {
+			return w.stop(ctx)
+		},
+	})
+
+	return w
+}
+
+// OnChange registra una función que se invoca cada vez que se aplica un nuevo modelo de puntuación.
+func (w *FactorsWatcher) OnChange(fn func(*RecommendationFactors)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, fn)
+}
+
+// start comienza a vigilar el directorio del archivo de factores.
+// Se vigila el directorio y no el archivo para detectar también los reemplazos (escritura atómica por renombrado).
+func (w *FactorsWatcher) start() error {
+	path, err := filepath.Abs(w.cfg.RecommendationFactorsPath)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		log.Printf("⚠️ No se puede vigilar %s, la recarga automática de factores queda deshabilitada: %v", path, err)
+		return nil
+	}
+
+	w.watcher = watcher
+	w.done = make(chan struct{})
+	go w.run(path)
+
+	log.Printf("👀 Vigilando cambios en %s", path)
+	return nil
+}
+
+// stop deja de vigilar el archivo y espera a que termine el procesamiento de eventos
+func (w *FactorsWatcher) stop(ctx context.Context) error {
+	if w.watcher == nil {
+		return nil
+	}
+
+	if err := w.watcher.Close(); err != nil {
+		return err
+	}
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run procesa los eventos del directorio hasta que se cierre el vigilante
+func (w *FactorsWatcher) run(path string) {
+	defer close(w.done)
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			// Ignorar otros archivos del directorio y los cambios de permisos
+			if filepath.Clean(event.Name) != path || event.Op == fsnotify.Chmod {
+				continue
+			}
+			reload = time.After(w.debounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("⚠️ Error vigilando el archivo de factores: %v", err)
+		case <-reload:
+			reload = nil
+			w.reload()
+		}
+	}
+}
+
+// reload vuelve a cargar el archivo de factores y, si es válido, notifica a los interesados
+func (w *FactorsWatcher) reload() {
+	path := w.cfg.RecommendationFactorsPath
+
+	factors, err := w.cfg.ReloadRecommendationFactors()
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Printf("⚠️ El archivo de factores %s ya no existe. Se conserva el modelo vigente", path)
+		return
+	case err != nil:
+		log.Printf("❌ Factores de recomendación inválidos (%s), se conserva el modelo vigente: %v", path, err)
+		return
+	}
+
+	log.Printf("✅ Factores de recomendación recargados desde %s: %d empresas y %d brokerages",
+		path, len(factors.Companies), len(factors.Brokerages))
+
+	w.mu.Lock()
+	listeners := make([]func(*RecommendationFactors), len(w.listeners))
+	copy(listeners, w.listeners)
+	w.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(factors)
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFactorsWatcher_Reload verifica que un archivo válido reemplace el modelo vigente
+// y que un archivo inválido se rechace conservando el anterior
+func TestFactorsWatcher_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recommendation_factors.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"companies": {"AAPL": 10}}`), 0o644))
+
+	cfg := &Config{RecommendationFactorsPath: path}
+	_, err := cfg.ReloadRecommendationFactors()
+	require.NoError(t, err)
+
+	changes := make(chan *RecommendationFactors, 1)
+	w := &FactorsWatcher{cfg: cfg, debounce: 10 * time.Millisecond}
+	w.OnChange(func(factors *RecommendationFactors) {
+		changes <- factors
+	})
+	require.NoError(t, w.start())
+	defer w.stop(context.Background())
+
+	// Un archivo válido se aplica y se notifica
+	require.NoError(t, os.WriteFile(path, []byte(`{"companies": {"AAPL": 20}}`), 0o644))
+	select {
+	case factors := <-changes:
+		assert.Equal(t, 20.0, factors.Companies["AAPL"])
+		assert.Same(t, factors, cfg.RecommendationFactors())
+	case <-time.After(5 * time.Second):
+		t.Fatal("No se recargaron los factores")
+	}
+
+	// Un archivo inválido se rechaza y se conserva el modelo anterior
+	require.NoError(t, os.WriteFile(path, []byte(`{"weights": {"rating": -1}}`), 0o644))
+	select {
+	case <-changes:
+		t.Fatal("No se debe notificar un modelo inválido")
+	case <-time.After(200 * time.Millisecond):
+	}
+	assert.Equal(t, 20.0, cfg.RecommendationFactors().Companies["AAPL"])
+}
+
+// TestConfig_RecommendationFactorsDefault verifica que sin factores cargados se use el modelo por defecto
+func TestConfig_RecommendationFactorsDefault(t *testing.T) {
+	cfg := &Config{}
+	assert.Equal(t, DefaultRecommendationFactors(), cfg.RecommendationFactors())
+}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ScoreWeights contiene la ponderación de cada componente del puntaje de recomendación.
+type ScoreWeights struct {
+	PercentDiff   float64 `json:"percent_diff"`   // Cambio porcentual en precio objetivo
+	Rating        float64 `json:"rating"`         // Calificación del analista
+	Action        float64 `json:"action"`         // Tipo de acción tomada
+	AbsoluteBonus float64 `json:"absolute_bonus"` // Magnitud absoluta del cambio
+}
+
+// ScoreModifiers contiene los factores de ajuste aplicados según el contexto del stock.
+type ScoreModifiers struct {
+	DecreasingTarget float64 `json:"decreasing_target"` // Factor para precios objetivo decrecientes
+	NegativeRating   float64 `json:"negative_rating"`   // Factor para calificaciones negativas con score positivo
+}
+
+// RecommendationFactors contiene el modelo de puntuación: factores para empresas y brokerages,
+// ponderaciones, puntuaciones de calificaciones y acciones, y modificadores de contexto.
+type RecommendationFactors struct {
+	Companies       map[string]float64 `json:"companies"`
+	Brokerages      map[string]float64 `json:"brokerages"`
+	Weights         ScoreWeights       `json:"weights"`
+	RatingScores    map[string]float64 `json:"rating_scores"`
+	ActionPatterns  map[string]float64 `json:"action_patterns"`
+	NegativeRatings []string           `json:"negative_ratings"`
+	Modifiers       ScoreModifiers     `json:"modifiers"`
+
+	sortedActionPatterns []string // ActionPatterns ordenados al construir el modelo, ver SortedActionPatterns
+}
+
+// FactorOverrides contiene los factores de empresas y brokerages administrados fuera del archivo,
+// que reemplazan a los del archivo en el modelo vigente.
+type FactorOverrides struct {
+	Companies  map[string]float64
+	Brokerages map[string]float64
+}
+
+// recommendationFactorsFile es el formato del archivo JSON. Weights y Modifiers apuntan a los valores
+// por defecto antes de decodificar, para que cada campo ausente conserve su valor por defecto.
+type recommendationFactorsFile struct {
+	Companies       map[string]float64 `json:"companies"`
+	Brokerages      map[string]float64 `json:"brokerages"`
+	Weights         *ScoreWeights      `json:"weights"`
+	RatingScores    map[string]float64 `json:"rating_scores"`
+	ActionPatterns  map[string]float64 `json:"action_patterns"`
+	NegativeRatings []string           `json:"negative_ratings"`
+	Modifiers       *ScoreModifiers    `json:"modifiers"`
+}
+
+// DefaultRecommendationFactors devuelve el modelo de puntuación por defecto, sin factores de empresas ni brokerages.
+func DefaultRecommendationFactors() *RecommendationFactors {
+	factors := &RecommendationFactors{
+		Companies:  map[string]float64{},
+		Brokerages: map[string]float64{},
+		Weights: ScoreWeights{
+			PercentDiff:   0.35,
+			Rating:        0.30,
+			Action:        0.20,
+			AbsoluteBonus: 0.15,
+		},
+		RatingScores: map[string]float64{
+			// Calificaciones muy positivas
+			"strong-buy": 25,
+			"strong buy": 25,
+			"buy":        20,
+			"outperform": 18,
+			"overweight": 15,
+
+			// Calificaciones positivas moderadas
+			"accumulate":        12,
+			"add":               12,
+			"sector outperform": 10,
+
+			// Calificaciones neutrales
+			"market perform": 5,
+			"sector perform": 5,
+			"equal weight":   5,
+			"in-line":        5,
+			"hold":           0,
+			"neutral":        0,
+
+			// Calificaciones negativas
+			"sector weight": -5,
+			"market weight": -5,
+			"underperform":  -10,
+			"underweight":   -10,
+			"reduce":        -15,
+			"sell":          -20,
+			"strong sell":   -25,
+		},
+		ActionPatterns: map[string]float64{
+			"upgraded by":       15,  // Mejora en la calificación
+			"target raised by":  12,  // Aumento del precio objetivo
+			"initiated by":      8,   // Nueva cobertura
+			"reiterated by":     5,   // Reiteración
+			"target set by":     3,   // Establecimiento de precio objetivo
+			"target lowered by": -10, // Reducción del precio objetivo
+			"downgraded by":     -12, // Degradación en la calificación
+		},
+		NegativeRatings: []string{"underperform", "underweight", "reduce", "sell", "strong sell"},
+		Modifiers: ScoreModifiers{
+			DecreasingTarget: 0.4,
+			NegativeRating:   0.6,
+		},
+	}
+	factors.sortedActionPatterns = sortActionPatterns(factors.ActionPatterns)
+	return factors
+}
+
+// IsNegativeRating indica si una calificación (sin normalizar) es considerada negativa.
+func (f *RecommendationFactors) IsNegativeRating(rating string) bool {
+	normalized := normalizeKey(rating)
+	for _, negative := range f.NegativeRatings {
+		if negative == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// SortedActionPatterns devuelve los patrones de acción del más largo al más corto,
+// para que la búsqueda por fragmento sea determinista cuando varios coinciden.
+// Los modelos cargados o por defecto ya los tienen ordenados; el slice devuelto no debe modificarse.
+func (f *RecommendationFactors) SortedActionPatterns() []string {
+	if f.sortedActionPatterns != nil {
+		return f.sortedActionPatterns
+	}
+	return sortActionPatterns(f.ActionPatterns)
+}
+
+// sortActionPatterns ordena los patrones de acción del más largo al más corto y alfabéticamente entre iguales
+func sortActionPatterns(actionPatterns map[string]float64) []string {
+	patterns := make([]string, 0, len(actionPatterns))
+	for pattern := range actionPatterns {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	return patterns
+}
+
+// loadRecommendationFactors carga los factores de recomendación desde un archivo JSON.
+// Las claves ausentes toman los valores por defecto de DefaultRecommendationFactors.
+func loadRecommendationFactors(path string) (*RecommendationFactors, error) {
+	// Verificar si el archivo existe
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, os.ErrNotExist
+	}
+
+	// Leer el archivo
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el archivo: %w", err)
+	}
+
+	return parseRecommendationFactors(data)
+}
+
+// parseRecommendationFactors decodifica, completa con valores por defecto y valida los factores de recomendación
+func parseRecommendationFactors(data []byte) (*RecommendationFactors, error) {
+	// Decodificar JSON; las ponderaciones y los modificadores se combinan campo a campo con los valores por defecto
+	factors := DefaultRecommendationFactors()
+	file := recommendationFactorsFile{Weights: &factors.Weights, Modifiers: &factors.Modifiers}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error al decodificar JSON: %w", err)
+	}
+
+	// Completar las claves ausentes con los valores por defecto
+	if file.Companies != nil {
+		factors.Companies = file.Companies
+	}
+	if file.Brokerages != nil {
+		factors.Brokerages = file.Brokerages
+	}
+	if file.RatingScores != nil {
+		factors.RatingScores = normalizeKeys(file.RatingScores)
+	}
+	if file.ActionPatterns != nil {
+		factors.ActionPatterns = normalizeKeys(file.ActionPatterns)
+		factors.sortedActionPatterns = sortActionPatterns(factors.ActionPatterns)
+	}
+	if file.NegativeRatings != nil {
+		factors.NegativeRatings = make([]string, len(file.NegativeRatings))
+		for i, rating := range file.NegativeRatings {
+			factors.NegativeRatings[i] = normalizeKey(rating)
+		}
+	}
+
+	if err := validateRecommendationFactors(factors); err != nil {
+		return nil, err
+	}
+
+	return factors, nil
+}
+
+// validateRecommendationFactors verifica que el modelo de puntuación sea coherente
+func validateRecommendationFactors(f *RecommendationFactors) error {
+	w := f.Weights
+	if w.PercentDiff < 0 || w.Rating < 0 || w.Action < 0 || w.AbsoluteBonus < 0 {
+		return errors.New("las ponderaciones (weights) no pueden ser negativas")
+	}
+	if w.PercentDiff+w.Rating+w.Action+w.AbsoluteBonus <= 0 {
+		return errors.New("al menos una ponderación (weights) debe ser mayor que 0")
+	}
+	if f.Modifiers.DecreasingTarget < 0 || f.Modifiers.NegativeRating < 0 {
+		return errors.New("los factores de ajuste (modifiers) no pueden ser negativos")
+	}
+	for rating := range f.RatingScores {
+		if rating == "" {
+			return errors.New("rating_scores contiene una calificación vacía")
+		}
+	}
+	for pattern := range f.ActionPatterns {
+		if pattern == "" {
+			return errors.New("action_patterns contiene un patrón vacío")
+		}
+	}
+	for _, rating := range f.NegativeRatings {
+		if rating == "" {
+			return errors.New("negative_ratings contiene una calificación vacía")
+		}
+	}
+	for ticker, factor := range f.Companies {
+		if factor <= -100 {
+			return fmt.Errorf("el factor de la compañía %q debe ser mayor que -100", ticker)
+		}
+	}
+	for brokerage, factor := range f.Brokerages {
+		if factor <= -100 {
+			return fmt.Errorf("el factor del brokerage %q debe ser mayor que -100", brokerage)
+		}
+	}
+	return nil
+}
+
+// normalizeKeys normaliza las claves de calificaciones y acciones (minúsculas y sin espacios extremos)
+func normalizeKeys(values map[string]float64) map[string]float64 {
+	normalized := make(map[string]float64, len(values))
+	for key, value := range values {
+		normalized[normalizeKey(key)] = value
+	}
+	return normalized
+}
+
+// normalizeKey normaliza una calificación o acción para compararla
+func normalizeKey(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
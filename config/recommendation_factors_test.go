@@ -0,0 +1,113 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseRecommendationFactors_Defaults verifica que las claves ausentes conserven el modelo por defecto
+func TestParseRecommendationFactors_Defaults(t *testing.T) {
+	factors, err := parseRecommendationFactors([]byte(`{"companies": {"AAPL": 10}, "brokerages": {"Citigroup": 5}}`))
+
+	assert.NoError(t, err)
+	defaults := DefaultRecommendationFactors()
+	assert.Equal(t, map[string]float64{"AAPL": 10}, factors.Companies)
+	assert.Equal(t, map[string]float64{"Citigroup": 5}, factors.Brokerages)
+	assert.Equal(t, defaults.Weights, factors.Weights)
+	assert.Equal(t, defaults.RatingScores, factors.RatingScores)
+	assert.Equal(t, defaults.ActionPatterns, factors.ActionPatterns)
+	assert.Equal(t, defaults.NegativeRatings, factors.NegativeRatings)
+	assert.Equal(t, defaults.Modifiers, factors.Modifiers)
+}
+
+// TestParseRecommendationFactors_Overrides verifica que las claves presentes reemplacen a las por defecto
+func TestParseRecommendationFactors_Overrides(t *testing.T) {
+	factors, err := parseRecommendationFactors([]byte(`{
+		"weights": {"percent_diff": 0.5, "rating": 0.5, "action": 0, "absolute_bonus": 0},
+		"rating_scores": {" Buy ": 30},
+		"action_patterns": {"Upgraded By": 20},
+		"negative_ratings": ["SELL"],
+		"modifiers": {"decreasing_target": 0.5, "negative_rating": 1}
+	}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, ScoreWeights{PercentDiff: 0.5, Rating: 0.5}, factors.Weights)
+	assert.Equal(t, map[string]float64{"buy": 30}, factors.RatingScores, "Las claves deben normalizarse")
+	assert.Equal(t, map[string]float64{"upgraded by": 20}, factors.ActionPatterns)
+	assert.True(t, factors.IsNegativeRating("Sell"))
+	assert.False(t, factors.IsNegativeRating("Underweight"))
+	assert.Equal(t, ScoreModifiers{DecreasingTarget: 0.5, NegativeRating: 1}, factors.Modifiers)
+	assert.Empty(t, factors.Companies)
+}
+
+// TestParseRecommendationFactors_PartialWeights verifica que las ponderaciones y los modificadores
+// ausentes conserven su valor por defecto cuando el archivo indica solo algunos
+func TestParseRecommendationFactors_PartialWeights(t *testing.T) {
+	factors, err := parseRecommendationFactors([]byte(`{
+		"weights": {"rating": 0.5},
+		"modifiers": {"negative_rating": 1}
+	}`))
+
+	assert.NoError(t, err)
+	defaults := DefaultRecommendationFactors()
+	expectedWeights := defaults.Weights
+	expectedWeights.Rating = 0.5
+	assert.Equal(t, expectedWeights, factors.Weights)
+	assert.Equal(t, ScoreModifiers{DecreasingTarget: defaults.Modifiers.DecreasingTarget, NegativeRating: 1}, factors.Modifiers)
+}
+
+// TestParseRecommendationFactors_Invalid verifica que se rechacen modelos incoherentes
+func TestParseRecommendationFactors_Invalid(t *testing.T) {
+	testCases := map[string]string{
+		"JSON inválido":         `{"weights": `,
+		"Ponderación negativa":  `{"weights": {"percent_diff": -1, "rating": 1}}`,
+		"Ponderaciones en cero": `{"weights": {"percent_diff": 0, "rating": 0, "action": 0, "absolute_bonus": 0}}`,
+		"Modificador negativo":  `{"modifiers": {"decreasing_target": -0.1}}`,
+		"Calificación vacía":    `{"rating_scores": {" ": 5}}`,
+		"Patrón vacío":          `{"action_patterns": {"": 5}}`,
+		"Factor fuera de rango": `{"companies": {"AAPL": -100}}`,
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseRecommendationFactors([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestSortedActionPatterns verifica que los patrones más específicos se evalúen primero
+func TestSortedActionPatterns(t *testing.T) {
+	factors := &RecommendationFactors{ActionPatterns: map[string]float64{"by": 1, "target raised by": 2, "raised by": 3}}
+
+	assert.Equal(t, []string{"target raised by", "raised by", "by"}, factors.SortedActionPatterns())
+
+	// Los modelos leídos del archivo guardan los patrones ya ordenados
+	parsed, err := parseRecommendationFactors([]byte(`{"action_patterns": {"By": 1, "Raised By": 3}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"raised by", "by"}, parsed.sortedActionPatterns)
+	assert.Equal(t, []string{"raised by", "by"}, parsed.SortedActionPatterns())
+}
+
+// TestFactorOverrides verifica que los factores administrados reemplacen a los del archivo,
+// también después de recargarlo
+func TestFactorOverrides(t *testing.T) {
+	cfg := &Config{}
+	fileFactors := DefaultRecommendationFactors()
+	fileFactors.Companies = map[string]float64{"AAPL": 10}
+	cfg.SetRecommendationFactors(fileFactors)
+
+	cfg.SetFactorOverrides(&FactorOverrides{
+		Companies:  map[string]float64{"MSFT": 5},
+		Brokerages: map[string]float64{},
+	})
+	assert.Equal(t, map[string]float64{"MSFT": 5}, cfg.RecommendationFactors().Companies)
+
+	reloaded := DefaultRecommendationFactors()
+	reloaded.Weights.PercentDiff = 1
+	cfg.SetRecommendationFactors(reloaded)
+	assert.Equal(t, map[string]float64{"MSFT": 5}, cfg.RecommendationFactors().Companies)
+	assert.Equal(t, 1.0, cfg.RecommendationFactors().Weights.PercentDiff)
+	assert.Same(t, reloaded, cfg.FileRecommendationFactors())
+}
@@ -1,17 +1,20 @@
 package database
 
 import (
+	"context"
 	"log"
 
 	"github.com/julianloaiza/stock-advisor/config"
 	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"go.uber.org/fx"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 // New crea una nueva conexión a la base de datos y migra el esquema.
-func New(cfg *config.Config) *gorm.DB {
+// La conexión se cierra al detener la aplicación, después de los componentes que dependen de ella.
+func New(lc fx.Lifecycle, cfg *config.Config) *gorm.DB {
 	// Configuración de GORM con nivel de log reducido
 	gormConfig := &gorm.Config{
 		// Usar Silent o Error para reducir drásticamente los logs
@@ -37,10 +40,25 @@ func New(cfg *config.Config) *gorm.DB {
 
 	// Auto-migrar el esquema
 	log.Println("🔄 Migrando esquema de base de datos...")
-	if err := db.AutoMigrate(&domain.Stock{}); err != nil {
+	if err := db.AutoMigrate(&domain.Stock{}, &domain.SyncJob{}, &domain.SyncCheckpoint{}, &domain.SyncReject{}, &domain.SyncRun{}, &domain.StockRatingHistory{}, &domain.RecommendationFactor{}, &domain.RecommendationFactorSeed{}); err != nil {
 		log.Fatalf("❌ Error en la migración: %v", err)
 	}
 
+	// Los rechazos de importaciones anteriores guardaban la línea del archivo en iteration
+	if err := db.Model(&domain.SyncReject{}).
+		Where("source = ? AND line = 0 AND iteration > 0", domain.RejectSourceImport).
+		Updates(map[string]interface{}{"line": gorm.Expr("iteration"), "iteration": 0}).Error; err != nil {
+		log.Fatalf("❌ Error en la migración de los rechazos de importaciones: %v", err)
+	}
+
+	// Cerrar la conexión al detener la aplicación
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			log.Println("🛑 Cerrando conexión a la base de datos...")
+			return sqlDB.Close()
+		},
+	})
+
 	log.Println("✅ Conexión exitosa a la base de datos y migración completada")
 	return db
 }
@@ -15,69 +15,91 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/stocks": {
+        "/admin/factors/brokerages": {
             "get": {
-                "description": "Recupera una lista filtrada y paginada de acciones bursátiles",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Devuelve los ajustes porcentuales aplicados al componente de calificación de cada brokerage",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stocks"
+                    "factors"
                 ],
-                "summary": "Obtener lista de stocks",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Texto de búsqueda general (ticker, company, brokerage, etc.)",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Número de página (por defecto: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
+                "summary": "Listar factores de brokerages",
+                "security": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Registros por página (por defecto: 10)",
-                        "name": "size",
-                        "in": "query"
+                        "AdminToken": []
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Factores de brokerages",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.RecommendationFactor"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Ordenar por puntaje de recomendación",
-                        "name": "recommends",
-                        "in": "query"
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
                     },
-                    {
-                        "type": "number",
-                        "description": "Valor mínimo del precio objetivo",
-                        "name": "minTargetTo",
-                        "in": "query"
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/factors/brokerages/{name}": {
+            "get": {
+                "description": "Devuelve el ajuste porcentual configurado para un brokerage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "factors"
+                ],
+                "summary": "Consultar el factor de un brokerage",
+                "security": [
                     {
-                        "type": "number",
-                        "description": "Valor máximo del precio objetivo",
-                        "name": "maxTargetTo",
-                        "in": "query"
-                    },
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
                     {
                         "type": "string",
-                        "default": "USD",
-                        "description": "Moneda de los precios (por defecto: USD)",
-                        "name": "currency",
-                        "in": "query"
+                        "description": "Nombre del brokerage",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Consulta de acciones exitosa",
+                        "description": "Factor del brokerage",
                         "schema": {
                             "allOf": [
                                 {
@@ -87,15 +109,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PaginatedData"
+                                            "$ref": "#/definitions/domain.RecommendationFactor"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Parámetros inválidos",
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Factor no encontrado",
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
@@ -107,11 +141,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/stocks/sync": {
-            "post": {
-                "description": "Actualiza la base de datos con información de acciones desde un servicio externo",
+            },
+            "put": {
+                "description": "Guarda el ajuste porcentual de un brokerage y lo aplica de inmediato al modelo de puntuación",
                 "consumes": [
                     "application/json"
                 ],
@@ -119,100 +151,3025 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stocks"
+                    "factors"
+                ],
+                "summary": "Crear o actualizar el factor de un brokerage",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
-                "summary": "Sincronizar stocks desde fuente externa",
                 "parameters": [
                     {
-                        "description": "Parámetros de sincronización",
+                        "type": "string",
+                        "description": "Nombre del brokerage",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Factor del brokerage",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/stocks.SyncRequest"
+                            "$ref": "#/definitions/factors.FactorRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sincronización exitosa",
+                        "description": "Factor guardado",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RecommendationFactor"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Error en la solicitud",
+                        "description": "Factor inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "500": {
-                        "description": "Error del servidor",
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "response.APIResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "description": "Código HTTP",
-                    "type": "integer"
-                },
-                "data": {
-                    "description": "Datos de respuesta"
-                },
-                "error": {
-                    "description": "Detalles del error",
-                    "type": "string"
-                },
-                "message": {
-                    "description": "Mensaje descriptivo",
-                    "type": "string"
+            },
+            "delete": {
+                "description": "Elimina el ajuste porcentual de un brokerage; sus calificaciones dejan de recibir ajuste",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "factors"
+                ],
+                "summary": "Eliminar el factor de un brokerage",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nombre del brokerage",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Factor eliminado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Factor no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
                 }
             }
         },
-        "response.PaginatedData": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "description": "Lista de ítems"
-                },
-                "page": {
-                    "description": "Número de página actual",
-                    "type": "integer"
-                },
-                "size": {
-                    "description": "Ítems por página",
-                    "type": "integer"
-                },
-                "total": {
-                    "description": "Total de ítems disponibles",
-                    "type": "integer"
+        "/admin/factors/companies": {
+            "get": {
+                "description": "Devuelve los ajustes porcentuales aplicados al componente de cambio porcentual de cada ticker",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "factors"
+                ],
+                "summary": "Listar factores de empresas",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Factores de empresas",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.RecommendationFactor"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
                 }
             }
         },
-        "stocks.SyncRequest": {
-            "type": "object",
-            "properties": {
-                "limit": {
-                    "description": "Número de iteraciones para la sincronización",
-                    "type": "integer",
-                    "minimum": 1,
+        "/admin/factors/companies/{ticker}": {
+            "get": {
+                "description": "Devuelve el ajuste porcentual configurado para un ticker",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "factors"
+                ],
+                "summary": "Consultar el factor de una empresa",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker de la empresa",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Factor de la empresa",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RecommendationFactor"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Factor no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Guarda el ajuste porcentual de un ticker y lo aplica de inmediato al modelo de puntuación",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "factors"
+                ],
+                "summary": "Crear o actualizar el factor de una empresa",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker de la empresa",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Factor de la empresa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/factors.FactorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Factor guardado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RecommendationFactor"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Factor inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina el ajuste porcentual de un ticker; sus stocks dejan de recibir ajuste",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "factors"
+                ],
+                "summary": "Eliminar el factor de una empresa",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker de la empresa",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Factor eliminado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Factor no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/brokerages": {
+            "get": {
+                "description": "Agrupa las acciones por brokerage: tickers cubiertos, upgrades y downgrades, cambio porcentual promedio del precio objetivo, puntaje promedio y factor configurado. Acepta los mismos filtros que GET /stocks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "brokerages"
+                ],
+                "summary": "Obtener el comportamiento por brokerage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texto de búsqueda general (ticker, company, brokerage, etc.)",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (por defecto: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Registros por página (por defecto: 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por puntaje de recomendación promedio",
+                        "name": "recommends",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo",
+                        "name": "minTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo",
+                        "name": "maxTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "USD",
+                        "description": "Moneda de los precios (por defecto: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por fecha de calificación más reciente",
+                        "name": "recent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tickers exactos, separados por coma",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brokerage exacto",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "upgraded",
+                                "downgraded",
+                                "target_raised",
+                                "target_lowered",
+                                "target_set",
+                                "initiated",
+                                "reiterated"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tipos de acción, separados por coma",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación nueva, separadas por coma",
+                        "name": "ratingTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación anterior, separadas por coma",
+                        "name": "ratingFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación mínimo",
+                        "name": "minScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación máximo",
+                        "name": "maxScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo anterior",
+                        "name": "minTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo anterior",
+                        "name": "maxTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual mínima del precio objetivo",
+                        "name": "minTargetChangePct",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual máxima del precio objetivo",
+                        "name": "maxTargetChangePct",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consulta de brokerages exitosa",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "content": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/domain.BrokerageSummary"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/brokerages/{name}/stocks": {
+            "get": {
+                "description": "Recupera las acciones de un brokerage, con los mismos filtros, orden y paginación que GET /stocks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "brokerages"
+                ],
+                "summary": "Obtener las acciones de un brokerage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nombre del brokerage",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texto de búsqueda general (ticker, company, brokerage, etc.)",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (por defecto: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Registros por página (por defecto: 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por puntaje de recomendación",
+                        "name": "recommends",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo",
+                        "name": "minTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo",
+                        "name": "maxTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "USD",
+                        "description": "Moneda de los precios (por defecto: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por fecha de calificación más reciente",
+                        "name": "recent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Orden por campos separados por coma, descendente con '-' (ej. -target_to,ticker); reemplaza a recommends y recent. Campos: id, ticker, company, target_to, currency, recommend_score, rated_at, target_change_pct",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tickers exactos, separados por coma",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "upgraded",
+                                "downgraded",
+                                "target_raised",
+                                "target_lowered",
+                                "target_set",
+                                "initiated",
+                                "reiterated"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tipos de acción, separados por coma",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación nueva, separadas por coma",
+                        "name": "ratingTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación anterior, separadas por coma",
+                        "name": "ratingFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación mínimo",
+                        "name": "minScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación máximo",
+                        "name": "maxScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo anterior",
+                        "name": "minTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo anterior",
+                        "name": "maxTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual mínima del precio objetivo",
+                        "name": "minTargetChangePct",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual máxima del precio objetivo",
+                        "name": "maxTargetChangePct",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consulta de acciones exitosa",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "content": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/domain.Stock"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks": {
+            "get": {
+                "description": "Recupera una lista filtrada y paginada de acciones bursátiles.\nCon format (o el encabezado Accept: text/csv, application/x-ndjson o el tipo de XLSX) exporta todos los stocks filtrados como archivo,\nsin paginación y enviando las filas a medida que se leen de la base de datos.\nCon cursor pagina por clave (keyset) en lugar de page: la respuesta incluye next_cursor y solo cuenta el total con total=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Obtener lista de stocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texto de búsqueda general (ticker, company, brokerage, etc.)",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (por defecto: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Registros por página (por defecto: 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por puntaje de recomendación",
+                        "name": "recommends",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo",
+                        "name": "minTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo",
+                        "name": "maxTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "USD",
+                        "description": "Moneda de los precios (por defecto: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por fecha de calificación más reciente",
+                        "name": "recent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Orden por campos separados por coma, descendente con '-' (ej. -target_to,ticker); reemplaza a recommends y recent. Campos: id, ticker, company, target_to, currency, recommend_score, rated_at, target_change_pct",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tickers exactos, separados por coma",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brokerage exacto",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "upgraded",
+                                "downgraded",
+                                "target_raised",
+                                "target_lowered",
+                                "target_set",
+                                "initiated",
+                                "reiterated"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tipos de acción, separados por coma",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación nueva, separadas por coma",
+                        "name": "ratingTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación anterior, separadas por coma",
+                        "name": "ratingFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación mínimo",
+                        "name": "minScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación máximo",
+                        "name": "maxScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo anterior",
+                        "name": "minTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo anterior",
+                        "name": "maxTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual mínima del precio objetivo",
+                        "name": "minTargetChangePct",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual máxima del precio objetivo",
+                        "name": "maxTargetChangePct",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Paginación por cursor: vacío para la primera página o el next_cursor de la respuesta anterior; no se combina con page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Incluir el total de registros en la paginación por cursor (por defecto: false)",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "xlsx"
+                        ],
+                        "description": "Formato de la respuesta; tiene prioridad sobre el encabezado Accept (por defecto: json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consulta de acciones exitosa, o el archivo exportado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PaginatedData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/import": {
+            "post": {
+                "description": "Importa calificaciones desde un archivo CSV (con encabezado) o NDJSON (un objeto por línea) con los campos de la API externa.\nCada fila se convierte y puntúa como en la sincronización y las filas inválidas se registran en los rechazos con la línea del archivo.\nLos stocks se guardan por lotes a medida que se leen; si la importación falla, los lotes ya guardados se conservan. Con mode=replace se eliminan además, después del último lote, los stocks que no están en el archivo; un archivo sin filas válidas no modifica los stocks",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Importar stocks desde un archivo",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Archivo CSV o NDJSON (máximo 32 MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "description": "Formato del archivo; por defecto se deduce de la extensión (.csv, .ndjson, .jsonl)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "enum": [
+                            "append",
+                            "replace"
+                        ],
+                        "description": "append (por defecto) agrega y actualiza; replace además elimina los stocks ausentes",
+                        "name": "mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resumen de la importación",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Archivo o parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Archivo demasiado grande",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/rescore": {
+            "post": {
+                "description": "Encola un recálculo por lotes del puntaje de todos los stocks guardados con los factores de recomendación actuales, sin sincronizar con la fuente externa. Se ejecuta en la cola de sincronización; al terminar, el job consultado en /stocks/sync/{id} registra en rescore cuántos puntajes cambiaron y la distribución antes y después",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Recalcular puntajes de recomendación",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Recálculo encolado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SyncJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cola de sincronización llena",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/sync": {
+            "post": {
+                "description": "Encola una sincronización con el servicio externo y devuelve el job para consultar su estado.\nCon dryRun, el job obtiene y procesa las páginas sin modificar la base de datos y al terminar registra en preview lo que cambiaría: totales, una muestra de stocks, los motivos de rechazo y la distribución de puntajes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Sincronizar stocks desde fuente externa",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "description": "Parámetros de sincronización",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stocks.SyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Sincronización encolada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SyncJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Error en la solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token de administración ausente o inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ADMIN_TOKEN no configurado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Cola de sincronización llena",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/sync/rejects": {
+            "get": {
+                "description": "Devuelve, del más reciente al más antiguo, los elementos de la API externa que no pudieron convertirse en stocks, con la iteración, el error y el contenido original",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Consultar elementos rechazados en las sincronizaciones",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (por defecto: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Registros por página (por defecto: 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consulta de rechazos exitosa",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "content": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/domain.SyncReject"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/sync/runs": {
+            "get": {
+                "description": "Devuelve, de la más reciente a la más antigua, cada ejecución de la sincronización: inicio y fin, límite solicitado y efectivo, motivo por el que terminó la paginación (empty_next_page, cycle o limit), totales y error si falló",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Consultar el historial de ejecuciones de la sincronización",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (por defecto: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Registros por página (por defecto: 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consulta de ejecuciones exitosa",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "content": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/domain.SyncRun"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/sync/runs/{id}/diff": {
+            "get": {
+                "description": "Devuelve los tickers agregados y eliminados respecto de la última ejecución anterior con historial, y los brokerages cuya calificación o precio objetivo cambió",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Comparar una ejecución de la sincronización con la anterior",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la ejecución",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Diferencias de la ejecución",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SyncRunDiff"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ejecución no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/sync/{id}": {
+            "get": {
+                "description": "Devuelve el estado (queued, running, succeeded, failed) y el progreso de un job de sincronización",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Consultar el estado de una sincronización",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del job de sincronización",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Estado de la sincronización",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SyncJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/score": {
+            "get": {
+                "description": "Devuelve los componentes del puntaje (cambio porcentual, calificación, acción y bonificación absoluta), los factores externos aplicados, las ponderaciones y los modificadores de contexto evaluados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocks"
+                ],
+                "summary": "Explicar el puntaje de recomendación de un stock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del stock",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Desglose del puntaje",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ScoreBreakdown"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickers": {
+            "get": {
+                "description": "Agrupa las acciones de los brokerages por ticker: cobertura, precio objetivo promedio/mediano/mínimo/máximo, distribución de calificaciones, upgrades netos y puntaje promedio. Acepta los mismos filtros que GET /stocks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickers"
+                ],
+                "summary": "Obtener el consenso por ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texto de búsqueda general (ticker, company, brokerage, etc.)",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (por defecto: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Registros por página (por defecto: 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por puntaje de recomendación promedio",
+                        "name": "recommends",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo",
+                        "name": "minTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo",
+                        "name": "maxTargetTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "USD",
+                        "description": "Moneda de los precios (por defecto: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ordenar por fecha de calificación más reciente",
+                        "name": "recent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tickers exactos, separados por coma",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brokerage exacto",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "upgraded",
+                                "downgraded",
+                                "target_raised",
+                                "target_lowered",
+                                "target_set",
+                                "initiated",
+                                "reiterated"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tipos de acción, separados por coma",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación nueva, separadas por coma",
+                        "name": "ratingTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "buy",
+                                "hold",
+                                "sell",
+                                "other"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Categorías de la calificación anterior, separadas por coma",
+                        "name": "ratingFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación mínimo",
+                        "name": "minScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntaje de recomendación máximo",
+                        "name": "maxScore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo del precio objetivo anterior",
+                        "name": "minTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo del precio objetivo anterior",
+                        "name": "maxTargetFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual mínima del precio objetivo",
+                        "name": "minTargetChangePct",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Variación porcentual máxima del precio objetivo",
+                        "name": "maxTargetChangePct",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consulta de tickers exitosa",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "content": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/domain.TickerSummary"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickers/{ticker}": {
+            "get": {
+                "description": "Devuelve el resumen de consenso de un ticker junto con las acciones de los brokerages que lo cubren, de la más reciente a la más antigua",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickers"
+                ],
+                "summary": "Obtener el consenso de un ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "USD",
+                        "description": "Moneda de los precios (por defecto: USD)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consenso del ticker",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.TickerDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticker no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickers/{ticker}/history": {
+            "get": {
+                "description": "Devuelve las calificaciones y precios objetivo de un ticker observados en cada sincronización, de la más antigua a la más reciente. Permite ver cómo evolucionó la opinión de cada brokerage aunque la tabla de stocks se actualice",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickers"
+                ],
+                "summary": "Obtener el historial de calificaciones de un ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker",
+                        "name": "ticker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limitar el historial a un brokerage",
+                        "name": "brokerage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número de página (por defecto: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Registros por página (por defecto: 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Historial del ticker",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "content": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/domain.StockRatingHistory"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.BrokerageSummary": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Acciones registradas",
+                    "type": "integer"
+                },
+                "avg_score": {
+                    "description": "Puntaje de recomendación promedio",
+                    "type": "number"
+                },
+                "avg_target_change_pct": {
+                    "description": "Cambio porcentual promedio del precio objetivo",
+                    "type": "number"
+                },
+                "brokerage": {
+                    "example": "Goldman Sachs",
+                    "type": "string"
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "factor": {
+                    "description": "Factor configurado; null si no tiene",
+                    "type": "number"
+                },
+                "last_rated_at": {
+                    "type": "string"
+                },
+                "tickers": {
+                    "description": "Tickers distintos que cubre",
+                    "type": "integer"
+                },
+                "upgrade_ratio": {
+                    "description": "Proporción de upgrades sobre upgrades y downgrades (0 a 1); null si no hay ninguno",
+                    "type": "number",
+                    "x-nullable": true
+                },
+                "upgrades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportResult": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "inserted": {
+                    "description": "Stocks nuevos",
+                    "type": "integer"
+                },
+                "items_parsed": {
+                    "description": "Filas convertidas a stocks",
+                    "type": "integer"
+                },
+                "items_rejected": {
+                    "description": "Filas descartadas por errores de parseo",
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "removed": {
+                    "description": "Stocks eliminados por no estar en el archivo (solo replace)",
+                    "type": "integer"
+                },
+                "rows_read": {
+                    "description": "Filas leídas del archivo, sin contar el encabezado ni las líneas vacías",
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "description": "Stocks existentes sin cambios",
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Stocks existentes con contenido modificado",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RatingDistribution": {
+            "type": "object",
+            "properties": {
+                "buy": {
+                    "type": "integer"
+                },
+                "hold": {
+                    "type": "integer"
+                },
+                "other": {
+                    "description": "Calificaciones no reconocidas por el modelo de puntuación",
+                    "type": "integer"
+                },
+                "sell": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RecommendationFactor": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "description": "Ajuste porcentual, mayor que -100",
+                    "example": 10,
+                    "type": "number"
+                },
+                "key": {
+                    "description": "Ticker o nombre del brokerage",
+                    "example": "AAPL",
+                    "type": "string"
+                },
+                "kind": {
+                    "example": "company",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RescoreResult": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "description": "Distribución de puntajes después del recálculo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreDistribution"
+                        }
+                    ]
+                },
+                "batches": {
+                    "description": "Lotes procesados",
+                    "type": "integer"
+                },
+                "before": {
+                    "description": "Distribución de puntajes antes del recálculo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreDistribution"
+                        }
+                    ]
+                },
+                "changed": {
+                    "description": "Stocks cuyo puntaje cambió",
+                    "type": "integer"
+                },
+                "processed": {
+                    "description": "Stocks evaluados",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ScoreBreakdown": {
+            "type": "object",
+            "properties": {
+                "adjusted_components": {
+                    "description": "Componentes tras aplicar los factores externos",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreComponents"
+                        }
+                    ]
+                },
+                "brokerage": {
+                    "type": "string"
+                },
+                "components": {
+                    "description": "Componentes base",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreComponents"
+                        }
+                    ]
+                },
+                "contributions": {
+                    "description": "Aporte ponderado de cada componente",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreComponents"
+                        }
+                    ]
+                },
+                "factors": {
+                    "description": "Factores externos aplicados",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScoreFactor"
+                    }
+                },
+                "final_score": {
+                    "type": "number"
+                },
+                "modifiers": {
+                    "description": "Modificadores de contexto evaluados, en orden",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScoreModifier"
+                    }
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "stored_score": {
+                    "description": "Puntaje guardado en la última sincronización",
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "weighted_score": {
+                    "type": "number"
+                },
+                "weights": {
+                    "description": "Ponderación de cada componente",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreComponents"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.ScoreComponents": {
+            "type": "object",
+            "properties": {
+                "absolute_bonus": {
+                    "description": "Magnitud absoluta del cambio",
+                    "type": "number"
+                },
+                "action": {
+                    "description": "Tipo de acción tomada",
+                    "type": "number"
+                },
+                "percent_diff": {
+                    "description": "Cambio porcentual en precio objetivo",
+                    "type": "number"
+                },
+                "rating": {
+                    "description": "Calificación del analista",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.ScoreDistribution": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "p25": {
+                    "type": "number"
+                },
+                "p75": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.ScoreFactor": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "description": "Componente afectado",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Ticker o nombre del brokerage",
+                    "type": "string"
+                },
+                "multiplier": {
+                    "description": "Multiplicador aplicado al componente (1 + percent/100)",
+                    "type": "number"
+                },
+                "percent": {
+                    "description": "Ajuste porcentual configurado",
+                    "type": "number"
+                },
+                "source": {
+                    "description": "company o brokerage",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ScoreModifier": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "factor": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score_after": {
+                    "type": "number"
+                },
+                "score_before": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.Stock": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "brokerage": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rated_at": {
+                    "type": "string"
+                },
+                "rating_from": {
+                    "type": "string"
+                },
+                "rating_to": {
+                    "type": "string"
+                },
+                "recommend_score": {
+                    "type": "number"
+                },
+                "target_from": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.StockRatingHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "brokerage": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "observed_at": {
+                    "type": "string"
+                },
+                "rated_at": {
+                    "type": "string"
+                },
+                "rating_from": {
+                    "type": "string"
+                },
+                "rating_to": {
+                    "type": "string"
+                },
+                "recommend_score": {
+                    "type": "number"
+                },
+                "sync_run_id": {
+                    "description": "Ejecución de la sincronización que la observó",
+                    "type": "integer"
+                },
+                "target_from": {
+                    "type": "number"
+                },
+                "target_to": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SyncJob": {
+            "type": "object",
+            "properties": {
+                "api_attempts": {
+                    "description": "Solicitudes HTTP realizadas, incluyendo reintentos",
+                    "type": "integer"
+                },
+                "api_retries": {
+                    "description": "Reintentos por errores transitorios de la API externa",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inserted": {
+                    "description": "Stocks nuevos",
+                    "type": "integer"
+                },
+                "items_parsed": {
+                    "description": "Elementos convertidos a stocks",
+                    "type": "integer"
+                },
+                "items_rejected": {
+                    "description": "Elementos descartados por errores de parseo",
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "pages_fetched": {
+                    "description": "Páginas obtenidas de la API externa",
+                    "type": "integer"
+                },
+                "pages_resumed": {
+                    "description": "Páginas recuperadas de checkpoints al reanudar",
+                    "type": "integer"
+                },
+                "preview": {
+                    "description": "Resumen del dry run, al terminar",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SyncPreview"
+                        }
+                    ]
+                },
+                "removed": {
+                    "description": "Stocks que desaparecieron de la fuente externa",
+                    "type": "integer"
+                },
+                "rescore": {
+                    "description": "Resumen del recálculo, al terminar",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RescoreResult"
+                        }
+                    ]
+                },
+                "resume": {
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "description": "Stocks existentes sin cambios",
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Stocks existentes con contenido modificado",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SyncPreview": {
+            "type": "object",
+            "properties": {
+                "api_attempts": {
+                    "description": "Solicitudes HTTP realizadas, incluyendo reintentos",
+                    "type": "integer"
+                },
+                "api_retries": {
+                    "description": "Reintentos por errores transitorios de la API externa",
+                    "type": "integer"
+                },
+                "inserted": {
+                    "description": "Stocks nuevos",
+                    "type": "integer"
+                },
+                "items_parsed": {
+                    "description": "Elementos convertidos a stocks",
+                    "type": "integer"
+                },
+                "items_rejected": {
+                    "description": "Elementos descartados por errores de parseo",
+                    "type": "integer"
+                },
+                "pages_fetched": {
+                    "description": "Páginas obtenidas de la API externa",
+                    "type": "integer"
+                },
+                "pages_resumed": {
+                    "description": "Páginas recuperadas de checkpoints al reanudar",
+                    "type": "integer"
+                },
+                "reject_reasons": {
+                    "description": "Elementos rechazados por motivo",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "type": "object"
+                },
+                "removed": {
+                    "description": "Stocks que desaparecieron de la fuente externa",
+                    "type": "integer"
+                },
+                "samples": {
+                    "description": "Muestra de stocks que se insertarían o actualizarían",
+                    "items": {
+                        "$ref": "#/definitions/domain.SyncPreviewRow"
+                    },
+                    "type": "array"
+                },
+                "scores": {
+                    "description": "Distribución de los puntajes de los stocks obtenidos",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreDistribution"
+                        }
+                    ]
+                },
+                "stop_reason": {
+                    "description": "Motivo por el que terminó la paginación",
+                    "type": "string"
+                },
+                "unchanged": {
+                    "description": "Stocks existentes sin cambios",
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Stocks existentes con contenido modificado",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SyncPreviewRow": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "insert o update",
+                    "type": "string"
+                },
+                "stock": {
+                    "$ref": "#/definitions/domain.Stock"
+                }
+            }
+        },
+        "domain.SyncRatingChange": {
+            "type": "object",
+            "properties": {
+                "brokerage": {
+                    "type": "string"
+                },
+                "current_rating": {
+                    "type": "string"
+                },
+                "current_target": {
+                    "type": "number"
+                },
+                "previous_rating": {
+                    "type": "string"
+                },
+                "previous_target": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SyncReject": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "iteration": {
+                    "description": "Página en la que se recibió el elemento (sync)",
+                    "type": "integer"
+                },
+                "line": {
+                    "description": "Línea del archivo importado (import)",
+                    "type": "integer"
+                },
+                "raw_item": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "source": {
+                    "description": "sync o import",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SyncRun": {
+            "type": "object",
+            "properties": {
+                "api_attempts": {
+                    "description": "Solicitudes HTTP realizadas, incluyendo reintentos",
+                    "type": "integer"
+                },
+                "api_retries": {
+                    "description": "Reintentos por errores transitorios de la API externa",
+                    "type": "integer"
+                },
+                "effective_limit": {
+                    "description": "Límite aplicado tras validarlo con el máximo configurado",
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inserted": {
+                    "description": "Stocks nuevos",
+                    "type": "integer"
+                },
+                "items_parsed": {
+                    "description": "Elementos convertidos a stocks",
+                    "type": "integer"
+                },
+                "items_rejected": {
+                    "description": "Elementos descartados por errores de parseo",
+                    "type": "integer"
+                },
+                "pages_fetched": {
+                    "description": "Páginas obtenidas de la API externa",
+                    "type": "integer"
+                },
+                "pages_resumed": {
+                    "description": "Páginas recuperadas de checkpoints al reanudar",
+                    "type": "integer"
+                },
+                "removed": {
+                    "description": "Stocks que desaparecieron de la fuente externa",
+                    "type": "integer"
+                },
+                "requested_limit": {
+                    "type": "integer"
+                },
+                "resume": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "description": "sync o import",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "stop_reason": {
+                    "description": "empty_next_page, cycle o limit; vacío si falló antes de terminar la paginación",
+                    "type": "string"
+                },
+                "unchanged": {
+                    "description": "Stocks existentes sin cambios",
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Stocks existentes con contenido modificado",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SyncRunDiff": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Tickers que no estaban en la ejecución anterior",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "changed": {
+                    "description": "Calificaciones o precios objetivo que cambiaron",
+                    "items": {
+                        "$ref": "#/definitions/domain.SyncRatingChange"
+                    },
+                    "type": "array"
+                },
+                "previous_run_id": {
+                    "description": "null si no hay una sincronización completa anterior con historial",
+                    "type": "integer"
+                },
+                "removed": {
+                    "description": "Tickers que ya no están en esta ejecución",
+                    "items": {
+                        "type": "string"
+                    },
+                    "type": "array"
+                },
+                "run_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.TickerDetail": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Acciones de brokerages registradas",
+                    "type": "integer"
+                },
+                "brokerages": {
+                    "description": "Brokerages distintos que cubren el ticker",
+                    "type": "integer"
+                },
+                "company": {
+                    "example": "Apple Inc.",
+                    "type": "string"
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "last_rated_at": {
+                    "type": "string"
+                },
+                "net_upgrades": {
+                    "description": "Upgrades menos downgrades",
+                    "type": "integer"
+                },
+                "ratings": {
+                    "$ref": "#/definitions/domain.RatingDistribution"
+                },
+                "score": {
+                    "description": "Puntaje de recomendación promedio",
+                    "type": "number"
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Stock"
+                    }
+                },
+                "target_avg": {
+                    "description": "Precio objetivo (target_to) promedio",
+                    "type": "number"
+                },
+                "target_max": {
+                    "type": "number"
+                },
+                "target_median": {
+                    "description": "Precio objetivo mediano",
+                    "type": "number"
+                },
+                "target_min": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "example": "AAPL",
+                    "type": "string"
+                },
+                "upgrades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.TickerSummary": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Acciones de brokerages registradas",
+                    "type": "integer"
+                },
+                "brokerages": {
+                    "description": "Brokerages distintos que cubren el ticker",
+                    "type": "integer"
+                },
+                "company": {
+                    "example": "Apple Inc.",
+                    "type": "string"
+                },
+                "downgrades": {
+                    "type": "integer"
+                },
+                "last_rated_at": {
+                    "type": "string"
+                },
+                "net_upgrades": {
+                    "description": "Upgrades menos downgrades",
+                    "type": "integer"
+                },
+                "ratings": {
+                    "$ref": "#/definitions/domain.RatingDistribution"
+                },
+                "score": {
+                    "description": "Puntaje de recomendación promedio",
+                    "type": "number"
+                },
+                "target_avg": {
+                    "description": "Precio objetivo (target_to) promedio",
+                    "type": "number"
+                },
+                "target_max": {
+                    "type": "number"
+                },
+                "target_median": {
+                    "description": "Precio objetivo mediano",
+                    "type": "number"
+                },
+                "target_min": {
+                    "type": "number"
+                },
+                "ticker": {
+                    "example": "AAPL",
+                    "type": "string"
+                },
+                "upgrades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "factors.FactorRequest": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "description": "Ajuste porcentual, mayor que -100",
+                    "example": 10,
+                    "type": "number"
+                }
+            }
+        },
+        "response.APIResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Código HTTP",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "Datos de respuesta"
+                },
+                "error": {
+                    "description": "Detalles del error",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Mensaje descriptivo",
+                    "type": "string"
+                }
+            }
+        },
+        "response.PaginatedData": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Lista de ítems"
+                },
+                "next_cursor": {
+                    "description": "Cursor de la página siguiente; vacío en la última",
+                    "type": "string"
+                },
+                "page": {
+                    "description": "Número de página actual; solo sin cursor",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "Ítems por página",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total de ítems disponibles; opcional con cursor",
+                    "type": "integer"
+                }
+            }
+        },
+        "stocks.SyncRequest": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "Obtener y procesar las páginas sin guardar nada y registrar en el job lo que cambiaría",
+                    "example": false,
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Número de iteraciones para la sincronización",
+                    "type": "integer",
+                    "minimum": 1,
                     "example": 5
+                },
+                "resume": {
+                    "description": "Reanudar la última sincronización fallida desde su último checkpoint",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         }
     },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Token de administración (ADMIN_TOKEN) con el formato \"Bearer <token>\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    },
     "tags": [
         {
             "description": "Operaciones con acciones bursátiles",
             "name": "Stocks"
+        },
+        {
+            "description": "Administración de los factores de recomendación de empresas y brokerages",
+            "name": "Factors"
         }
     ]
 }`
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/database"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/repositories"
+	"github.com/julianloaiza/stock-advisor/internal/services"
+	"github.com/julianloaiza/stock-advisor/internal/services/stocks"
+	"go.uber.org/fx"
+)
+
+// runImport ejecuta el comando import, equivalente a POST /stocks/import: importa un archivo CSV o NDJSON
+// directamente en la base de datos y muestra el resumen en formato JSON. Devuelve el código de salida.
+//
+//	stock-advisor import -file stocks.csv [-format csv|ndjson] [-mode append|replace]
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	path := flags.String("file", "", "Archivo CSV o NDJSON a importar (obligatorio)")
+	format := flags.String("format", "", "Formato del archivo: csv o ndjson (por defecto se deduce de la extensión)")
+	mode := flags.String("mode", domain.ImportModeAppend, "append agrega y actualiza; replace además elimina los stocks ausentes")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "❌ El parámetro -file es obligatorio")
+		flags.Usage()
+		return 2
+	}
+	if *format == "" {
+		*format = domain.ImportFormatFromName(*path)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Printf("❌ Error abriendo el archivo: %v", err)
+		return 1
+	}
+	defer file.Close()
+
+	// Construir los servicios sin el servidor HTTP, el programador ni el worker de jobs
+	var service stocks.Service
+	app := fx.New(
+		fx.NopLogger,
+		fx.Provide(
+			context.Background,
+			newCommandConfig,
+			config.NewFactorsWatcher,
+			database.New,
+		),
+		repositories.Module,
+		services.Module,
+		fx.Populate(&service),
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := app.Start(startCtx); err != nil {
+		log.Printf("❌ Error iniciando la aplicación: %v", err)
+		return 1
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		app.Stop(stopCtx)
+	}()
+
+	result, err := service.ImportStocks(context.Background(), file, domain.ImportOptions{Format: *format, Mode: *mode})
+	if err != nil {
+		log.Printf("❌ Error importando stocks: %v", err)
+		return 1
+	}
+
+	output, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(output))
+	return 0
+}
+
+// newCommandConfig carga la configuración para los comandos de línea: sin el worker de jobs de sincronización
+// ni la recarga automática del archivo de factores
+func newCommandConfig() *config.Config {
+	cfg := config.New()
+	cfg.SyncWorkerDisabled = true
+	cfg.RecommendationFactorsWatch = false
+	return cfg
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// BrokerageSummary resume el comportamiento de un brokerage a partir de sus acciones.
+type BrokerageSummary struct {
+	Brokerage          string    `json:"brokerage" example:"Goldman Sachs"`
+	Tickers            int       `json:"tickers"` // Tickers distintos que cubre
+	Actions            int       `json:"actions"` // Acciones registradas
+	Upgrades           int       `json:"upgrades"`
+	Downgrades         int       `json:"downgrades"`
+	UpgradeRatio       *float64  `gorm:"-" json:"upgrade_ratio" extensions:"x-nullable"` // Proporción de upgrades sobre upgrades y downgrades (0 a 1); null si no hay ninguno
+	AvgTargetChangePct float64   `json:"avg_target_change_pct"`                          // Cambio porcentual promedio del precio objetivo
+	AvgScore           float64   `json:"avg_score"`                                      // Puntaje de recomendación promedio
+	Factor             *float64  `gorm:"-" json:"factor"`                                // Factor configurado; null si no tiene
+	LastRatedAt        time.Time `json:"last_rated_at"`
+}
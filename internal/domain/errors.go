@@ -0,0 +1,20 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNotFound indica que el recurso solicitado no existe.
+	ErrNotFound = errors.New("recurso no encontrado")
+
+	// ErrSyncQueueFull indica que no hay capacidad para encolar más sincronizaciones.
+	ErrSyncQueueFull = errors.New("la cola de sincronización está llena")
+
+	// ErrInvalidFactor indica que un factor de recomendación está fuera del rango permitido.
+	ErrInvalidFactor = errors.New("factor de recomendación inválido")
+
+	// ErrInvalidImport indica que el archivo o las opciones de una importación de stocks no son válidos.
+	ErrInvalidImport = errors.New("importación inválida")
+
+	// ErrInvalidCursor indica que el cursor de paginación no es válido o no corresponde al orden solicitado.
+	ErrInvalidCursor = errors.New("cursor de paginación inválido")
+)
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Tipos de factores de recomendación administrables.
+const (
+	FactorKindCompany   = "company"
+	FactorKindBrokerage = "brokerage"
+)
+
+// RecommendationFactor es el ajuste porcentual aplicado al puntaje de una empresa (por ticker)
+// o de un brokerage (por nombre). Reemplaza a los factores definidos en recommendation_factors.json.
+type RecommendationFactor struct {
+	Kind      string    `gorm:"primaryKey;size:20" json:"kind" example:"company"`
+	Key       string    `gorm:"primaryKey" json:"key" example:"AAPL"` // Ticker o nombre del brokerage
+	Factor    float64   `gorm:"not null" json:"factor" example:"10"`  // Ajuste porcentual, mayor que -100
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RecommendationFactorSeed registra que la tabla de factores ya se inicializó desde recommendation_factors.json.
+// Tiene una sola fila; gracias a ella, eliminar todos los factores no provoca una nueva inicialización al reiniciar.
+type RecommendationFactorSeed struct {
+	ID       int       `gorm:"primaryKey;autoIncrement:false"`
+	SeededAt time.Time `gorm:"not null"`
+}
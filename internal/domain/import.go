@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Formatos de archivo aceptados por la importación de stocks.
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// Modos de la importación de stocks.
+const (
+	ImportModeAppend  = "append"  // Inserta y actualiza los stocks del archivo
+	ImportModeReplace = "replace" // Además elimina los stocks que no están en el archivo
+)
+
+// ImportFormatFromName deduce el formato de importación de la extensión de un archivo; vacío si no la reconoce.
+func ImportFormatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return ImportFormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// ImportOptions contiene los parámetros de una importación de stocks.
+type ImportOptions struct {
+	Format string // csv o ndjson
+	Mode   string // append o replace; append si está vacío
+}
+
+// ImportResult resume una importación de stocks y los cambios aplicados en la base de datos.
+type ImportResult struct {
+	Format        string `json:"format"`
+	Mode          string `json:"mode"`
+	RowsRead      int    `json:"rows_read"`      // Filas leídas del archivo, sin contar el encabezado ni las líneas vacías
+	ItemsParsed   int    `json:"items_parsed"`   // Filas convertidas a stocks
+	ItemsRejected int    `json:"items_rejected"` // Filas descartadas por errores de parseo
+	Inserted      int    `json:"inserted"`       // Stocks nuevos
+	Updated       int    `json:"updated"`        // Stocks existentes con contenido modificado
+	Unchanged     int    `json:"unchanged"`      // Stocks existentes sin cambios
+	Removed       int    `json:"removed"`        // Stocks eliminados por no estar en el archivo (solo replace)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// StockRatingHistory es una acción de un brokerage observada en una sincronización.
+// La tabla solo admite inserciones: conserva las calificaciones y precios objetivo de cada ejecución
+// aunque la tabla de stocks se actualice.
+type StockRatingHistory struct {
+	ID             int64     `gorm:"primaryKey" json:"id"`
+	SyncRunID      int64     `gorm:"not null;index" json:"sync_run_id"` // Ejecución de la sincronización que la observó
+	NaturalKey     string    `gorm:"size:64" json:"-"`                  // Clave natural del stock observado
+	Ticker         string    `gorm:"not null;index:idx_rating_history_ticker_observed,priority:1" json:"ticker"`
+	Company        string    `gorm:"not null" json:"company"`
+	Brokerage      string    `gorm:"not null" json:"brokerage"`
+	Action         string    `gorm:"not null" json:"action"`
+	RatingFrom     string    `gorm:"not null" json:"rating_from"`
+	RatingTo       string    `gorm:"not null" json:"rating_to"`
+	TargetFrom     float64   `gorm:"not null" json:"target_from"`
+	TargetTo       float64   `gorm:"not null" json:"target_to"`
+	Currency       string    `gorm:"not null" json:"currency"`
+	RecommendScore float64   `gorm:"not null" json:"recommend_score"`
+	RatedAt        time.Time `json:"rated_at"`
+	ObservedAt     time.Time `gorm:"not null;index:idx_rating_history_ticker_observed,priority:2" json:"observed_at"`
+}
+
+// TableName conserva el nombre en singular de la tabla de historial.
+func (StockRatingHistory) TableName() string {
+	return "stock_rating_history"
+}
+
+// NewStockRatingHistory crea el registro de historial de un stock observado en una sincronización.
+func NewStockRatingHistory(stock Stock, runID int64, observedAt time.Time) StockRatingHistory {
+	return StockRatingHistory{
+		SyncRunID:      runID,
+		NaturalKey:     stock.NaturalKey,
+		Ticker:         stock.Ticker,
+		Company:        stock.Company,
+		Brokerage:      stock.Brokerage,
+		Action:         stock.Action,
+		RatingFrom:     stock.RatingFrom,
+		RatingTo:       stock.RatingTo,
+		TargetFrom:     stock.TargetFrom,
+		TargetTo:       stock.TargetTo,
+		Currency:       stock.Currency,
+		RecommendScore: stock.RecommendScore,
+		RatedAt:        stock.RatedAt,
+		ObservedAt:     observedAt,
+	}
+}
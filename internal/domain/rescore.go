@@ -0,0 +1,21 @@
+package domain
+
+// ScoreDistribution resume la distribución de los puntajes de recomendación.
+type ScoreDistribution struct {
+	Count  int     `json:"count"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	P25    float64 `json:"p25"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"p75"`
+}
+
+// RescoreResult resume un recálculo de los puntajes de recomendación de los stocks guardados.
+type RescoreResult struct {
+	Processed int               `json:"processed"` // Stocks evaluados
+	Changed   int               `json:"changed"`   // Stocks cuyo puntaje cambió
+	Batches   int               `json:"batches"`   // Lotes procesados
+	Before    ScoreDistribution `json:"before"`    // Distribución de puntajes antes del recálculo
+	After     ScoreDistribution `json:"after"`     // Distribución de puntajes después del recálculo
+}
@@ -0,0 +1,49 @@
+package domain
+
+// Nombres de los modificadores de contexto del puntaje de recomendación.
+const (
+	ScoreModifierDecreasingTarget = "decreasing_target"
+	ScoreModifierNegativeRating   = "negative_rating"
+)
+
+// ScoreComponents agrupa los componentes individuales del puntaje de recomendación.
+type ScoreComponents struct {
+	PercentDiff   float64 `json:"percent_diff"`   // Cambio porcentual en precio objetivo
+	Rating        float64 `json:"rating"`         // Calificación del analista
+	Action        float64 `json:"action"`         // Tipo de acción tomada
+	AbsoluteBonus float64 `json:"absolute_bonus"` // Magnitud absoluta del cambio
+}
+
+// ScoreFactor describe un factor externo (empresa o brokerage) aplicado a un componente del puntaje.
+type ScoreFactor struct {
+	Source     string  `json:"source"`     // company o brokerage
+	Key        string  `json:"key"`        // Ticker o nombre del brokerage
+	Component  string  `json:"component"`  // Componente afectado
+	Percent    float64 `json:"percent"`    // Ajuste porcentual configurado
+	Multiplier float64 `json:"multiplier"` // Multiplicador aplicado al componente (1 + percent/100)
+}
+
+// ScoreModifier describe un modificador de contexto evaluado sobre el puntaje ponderado.
+type ScoreModifier struct {
+	Name        string  `json:"name"`
+	Factor      float64 `json:"factor"`
+	Applied     bool    `json:"applied"`
+	ScoreBefore float64 `json:"score_before"`
+	ScoreAfter  float64 `json:"score_after"`
+}
+
+// ScoreBreakdown explica cómo se obtuvo el puntaje de recomendación de un stock.
+type ScoreBreakdown struct {
+	StockID       int64           `json:"stock_id"`
+	Ticker        string          `json:"ticker"`
+	Brokerage     string          `json:"brokerage"`
+	Components    ScoreComponents `json:"components"`          // Componentes base
+	Factors       []ScoreFactor   `json:"factors"`             // Factores externos aplicados
+	Adjusted      ScoreComponents `json:"adjusted_components"` // Componentes tras aplicar los factores externos
+	Weights       ScoreComponents `json:"weights"`             // Ponderación de cada componente
+	Contributions ScoreComponents `json:"contributions"`       // Aporte ponderado de cada componente
+	WeightedScore float64         `json:"weighted_score"`
+	Modifiers     []ScoreModifier `json:"modifiers"` // Modificadores de contexto evaluados, en orden
+	FinalScore    float64         `json:"final_score"`
+	StoredScore   float64         `json:"stored_score"` // Puntaje guardado en la última sincronización
+}
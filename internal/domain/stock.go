@@ -1,16 +1,80 @@
 package domain
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
 // Stock representa la información de un valor bursátil.
 type Stock struct {
-	ID             int64   `gorm:"primaryKey" json:"id"`
-	Ticker         string  `gorm:"not null;index" json:"ticker"`
-	Company        string  `gorm:"not null;index" json:"company"`
-	Brokerage      string  `gorm:"not null" json:"brokerage"`
-	Action         string  `gorm:"not null" json:"action"`
-	RatingFrom     string  `gorm:"not null" json:"rating_from"`
-	RatingTo       string  `gorm:"not null" json:"rating_to"`
-	TargetFrom     float64 `gorm:"not null" json:"target_from"`
-	TargetTo       float64 `gorm:"not null;index" json:"target_to"`
-	Currency       string  `gorm:"not null;default:'USD';index" json:"currency"`
-	RecommendScore float64 `gorm:"not null;default:0;index" json:"recommend_score"`
+	ID             int64          `gorm:"primaryKey" json:"id"`
+	NaturalKey     string         `gorm:"size:64;uniqueIndex" json:"-"`
+	Ticker         string         `gorm:"not null;index" json:"ticker"`
+	Company        string         `gorm:"not null;index" json:"company"`
+	Brokerage      string         `gorm:"not null" json:"brokerage"`
+	Action         string         `gorm:"not null" json:"action"`
+	RatingFrom     string         `gorm:"not null" json:"rating_from"`
+	RatingTo       string         `gorm:"not null" json:"rating_to"`
+	TargetFrom     float64        `gorm:"not null" json:"target_from"`
+	TargetTo       float64        `gorm:"not null;index" json:"target_to"`
+	Currency       string         `gorm:"not null;default:'USD';index" json:"currency"`
+	RecommendScore float64        `gorm:"not null;default:0;index" json:"recommend_score"`
+	RatedAt        time.Time      `gorm:"index" json:"rated_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TargetChangePct calcula la variación porcentual del precio objetivo; 0 si el precio anterior no es positivo.
+func (s Stock) TargetChangePct() float64 {
+	if s.TargetFrom <= 0 {
+		return 0
+	}
+	return (s.TargetTo - s.TargetFrom) / s.TargetFrom * 100
+}
+
+// ComputeNaturalKey calcula la clave natural del stock a partir del ticker, el brokerage
+// y la fecha de la calificación. La clave identifica la misma calificación entre
+// sincronizaciones sucesivas, de modo que varias calificaciones de un ticker por el
+// mismo brokerage se guardan por separado. Si la fuente no informa la fecha, la clave
+// usa en su lugar el contenido de la calificación (acción, calificaciones y precios objetivo).
+func (s Stock) ComputeNaturalKey() string {
+	parts := []string{
+		strings.ToUpper(strings.TrimSpace(s.Ticker)),
+		strings.ToLower(strings.TrimSpace(s.Brokerage)),
+	}
+	if s.RatedAt.IsZero() {
+		parts = append(parts,
+			strings.ToLower(strings.TrimSpace(s.Action)),
+			strings.ToLower(strings.TrimSpace(s.RatingFrom)),
+			strings.ToLower(strings.TrimSpace(s.RatingTo)),
+			strconv.FormatFloat(s.TargetFrom, 'f', -1, 64),
+			strconv.FormatFloat(s.TargetTo, 'f', -1, 64),
+		)
+	} else {
+		parts = append(parts, s.RatedAt.UTC().Format(time.RFC3339Nano))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// SameContent indica si dos stocks tienen el mismo contenido descriptivo,
+// ignorando identificadores y marcas de auditoría (created_at, updated_at, deleted_at).
+func (s Stock) SameContent(other Stock) bool {
+	return s.Ticker == other.Ticker &&
+		s.Company == other.Company &&
+		s.Brokerage == other.Brokerage &&
+		s.Action == other.Action &&
+		s.RatingFrom == other.RatingFrom &&
+		s.RatingTo == other.RatingTo &&
+		s.TargetFrom == other.TargetFrom &&
+		s.TargetTo == other.TargetTo &&
+		s.Currency == other.Currency &&
+		s.RecommendScore == other.RecommendScore &&
+		s.RatedAt.Equal(other.RatedAt)
 }
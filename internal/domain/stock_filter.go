@@ -0,0 +1,91 @@
+package domain
+
+import "time"
+
+// StockFilter agrupa los criterios de búsqueda, ordenamiento y paginación de stocks.
+type StockFilter struct {
+	Query       string    // Texto de búsqueda general
+	Page        int       // Número de página
+	Size        int       // Registros por página
+	Recommends  bool      // Ordenar por puntaje de recomendación
+	Recent      bool      // Ordenar por fecha de calificación más reciente
+	MinTargetTo float64   // Valor mínimo del precio objetivo
+	MaxTargetTo float64   // Valor máximo del precio objetivo
+	Currency    string    // Moneda de los precios
+	Brokerage   string    // Brokerage exacto
+	From        time.Time // Fecha mínima de calificación (inclusiva)
+	To          time.Time // Fecha máxima de calificación (exclusiva)
+
+	// Filtros por campo, combinados entre sí y con los anteriores con AND
+	Tickers            []string      // Tickers exactos; coincide con cualquiera de ellos
+	Actions            []string      // Tipos de acción (StockAction*); coincide con cualquiera de ellos
+	RatingTo           []string      // Categorías de la calificación nueva (RatingBucket*)
+	RatingFrom         []string      // Categorías de la calificación anterior (RatingBucket*)
+	RatingBuckets      RatingBuckets // Calificaciones de cada categoría; las completa el servicio si se filtra por categoría
+	MinScore           *float64      // Puntaje de recomendación mínimo
+	MaxScore           *float64      // Puntaje de recomendación máximo
+	MinTargetFrom      *float64      // Valor mínimo del precio objetivo anterior
+	MaxTargetFrom      *float64      // Valor máximo del precio objetivo anterior
+	MinTargetChangePct *float64      // Variación porcentual mínima del precio objetivo
+	MaxTargetChangePct *float64      // Variación porcentual máxima del precio objetivo
+
+	Sort      []StockSort // Orden explícito; si se indica, reemplaza al de Recommends y Recent
+	UseCursor bool        // Paginar por cursor (keyset) en lugar de por número de página
+	Cursor    string      // Cursor opaco de la página siguiente; vacío para la primera página
+	WithTotal bool        // Calcular el total de registros en la paginación por cursor
+}
+
+// Tipos de acción de los brokerages para el filtro Actions.
+const (
+	StockActionUpgraded      = "upgraded"
+	StockActionDowngraded    = "downgraded"
+	StockActionTargetRaised  = "target_raised"
+	StockActionTargetLowered = "target_lowered"
+	StockActionTargetSet     = "target_set"
+	StockActionInitiated     = "initiated"
+	StockActionReiterated    = "reiterated"
+)
+
+// StockActionPrefixes relaciona cada tipo de acción con el comienzo del texto de la acción (sin distinguir mayúsculas).
+var StockActionPrefixes = map[string]string{
+	StockActionUpgraded:      "upgraded",
+	StockActionDowngraded:    "downgraded",
+	StockActionTargetRaised:  "target raised",
+	StockActionTargetLowered: "target lowered",
+	StockActionTargetSet:     "target set",
+	StockActionInitiated:     "initiated",
+	StockActionReiterated:    "reiterated",
+}
+
+// Categorías de calificación para los filtros RatingTo y RatingFrom; other agrupa las calificaciones no clasificadas.
+const (
+	RatingBucketBuy   = "buy"
+	RatingBucketHold  = "hold"
+	RatingBucketSell  = "sell"
+	RatingBucketOther = "other"
+)
+
+// Campos por los que pueden ordenarse los stocks: las columnas indexadas y la variación del precio objetivo.
+const (
+	StockSortID              = "id"
+	StockSortTicker          = "ticker"
+	StockSortCompany         = "company"
+	StockSortTargetTo        = "target_to"
+	StockSortCurrency        = "currency"
+	StockSortRecommendScore  = "recommend_score"
+	StockSortRatedAt         = "rated_at"
+	StockSortTargetChangePct = "target_change_pct" // (target_to - target_from) / target_from * 100; 0 si target_from no es positivo
+)
+
+// StockSort es un campo de orden de los stocks y su dirección.
+type StockSort struct {
+	Field string
+	Desc  bool
+}
+
+// StockPage es una página de stocks obtenida con paginación por cursor.
+type StockPage struct {
+	Stocks     []Stock
+	Total      *int64 // Total de registros; nil si no se solicitó
+	NextCursor string // Cursor de la página siguiente; vacío si no hay más stocks
+}
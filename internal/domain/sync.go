@@ -0,0 +1,160 @@
+package domain
+
+import "time"
+
+// Estados posibles de un job de sincronización.
+const (
+	SyncJobQueued    = "queued"
+	SyncJobRunning   = "running"
+	SyncJobSucceeded = "succeeded"
+	SyncJobFailed    = "failed"
+)
+
+// Motivos por los que termina la paginación de una sincronización.
+const (
+	SyncStopEmptyNextPage = "empty_next_page" // La API no devolvió next_page
+	SyncStopCycle         = "cycle"           // La API devolvió un next_page ya visto
+	SyncStopLimit         = "limit"           // Se alcanzó el límite de páginas
+)
+
+// Tipos de cambio que aplicaría una sincronización sobre un stock.
+const (
+	SyncChangeInsert = "insert"
+	SyncChangeUpdate = "update"
+)
+
+// SyncOptions contiene los parámetros de una sincronización.
+type SyncOptions struct {
+	Limit  int  // Número máximo de iteraciones (páginas) a procesar
+	Resume bool // Reanudar desde el último checkpoint en lugar de empezar de cero
+	DryRun bool // Obtener y procesar las páginas sin guardar nada, solo calcular lo que cambiaría
+}
+
+// SyncResult resume el progreso de una sincronización y los cambios aplicados en la base de datos.
+type SyncResult struct {
+	PagesFetched  int `json:"pages_fetched"`  // Páginas obtenidas de la API externa
+	PagesResumed  int `json:"pages_resumed"`  // Páginas recuperadas de checkpoints al reanudar
+	APIAttempts   int `json:"api_attempts"`   // Solicitudes HTTP realizadas, incluyendo reintentos
+	APIRetries    int `json:"api_retries"`    // Reintentos por errores transitorios de la API externa
+	ItemsParsed   int `json:"items_parsed"`   // Elementos convertidos a stocks
+	ItemsRejected int `json:"items_rejected"` // Elementos descartados por errores de parseo
+	Inserted      int `json:"inserted"`       // Stocks nuevos
+	Updated       int `json:"updated"`        // Stocks existentes con contenido modificado
+	Unchanged     int `json:"unchanged"`      // Stocks existentes sin cambios
+	Removed       int `json:"removed"`        // Stocks que desaparecieron de la fuente externa
+}
+
+// SyncPreview resume lo que cambiaría una sincronización sin aplicar los cambios (dry run).
+type SyncPreview struct {
+	SyncResult
+	StopReason    string            `json:"stop_reason"`    // Motivo por el que terminó la paginación
+	Samples       []SyncPreviewRow  `json:"samples"`        // Muestra de stocks que se insertarían o actualizarían
+	RejectReasons map[string]int    `json:"reject_reasons"` // Elementos rechazados por motivo
+	Scores        ScoreDistribution `json:"scores"`         // Distribución de los puntajes de los stocks obtenidos
+}
+
+// SyncPreviewRow es un stock que una sincronización insertaría o actualizaría.
+type SyncPreviewRow struct {
+	Change string `json:"change"` // insert o update
+	Stock  Stock  `json:"stock"`
+}
+
+// Tipos de jobs que ejecuta el worker de sincronización.
+const (
+	SyncJobKindSync    = "sync"    // Sincronización con la fuente externa, o su dry run
+	SyncJobKindRescore = "rescore" // Recálculo de los puntajes de los stocks guardados
+)
+
+// SyncJob representa una sincronización o un recálculo encolado para ejecutarse en segundo plano.
+// Un job de dry run no modifica la base de datos y al terminar guarda en Preview lo que cambiaría;
+// un job de recálculo guarda su resumen en Rescore.
+type SyncJob struct {
+	ID         int64  `gorm:"primaryKey" json:"id"`
+	Kind       string `gorm:"size:20;not null;default:sync" json:"kind"`
+	State      string `gorm:"not null;index" json:"state"`
+	Limit      int    `gorm:"not null" json:"limit"`
+	Resume     bool   `gorm:"not null;default:false" json:"resume"`
+	DryRun     bool   `gorm:"not null;default:false" json:"dry_run"`
+	SyncResult `gorm:"embedded"`
+	Preview    *SyncPreview   `gorm:"type:jsonb;serializer:json" json:"preview,omitempty"` // Resumen del dry run, al terminar
+	Rescore    *RescoreResult `gorm:"type:jsonb;serializer:json" json:"rescore,omitempty"` // Resumen del recálculo, al terminar
+	Error      string         `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// Options devuelve los parámetros de sincronización del job.
+func (j SyncJob) Options() SyncOptions {
+	return SyncOptions{Limit: j.Limit, Resume: j.Resume, DryRun: j.DryRun}
+}
+
+// SyncCheckpoint guarda el resultado de una página ya procesada para poder reanudar
+// una sincronización fallida o interrumpida desde la última página correcta.
+type SyncCheckpoint struct {
+	ID            int64   `gorm:"primaryKey"`
+	Iteration     int     `gorm:"not null;uniqueIndex"` // Número de página dentro de la sincronización
+	NextPage      string  // Token de la página siguiente devuelto por la API
+	Final         bool    `gorm:"not null;default:false"` // La paginación terminó en esta página
+	Stocks        []Stock `gorm:"type:jsonb;serializer:json"`
+	ItemsRejected int     `gorm:"not null;default:0"`
+	CreatedAt     time.Time
+}
+
+// Orígenes de los elementos rechazados.
+const (
+	RejectSourceSync   = "sync"   // Sincronización con la fuente externa
+	RejectSourceImport = "import" // Importación de un archivo
+)
+
+// SyncReject guarda un elemento de la API externa o de un archivo importado que no pudo convertirse en stock,
+// junto con el error y el contenido original para poder inspeccionarlo.
+type SyncReject struct {
+	ID        int64                  `gorm:"primaryKey" json:"id"`
+	Source    string                 `gorm:"size:20;not null;default:sync" json:"source"` // sync o import
+	Iteration int                    `gorm:"not null;index" json:"iteration,omitempty"`   // Página en la que se recibió el elemento (sync)
+	Line      int                    `gorm:"not null;default:0" json:"line,omitempty"`    // Línea del archivo importado (import)
+	Error     string                 `gorm:"type:text;not null" json:"error"`
+	RawItem   map[string]interface{} `gorm:"type:jsonb;serializer:json" json:"raw_item"`
+	CreatedAt time.Time              `gorm:"index" json:"created_at"`
+}
+
+// Orígenes de las ejecuciones registradas en el historial.
+const (
+	SyncRunSourceSync   = "sync"   // Sincronización con la fuente externa
+	SyncRunSourceImport = "import" // Importación de un archivo
+)
+
+// SyncRun registra cada ejecución de la sincronización, ya sea programada o encolada como job, y cada importación:
+// sus parámetros, el motivo por el que terminó la paginación y los totales obtenidos.
+type SyncRun struct {
+	ID             int64  `gorm:"primaryKey" json:"id"`
+	Source         string `gorm:"size:20;not null;default:sync" json:"source"` // sync o import
+	RequestedLimit int    `gorm:"not null" json:"requested_limit"`
+	EffectiveLimit int    `gorm:"not null;default:0" json:"effective_limit"` // Límite aplicado tras validarlo con el máximo configurado
+	Resume         bool   `gorm:"not null;default:false" json:"resume"`
+	StopReason     string `gorm:"size:20" json:"stop_reason,omitempty"` // empty_next_page, cycle o limit; vacío si falló antes de terminar la paginación
+	SyncResult     `gorm:"embedded"`
+	Error          string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt      time.Time  `gorm:"not null;index" json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+}
+
+// SyncRunDiff compara las acciones observadas por una ejecución con las de la ejecución anterior.
+type SyncRunDiff struct {
+	RunID         int64              `json:"run_id"`
+	PreviousRunID *int64             `json:"previous_run_id"` // null si no hay una sincronización completa anterior con historial
+	Added         []string           `json:"added"`           // Tickers que no estaban en la ejecución anterior
+	Removed       []string           `json:"removed"`         // Tickers que ya no están en esta ejecución
+	Changed       []SyncRatingChange `json:"changed"`         // Calificaciones o precios objetivo que cambiaron
+}
+
+// SyncRatingChange es el cambio de calificación o precio objetivo de un brokerage sobre un ticker entre dos ejecuciones.
+type SyncRatingChange struct {
+	Ticker         string  `json:"ticker"`
+	Brokerage      string  `json:"brokerage"`
+	PreviousRating string  `json:"previous_rating"`
+	CurrentRating  string  `json:"current_rating"`
+	PreviousTarget float64 `json:"previous_target"`
+	CurrentTarget  float64 `json:"current_target"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// RatingBuckets agrupa las calificaciones normalizadas (minúsculas) por categoría de consenso.
+type RatingBuckets struct {
+	Buy  []string
+	Hold []string
+	Sell []string
+}
+
+// RatingDistribution cuenta las calificaciones vigentes (rating_to) de un ticker por categoría.
+type RatingDistribution struct {
+	Buy   int `json:"buy"`
+	Hold  int `json:"hold"`
+	Sell  int `json:"sell"`
+	Other int `json:"other"` // Calificaciones no reconocidas por el modelo de puntuación
+}
+
+// TickerSummary resume el consenso de los brokerages sobre un ticker.
+type TickerSummary struct {
+	Ticker       string             `json:"ticker" example:"AAPL"`
+	Company      string             `json:"company" example:"Apple Inc."`
+	Brokerages   int                `json:"brokerages"`    // Brokerages distintos que cubren el ticker
+	Actions      int                `json:"actions"`       // Acciones de brokerages registradas
+	TargetAvg    float64            `json:"target_avg"`    // Precio objetivo (target_to) promedio
+	TargetMedian float64            `json:"target_median"` // Precio objetivo mediano
+	TargetMin    float64            `json:"target_min"`
+	TargetMax    float64            `json:"target_max"`
+	Ratings      RatingDistribution `gorm:"embedded;embeddedPrefix:rating_" json:"ratings"`
+	Upgrades     int                `json:"upgrades"`
+	Downgrades   int                `json:"downgrades"`
+	NetUpgrades  int                `gorm:"-" json:"net_upgrades"` // Upgrades menos downgrades
+	Score        float64            `json:"score"`                 // Puntaje de recomendación promedio
+	LastRatedAt  time.Time          `json:"last_rated_at"`
+}
+
+// TickerDetail contiene el resumen de un ticker junto con las acciones de los brokerages que lo cubren.
+type TickerDetail struct {
+	TickerSummary
+	Stocks []Stock `json:"stocks"`
+}
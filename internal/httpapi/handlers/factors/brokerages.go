@@ -0,0 +1,74 @@
+package factors
+
+import (
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// GetBrokerageFactors
+// @Summary Listar factores de brokerages
+// @Description Devuelve los ajustes porcentuales aplicados al componente de calificación de cada brokerage
+// @Tags factors
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]domain.RecommendationFactor} "Factores de brokerages"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/brokerages [get]
+func (h *handler) GetBrokerageFactors(c echo.Context) error {
+	return h.getFactors(c, domain.FactorKindBrokerage)
+}
+
+// GetBrokerageFactor
+// @Summary Consultar el factor de un brokerage
+// @Description Devuelve el ajuste porcentual configurado para un brokerage
+// @Tags factors
+// @Produce json
+// @Param name path string true "Nombre del brokerage"
+// @Success 200 {object} response.APIResponse{data=domain.RecommendationFactor} "Factor del brokerage"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 404 {object} response.APIResponse "Factor no encontrado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/brokerages/{name} [get]
+func (h *handler) GetBrokerageFactor(c echo.Context) error {
+	return h.getFactor(c, domain.FactorKindBrokerage, pathParam(c, "name"))
+}
+
+// PutBrokerageFactor
+// @Summary Crear o actualizar el factor de un brokerage
+// @Description Guarda el ajuste porcentual de un brokerage y lo aplica de inmediato al modelo de puntuación
+// @Tags factors
+// @Accept json
+// @Produce json
+// @Param name path string true "Nombre del brokerage"
+// @Param request body FactorRequest true "Factor del brokerage"
+// @Success 200 {object} response.APIResponse{data=domain.RecommendationFactor} "Factor guardado"
+// @Failure 400 {object} response.APIResponse "Factor inválido"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/brokerages/{name} [put]
+func (h *handler) PutBrokerageFactor(c echo.Context) error {
+	return h.putFactor(c, domain.FactorKindBrokerage, pathParam(c, "name"))
+}
+
+// DeleteBrokerageFactor
+// @Summary Eliminar el factor de un brokerage
+// @Description Elimina el ajuste porcentual de un brokerage; sus calificaciones dejan de recibir ajuste
+// @Tags factors
+// @Produce json
+// @Param name path string true "Nombre del brokerage"
+// @Success 200 {object} response.APIResponse "Factor eliminado"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 404 {object} response.APIResponse "Factor no encontrado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/brokerages/{name} [delete]
+func (h *handler) DeleteBrokerageFactor(c echo.Context) error {
+	return h.deleteFactor(c, domain.FactorKindBrokerage, pathParam(c, "name"))
+}
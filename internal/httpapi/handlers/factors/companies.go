@@ -0,0 +1,74 @@
+package factors
+
+import (
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// GetCompanyFactors
+// @Summary Listar factores de empresas
+// @Description Devuelve los ajustes porcentuales aplicados al componente de cambio porcentual de cada ticker
+// @Tags factors
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]domain.RecommendationFactor} "Factores de empresas"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/companies [get]
+func (h *handler) GetCompanyFactors(c echo.Context) error {
+	return h.getFactors(c, domain.FactorKindCompany)
+}
+
+// GetCompanyFactor
+// @Summary Consultar el factor de una empresa
+// @Description Devuelve el ajuste porcentual configurado para un ticker
+// @Tags factors
+// @Produce json
+// @Param ticker path string true "Ticker de la empresa"
+// @Success 200 {object} response.APIResponse{data=domain.RecommendationFactor} "Factor de la empresa"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 404 {object} response.APIResponse "Factor no encontrado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/companies/{ticker} [get]
+func (h *handler) GetCompanyFactor(c echo.Context) error {
+	return h.getFactor(c, domain.FactorKindCompany, pathParam(c, "ticker"))
+}
+
+// PutCompanyFactor
+// @Summary Crear o actualizar el factor de una empresa
+// @Description Guarda el ajuste porcentual de un ticker y lo aplica de inmediato al modelo de puntuación
+// @Tags factors
+// @Accept json
+// @Produce json
+// @Param ticker path string true "Ticker de la empresa"
+// @Param request body FactorRequest true "Factor de la empresa"
+// @Success 200 {object} response.APIResponse{data=domain.RecommendationFactor} "Factor guardado"
+// @Failure 400 {object} response.APIResponse "Factor inválido"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/companies/{ticker} [put]
+func (h *handler) PutCompanyFactor(c echo.Context) error {
+	return h.putFactor(c, domain.FactorKindCompany, pathParam(c, "ticker"))
+}
+
+// DeleteCompanyFactor
+// @Summary Eliminar el factor de una empresa
+// @Description Elimina el ajuste porcentual de un ticker; sus stocks dejan de recibir ajuste
+// @Tags factors
+// @Produce json
+// @Param ticker path string true "Ticker de la empresa"
+// @Success 200 {object} response.APIResponse "Factor eliminado"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 404 {object} response.APIResponse "Factor no encontrado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Security AdminToken
+// @Router /admin/factors/companies/{ticker} [delete]
+func (h *handler) DeleteCompanyFactor(c echo.Context) error {
+	return h.deleteFactor(c, domain.FactorKindCompany, pathParam(c, "ticker"))
+}
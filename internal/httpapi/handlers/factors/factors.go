@@ -0,0 +1,174 @@
+package factors
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/middleware"
+	"github.com/julianloaiza/stock-advisor/internal/services/factors"
+)
+
+// handler implementa la interfaz handlers.Handler.
+type handler struct {
+	service    factors.Service
+	adminToken string
+}
+
+// Result es el tipo para publicar el handler en el grupo de handlers.
+type Result struct {
+	fx.Out
+
+	Handler handlers.Handler `group:"handlers"`
+}
+
+// New construye el handler de factores de recomendación y lo expone como parte del grupo "handlers".
+func New(service factors.Service, cfg *config.Config) Result {
+	return Result{
+		Handler: &handler{service: service, adminToken: cfg.AdminToken},
+	}
+}
+
+// RegisterRoutes registra las rutas de administración de factores, protegidas con ADMIN_TOKEN.
+func (h *handler) RegisterRoutes(e *echo.Echo) {
+	group := e.Group("/admin/factors", middleware.RequireAdminToken(h.adminToken))
+	group.GET("/companies", h.GetCompanyFactors)
+	group.GET("/companies/:ticker", h.GetCompanyFactor)
+	group.PUT("/companies/:ticker", h.PutCompanyFactor)
+	group.DELETE("/companies/:ticker", h.DeleteCompanyFactor)
+	group.GET("/brokerages", h.GetBrokerageFactors)
+	group.GET("/brokerages/:name", h.GetBrokerageFactor)
+	group.PUT("/brokerages/:name", h.PutBrokerageFactor)
+	group.DELETE("/brokerages/:name", h.DeleteBrokerageFactor)
+}
+
+// FactorRequest estructura para la solicitud de creación o actualización de un factor
+type FactorRequest struct {
+	Factor *float64 `json:"factor" example:"10"` // Ajuste porcentual, mayor que -100
+}
+
+// getFactors responde con todos los factores del tipo indicado
+func (h *handler) getFactors(c echo.Context, kind string) error {
+	factors, err := h.service.GetFactors(kind)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error obteniendo los factores",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		factors,
+		"Consulta de factores exitosa",
+	))
+}
+
+// getFactor responde con el factor del tipo y la clave indicados
+func (h *handler) getFactor(c echo.Context, kind, key string) error {
+	factor, err := h.service.GetFactor(kind, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, response.NewError(
+			http.StatusNotFound,
+			"Factor no encontrado",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error obteniendo el factor",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		factor,
+		"Consulta del factor exitosa",
+	))
+}
+
+// putFactor crea o actualiza el factor del tipo y la clave indicados
+func (h *handler) putFactor(c echo.Context, kind, key string) error {
+	var req FactorRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Error al leer el body de la petición",
+			err.Error(),
+		))
+	}
+
+	if req.Factor == nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"El campo 'factor' es obligatorio",
+			"",
+		))
+	}
+
+	factor, err := h.service.SaveFactor(kind, key, *req.Factor)
+	if errors.Is(err, domain.ErrInvalidFactor) {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Factor inválido",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error guardando el factor",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		factor,
+		"Factor guardado exitosamente",
+	))
+}
+
+// deleteFactor elimina el factor del tipo y la clave indicados
+func (h *handler) deleteFactor(c echo.Context, kind, key string) error {
+	err := h.service.DeleteFactor(kind, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, response.NewError(
+			http.StatusNotFound,
+			"Factor no encontrado",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error eliminando el factor",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		nil,
+		"Factor eliminado exitosamente",
+	))
+}
+
+// pathParam obtiene un parámetro de ruta decodificado (los nombres de brokerages pueden contener espacios)
+func pathParam(c echo.Context, name string) string {
+	value := c.Param(name)
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
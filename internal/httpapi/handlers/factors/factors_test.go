@@ -0,0 +1,183 @@
+package factors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockFactorService es un mock del servicio de factores
+type mockFactorService struct {
+	mock.Mock
+}
+
+func (m *mockFactorService) GetFactors(kind string) ([]domain.RecommendationFactor, error) {
+	args := m.Called(kind)
+	return args.Get(0).([]domain.RecommendationFactor), args.Error(1)
+}
+
+func (m *mockFactorService) GetFactor(kind, key string) (domain.RecommendationFactor, error) {
+	args := m.Called(kind, key)
+	return args.Get(0).(domain.RecommendationFactor), args.Error(1)
+}
+
+func (m *mockFactorService) SaveFactor(kind, key string, factor float64) (domain.RecommendationFactor, error) {
+	args := m.Called(kind, key, factor)
+	return args.Get(0).(domain.RecommendationFactor), args.Error(1)
+}
+
+func (m *mockFactorService) DeleteFactor(kind, key string) error {
+	args := m.Called(kind, key)
+	return args.Error(0)
+}
+
+func (m *mockFactorService) OnChange(fn func()) {}
+
+// newFactorContext crea un contexto Echo para una ruta de factores con un parámetro de ruta
+func newFactorContext(method, target, body, param, value string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if param != "" {
+		c.SetParamNames(param)
+		c.SetParamValues(value)
+	}
+	return c, rec
+}
+
+// TestGetCompanyFactors verifica que se listen los factores de empresas
+func TestGetCompanyFactors(t *testing.T) {
+	c, rec := newFactorContext(http.MethodGet, "/admin/factors/companies", "", "", "")
+
+	mockService := new(mockFactorService)
+	mockService.On("GetFactors", domain.FactorKindCompany).Return([]domain.RecommendationFactor{
+		{Kind: domain.FactorKindCompany, Key: "AAPL", Factor: 10},
+	}, nil)
+
+	h := &handler{service: mockService}
+	err := h.GetCompanyFactors(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.Len(t, res.Data, 1)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetBrokerageFactor_NotFound verifica que un factor inexistente devuelva 404 y que el nombre se decodifique
+func TestGetBrokerageFactor_NotFound(t *testing.T) {
+	c, rec := newFactorContext(http.MethodGet, "/admin/factors/brokerages/Morgan%20Stanley", "", "name", "Morgan%20Stanley")
+
+	mockService := new(mockFactorService)
+	mockService.On("GetFactor", domain.FactorKindBrokerage, "Morgan Stanley").Return(domain.RecommendationFactor{}, domain.ErrNotFound)
+
+	h := &handler{service: mockService}
+	err := h.GetBrokerageFactor(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestPutCompanyFactor_Success verifica que se guarde el factor de una empresa
+func TestPutCompanyFactor_Success(t *testing.T) {
+	c, rec := newFactorContext(http.MethodPut, "/admin/factors/companies/AAPL", `{"factor": 12.5}`, "ticker", "AAPL")
+
+	mockService := new(mockFactorService)
+	mockService.On("SaveFactor", domain.FactorKindCompany, "AAPL", 12.5).Return(domain.RecommendationFactor{
+		Kind: domain.FactorKindCompany, Key: "AAPL", Factor: 12.5,
+	}, nil)
+
+	h := &handler{service: mockService}
+	err := h.PutCompanyFactor(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	data, ok := res.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, 12.5, data["factor"])
+	mockService.AssertExpectations(t)
+}
+
+// TestPutCompanyFactor_MissingFactor verifica que el campo factor sea obligatorio
+func TestPutCompanyFactor_MissingFactor(t *testing.T) {
+	c, rec := newFactorContext(http.MethodPut, "/admin/factors/companies/AAPL", `{}`, "ticker", "AAPL")
+
+	mockService := new(mockFactorService)
+	h := &handler{service: mockService}
+	err := h.PutCompanyFactor(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "SaveFactor")
+}
+
+// TestPutBrokerageFactor_Invalid verifica que un factor fuera de rango devuelva 400
+func TestPutBrokerageFactor_Invalid(t *testing.T) {
+	c, rec := newFactorContext(http.MethodPut, "/admin/factors/brokerages/Citigroup", `{"factor": -100}`, "name", "Citigroup")
+
+	mockService := new(mockFactorService)
+	mockService.On("SaveFactor", domain.FactorKindBrokerage, "Citigroup", -100.0).Return(domain.RecommendationFactor{}, domain.ErrInvalidFactor)
+
+	h := &handler{service: mockService}
+	err := h.PutBrokerageFactor(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestDeleteCompanyFactor verifica la eliminación de un factor y el 404 cuando no existe
+func TestDeleteCompanyFactor(t *testing.T) {
+	mockService := new(mockFactorService)
+	mockService.On("DeleteFactor", domain.FactorKindCompany, "AAPL").Return(nil)
+	mockService.On("DeleteFactor", domain.FactorKindCompany, "MSFT").Return(domain.ErrNotFound)
+	h := &handler{service: mockService}
+
+	c, rec := newFactorContext(http.MethodDelete, "/admin/factors/companies/AAPL", "", "ticker", "AAPL")
+	assert.NoError(t, h.DeleteCompanyFactor(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	c, rec = newFactorContext(http.MethodDelete, "/admin/factors/companies/MSFT", "", "ticker", "MSFT")
+	assert.NoError(t, h.DeleteCompanyFactor(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestRegisterRoutes_RequiresAdminToken verifica que las rutas de administración exijan el token configurado
+func TestRegisterRoutes_RequiresAdminToken(t *testing.T) {
+	mockService := new(mockFactorService)
+	mockService.On("GetFactors", domain.FactorKindCompany).Return([]domain.RecommendationFactor{}, nil)
+
+	e := echo.New()
+	h := &handler{service: mockService, adminToken: "secret"}
+	h.RegisterRoutes(e)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/factors/companies/AAPL", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockService.AssertNotCalled(t, "DeleteFactor", mock.Anything, mock.Anything)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/factors/companies", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
@@ -8,12 +8,13 @@ type APIResponse struct {
 	Error   string      `json:"error,omitempty"`   // Detalles del error
 }
 
-// PaginatedData estructura para respuestas paginadas.
+// PaginatedData estructura para respuestas paginadas, por número de página o por cursor.
 type PaginatedData struct {
-	Content interface{} `json:"content"` // Lista de ítems
-	Total   int64       `json:"total"`   // Total de ítems disponibles
-	Page    int         `json:"page"`    // Número de página actual
-	Size    int         `json:"size"`    // Ítems por página
+	Content    interface{} `json:"content"`               // Lista de ítems
+	Total      *int64      `json:"total,omitempty"`       // Total de ítems disponibles; opcional con cursor
+	Page       int         `json:"page,omitempty"`        // Número de página actual; solo sin cursor
+	Size       int         `json:"size"`                  // Ítems por página
+	NextCursor string      `json:"next_cursor,omitempty"` // Cursor de la página siguiente; vacío en la última
 }
 
 // NewSuccess crea una respuesta exitosa.
@@ -38,8 +39,18 @@ func NewError(code int, message, errorDetail string) APIResponse {
 func NewPaginated(content interface{}, total int64, page, size int) PaginatedData {
 	return PaginatedData{
 		Content: content,
-		Total:   total,
+		Total:   &total,
 		Page:    page,
 		Size:    size,
 	}
 }
+
+// NewCursorPaginated crea una respuesta paginada por cursor; total puede ser nil si no se calculó.
+func NewCursorPaginated(content interface{}, total *int64, size int, nextCursor string) PaginatedData {
+	return PaginatedData{
+		Content:    content,
+		Total:      total,
+		Size:       size,
+		NextCursor: nextCursor,
+	}
+}
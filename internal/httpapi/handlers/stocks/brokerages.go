@@ -0,0 +1,133 @@
+package stocks
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// GetBrokerages
+// @Summary Obtener el comportamiento por brokerage
+// @Description Agrupa las acciones por brokerage: tickers cubiertos, upgrades y downgrades, cambio porcentual promedio del precio objetivo, puntaje promedio y factor configurado. Acepta los mismos filtros que GET /stocks
+// @Tags brokerages
+// @Produce json
+// @Param query query string false "Texto de búsqueda general (ticker, company, brokerage, etc.)"
+// @Param page query int false "Número de página (por defecto: 1)" default(1)
+// @Param size query int false "Registros por página (por defecto: 10)" default(10)
+// @Param recommends query bool false "Ordenar por puntaje de recomendación promedio"
+// @Param minTargetTo query number false "Valor mínimo del precio objetivo"
+// @Param maxTargetTo query number false "Valor máximo del precio objetivo"
+// @Param currency query string false "Moneda de los precios (por defecto: USD)" default(USD)
+// @Param from query string false "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)"
+// @Param to query string false "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)"
+// @Param recent query bool false "Ordenar por fecha de calificación más reciente"
+// @Param ticker query []string false "Tickers exactos, separados por coma" collectionFormat(csv)
+// @Param brokerage query string false "Brokerage exacto"
+// @Param action query []string false "Tipos de acción, separados por coma" collectionFormat(csv) Enums(upgraded, downgraded, target_raised, target_lowered, target_set, initiated, reiterated)
+// @Param ratingTo query []string false "Categorías de la calificación nueva, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param ratingFrom query []string false "Categorías de la calificación anterior, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param minScore query number false "Puntaje de recomendación mínimo"
+// @Param maxScore query number false "Puntaje de recomendación máximo"
+// @Param minTargetFrom query number false "Valor mínimo del precio objetivo anterior"
+// @Param maxTargetFrom query number false "Valor máximo del precio objetivo anterior"
+// @Param minTargetChangePct query number false "Variación porcentual mínima del precio objetivo"
+// @Param maxTargetChangePct query number false "Variación porcentual máxima del precio objetivo"
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData{content=[]domain.BrokerageSummary}} "Consulta de brokerages exitosa"
+// @Failure 400 {object} response.APIResponse "Parámetros inválidos"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /brokerages [get]
+func (h *handler) GetBrokerages(c echo.Context) error {
+	params, err := parseStockParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	brokerages, total, err := h.service.GetBrokerages(params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error obteniendo brokerages",
+			err.Error(),
+		))
+	}
+
+	paginated := response.NewPaginated(brokerages, total, params.Page, params.Size)
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		paginated,
+		"Consulta de brokerages exitosa",
+	))
+}
+
+// GetBrokerageStocks
+// @Summary Obtener las acciones de un brokerage
+// @Description Recupera las acciones de un brokerage, con los mismos filtros, orden y paginación que GET /stocks
+// @Tags brokerages
+// @Produce json
+// @Param name path string true "Nombre del brokerage"
+// @Param query query string false "Texto de búsqueda general (ticker, company, brokerage, etc.)"
+// @Param page query int false "Número de página (por defecto: 1)" default(1)
+// @Param size query int false "Registros por página (por defecto: 10)" default(10)
+// @Param recommends query bool false "Ordenar por puntaje de recomendación"
+// @Param minTargetTo query number false "Valor mínimo del precio objetivo"
+// @Param maxTargetTo query number false "Valor máximo del precio objetivo"
+// @Param currency query string false "Moneda de los precios (por defecto: USD)" default(USD)
+// @Param from query string false "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)"
+// @Param to query string false "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)"
+// @Param recent query bool false "Ordenar por fecha de calificación más reciente"
+// @Param sort query string false "Orden por campos separados por coma, descendente con '-' (ej. -target_to,ticker); reemplaza a recommends y recent. Campos: id, ticker, company, target_to, currency, recommend_score, rated_at, target_change_pct"
+// @Param ticker query []string false "Tickers exactos, separados por coma" collectionFormat(csv)
+// @Param action query []string false "Tipos de acción, separados por coma" collectionFormat(csv) Enums(upgraded, downgraded, target_raised, target_lowered, target_set, initiated, reiterated)
+// @Param ratingTo query []string false "Categorías de la calificación nueva, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param ratingFrom query []string false "Categorías de la calificación anterior, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param minScore query number false "Puntaje de recomendación mínimo"
+// @Param maxScore query number false "Puntaje de recomendación máximo"
+// @Param minTargetFrom query number false "Valor mínimo del precio objetivo anterior"
+// @Param maxTargetFrom query number false "Valor máximo del precio objetivo anterior"
+// @Param minTargetChangePct query number false "Variación porcentual mínima del precio objetivo"
+// @Param maxTargetChangePct query number false "Variación porcentual máxima del precio objetivo"
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData{content=[]domain.Stock}} "Consulta de acciones exitosa"
+// @Failure 400 {object} response.APIResponse "Parámetros inválidos"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /brokerages/{name}/stocks [get]
+func (h *handler) GetBrokerageStocks(c echo.Context) error {
+	params, err := parseStockParams(c)
+	if err == nil {
+		err = parseSortParam(c, &params)
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	// Los nombres de brokerages pueden contener espacios codificados en la ruta
+	name := c.Param("name")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+
+	stocksList, total, err := h.service.GetBrokerageStocks(name, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error buscando stocks",
+			err.Error(),
+		))
+	}
+
+	paginated := response.NewPaginated(stocksList, total, params.Page, params.Size)
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		paginated,
+		"Consulta de acciones exitosa",
+	))
+}
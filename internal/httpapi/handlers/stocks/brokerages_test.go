@@ -0,0 +1,59 @@
+package stocks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetBrokerages_Success verifica que se devuelva el resumen paginado por brokerage
+func TestGetBrokerages_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/brokerages", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	ratio := 0.75
+	mockService := new(mockStockService)
+	mockService.On("GetBrokerages", domain.StockFilter{Page: 1, Size: 10, Currency: "USD"}).
+		Return([]domain.BrokerageSummary{{Brokerage: "Citigroup", Upgrades: 3, Downgrades: 1, UpgradeRatio: &ratio}}, int64(1), nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetBrokerages(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	content := res.Data.(map[string]interface{})["content"].([]interface{})
+	item := content[0].(map[string]interface{})
+	assert.Equal(t, 0.75, item["upgrade_ratio"])
+	assert.Nil(t, item["factor"], "Sin factor configurado debe devolverse null")
+	mockService.AssertExpectations(t)
+}
+
+// TestGetBrokerageStocks_DecodesName verifica que el nombre del brokerage se decodifique de la ruta
+func TestGetBrokerageStocks_DecodesName(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/brokerages/Morgan%20Stanley/stocks?page=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues("Morgan%20Stanley")
+
+	mockService := new(mockStockService)
+	mockService.On("GetBrokerageStocks", "Morgan Stanley", domain.StockFilter{Page: 2, Size: 10, Currency: "USD"}).
+		Return([]domain.Stock{{ID: 1, Brokerage: "Morgan Stanley"}}, int64(11), nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetBrokerageStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
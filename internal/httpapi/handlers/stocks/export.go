@@ -0,0 +1,254 @@
+package stocks
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// Formatos de exportación de GET /stocks; json es la respuesta paginada habitual.
+const (
+	exportFormatJSON   = "json"
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+	exportFormatXLSX   = "xlsx"
+)
+
+// exportFlushRows es la cantidad de filas escritas entre cada envío parcial de la respuesta al cliente
+const exportFlushRows = 500
+
+// exportContentTypes relaciona cada formato de exportación con su tipo de contenido
+var exportContentTypes = map[string]string{
+	exportFormatCSV:    "text/csv; charset=utf-8",
+	exportFormatNDJSON: "application/x-ndjson",
+	exportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportAcceptTypes relaciona los tipos del encabezado Accept con el formato de la respuesta
+var exportAcceptTypes = map[string]string{
+	"application/json":     exportFormatJSON,
+	"text/csv":             exportFormatCSV,
+	"application/x-ndjson": exportFormatNDJSON,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": exportFormatXLSX,
+}
+
+// exportColumns son las columnas de las exportaciones CSV y XLSX, en orden
+var exportColumns = []string{
+	"id", "ticker", "company", "brokerage", "action", "rating_from", "rating_to",
+	"target_from", "target_to", "currency", "recommend_score", "rated_at",
+}
+
+// stockWriter escribe los stocks exportados en el formato de la respuesta
+type stockWriter interface {
+	// Write agrega un stock a la salida
+	Write(stock domain.Stock) error
+
+	// Flush envía al destino las filas pendientes
+	Flush() error
+
+	// Close completa el documento y envía las filas pendientes
+	Close() error
+}
+
+// parseExportFormat determina el formato de la respuesta de GET /stocks a partir del parámetro format
+// o, si no se indica, del primer tipo reconocido del encabezado Accept. Por defecto es json.
+func parseExportFormat(c echo.Context) (string, error) {
+	if format := strings.ToLower(strings.TrimSpace(c.QueryParam("format"))); format != "" {
+		if format != exportFormatJSON && exportContentTypes[format] == "" {
+			return "", echo.NewHTTPError(http.StatusBadRequest, "Format debe ser json, csv, ndjson o xlsx")
+		}
+		return format, nil
+	}
+
+	for _, accepted := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		if format, ok := exportAcceptTypes[mediaType]; ok {
+			return format, nil
+		}
+	}
+	return exportFormatJSON, nil
+}
+
+// exportStocks escribe en la respuesta todos los stocks que cumplen el filtro, sin paginación, en el formato indicado.
+// Las filas se envían a medida que se leen de la base de datos; si el error ocurre antes de enviar datos
+// se responde con el error habitual en JSON, y si ocurre después solo puede cortarse la respuesta.
+func (h *handler) exportStocks(c echo.Context, filter domain.StockFilter, format string) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, exportContentTypes[format])
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=stocks.%s", format))
+
+	writer, err := newStockWriter(res, format)
+	if err != nil {
+		return h.exportError(c, err)
+	}
+
+	rows := 0
+	err = h.service.ExportStocks(c.Request().Context(), filter, func(stock domain.Stock) error {
+		if err := writer.Write(stock); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			res.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		return h.exportError(c, err)
+	}
+
+	log.Printf("Exportación de stocks completada: %d filas en formato %s", rows, format)
+	return nil
+}
+
+// exportError responde con el error de una exportación si todavía no se enviaron datos al cliente
+func (h *handler) exportError(c echo.Context, err error) error {
+	if c.Response().Committed {
+		log.Printf("Exportación de stocks interrumpida: %v", err)
+		return nil
+	}
+
+	c.Response().Header().Del(echo.HeaderContentType)
+	c.Response().Header().Del(echo.HeaderContentDisposition)
+	return c.JSON(http.StatusInternalServerError, response.NewError(
+		http.StatusInternalServerError,
+		"Error exportando stocks",
+		err.Error(),
+	))
+}
+
+// newStockWriter crea el escritor del formato de exportación indicado
+func newStockWriter(w io.Writer, format string) (stockWriter, error) {
+	switch format {
+	case exportFormatCSV:
+		return newCSVStockWriter(w), nil
+	case exportFormatNDJSON:
+		return newNDJSONStockWriter(w), nil
+	case exportFormatXLSX:
+		return newXLSXStockWriter(w), nil
+	default:
+		return nil, fmt.Errorf("formato de exportación desconocido: %s", format)
+	}
+}
+
+// exportValues devuelve los valores de un stock en el orden de exportColumns;
+// los números se mantienen como float64 o int64 para que XLSX los escriba como celdas numéricas
+func exportValues(stock domain.Stock) []interface{} {
+	ratedAt := ""
+	if !stock.RatedAt.IsZero() {
+		ratedAt = stock.RatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return []interface{}{
+		stock.ID, stock.Ticker, stock.Company, stock.Brokerage, stock.Action, stock.RatingFrom, stock.RatingTo,
+		stock.TargetFrom, stock.TargetTo, stock.Currency, stock.RecommendScore, ratedAt,
+	}
+}
+
+// formatExportValue convierte un valor de exportValues en texto. Los textos pasan por escapeFormula
+// porque provienen de la fuente externa o de archivos importados.
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return escapeFormula(fmt.Sprint(v))
+	}
+}
+
+// escapeFormula antepone un apóstrofo a los textos que una hoja de cálculo interpretaría como fórmula
+// (los que empiezan con =, +, -, @, tabulación o retorno de carro), para evitar la inyección de fórmulas
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// csvStockWriter escribe los stocks como CSV con encabezado
+type csvStockWriter struct {
+	writer *csv.Writer
+	header bool
+	record []string
+}
+
+func newCSVStockWriter(w io.Writer) *csvStockWriter {
+	return &csvStockWriter{writer: csv.NewWriter(w), record: make([]string, len(exportColumns))}
+}
+
+func (w *csvStockWriter) Write(stock domain.Stock) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for i, value := range exportValues(stock) {
+		w.record[i] = formatExportValue(value)
+	}
+	return w.writer.Write(w.record)
+}
+
+// writeHeader escribe el encabezado antes de la primera fila, o al cerrar si no hubo filas
+func (w *csvStockWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.writer.Write(exportColumns)
+}
+
+func (w *csvStockWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvStockWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// ndjsonStockWriter escribe cada stock como un objeto JSON por línea, con los mismos campos que la respuesta JSON
+type ndjsonStockWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newNDJSONStockWriter(w io.Writer) *ndjsonStockWriter {
+	buffer := bufio.NewWriter(w)
+	return &ndjsonStockWriter{buffer: buffer, encoder: json.NewEncoder(buffer)}
+}
+
+func (w *ndjsonStockWriter) Write(stock domain.Stock) error {
+	return w.encoder.Encode(stock)
+}
+
+func (w *ndjsonStockWriter) Flush() error {
+	return w.buffer.Flush()
+}
+
+func (w *ndjsonStockWriter) Close() error {
+	return w.buffer.Flush()
+}
@@ -0,0 +1,208 @@
+package stocks
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportStocksFixture son los stocks devueltos por el servicio en las pruebas de exportación
+var exportStocksFixture = []domain.Stock{
+	{
+		ID: 1, Ticker: "AAPL", Company: "Apple, Inc.", Brokerage: "Example <Broker>", Action: "upgraded by",
+		RatingFrom: "Hold", RatingTo: "Buy", TargetFrom: 150, TargetTo: 180.5, Currency: "USD", RecommendScore: 12.25,
+		RatedAt: time.Date(2025, 1, 13, 0, 30, 5, 0, time.UTC),
+	},
+	{ID: 2, Ticker: "MSFT", Company: "=HYPERLINK(\"http://example.com\")", Brokerage: "@Other", Currency: "USD"},
+}
+
+// newExportContext crea el contexto de GET /stocks con la URL y el encabezado Accept indicados
+func newExportContext(target, accept string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(req, rec), rec
+}
+
+// TestGetStocks_ExportCSV verifica la exportación CSV con los filtros de la consulta y sin paginación
+func TestGetStocks_ExportCSV(t *testing.T) {
+	c, rec := newExportContext("/stocks?query=tech&currency=USD&page=3&format=csv", "")
+
+	mockService := new(mockStockService)
+	mockService.On("ExportStocks", domain.StockFilter{Query: "tech", Page: 3, Size: 10, Currency: "USD"}).
+		Return(exportStocksFixture, nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "attachment; filename=stocks.csv", rec.Header().Get(echo.HeaderContentDisposition))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, exportColumns, records[0])
+	assert.Equal(t, []string{"1", "AAPL", "Apple, Inc.", "Example <Broker>", "upgraded by", "Hold", "Buy",
+		"150", "180.5", "USD", "12.25", "2025-01-13T00:30:05Z"}, records[1])
+	assert.Equal(t, "", records[2][11])
+	// Los textos que empiezan como una fórmula se exportan con un apóstrofo
+	assert.Equal(t, `'=HYPERLINK("http://example.com")`, records[2][2])
+	assert.Equal(t, "'@Other", records[2][3])
+	mockService.AssertExpectations(t)
+}
+
+// TestGetStocks_ExportNDJSON verifica que el encabezado Accept seleccione la exportación NDJSON
+func TestGetStocks_ExportNDJSON(t *testing.T) {
+	c, rec := newExportContext("/stocks", "application/x-ndjson")
+
+	mockService := new(mockStockService)
+	mockService.On("ExportStocks", domain.StockFilter{Page: 1, Size: 10, Currency: "USD"}).Return(exportStocksFixture, nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var stock domain.Stock
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &stock))
+	assert.Equal(t, "AAPL", stock.Ticker)
+	assert.Equal(t, 180.5, stock.TargetTo)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetStocks_ExportXLSX verifica que el libro XLSX sea un ZIP válido con la hoja de stocks
+func TestGetStocks_ExportXLSX(t *testing.T) {
+	c, rec := newExportContext("/stocks?format=xlsx", "")
+
+	mockService := new(mockStockService)
+	mockService.On("ExportStocks", domain.StockFilter{Page: 1, Size: 10, Currency: "USD"}).Return(exportStocksFixture, nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, exportContentTypes[exportFormatXLSX], rec.Header().Get(echo.HeaderContentType))
+
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	assert.NoError(t, err)
+
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		src, err := file.Open()
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(src)
+		src.Close()
+		parts[file.Name] = string(content)
+
+		// Todas las partes deben ser XML bien formado
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err != nil {
+				assert.ErrorIs(t, err, io.EOF, "XML inválido en %s", file.Name)
+				break
+			}
+		}
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "xl/workbook.xml")
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Equal(t, 3, strings.Count(sheet, "<row "))
+	assert.Contains(t, sheet, `<c r="A2"><v>1</v></c>`)
+	assert.Contains(t, sheet, `<c r="I2"><v>180.5</v></c>`)
+	assert.Contains(t, sheet, "Example &lt;Broker&gt;")
+	assert.Contains(t, sheet, "&#39;@Other")
+	mockService.AssertExpectations(t)
+}
+
+// TestGetStocks_ExportEmpty verifica que una exportación sin stocks incluya igualmente el encabezado
+func TestGetStocks_ExportEmpty(t *testing.T) {
+	c, rec := newExportContext("/stocks?format=csv", "")
+
+	mockService := new(mockStockService)
+	mockService.On("ExportStocks", domain.StockFilter{Page: 1, Size: 10, Currency: "USD"}).Return([]domain.Stock{}, nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, strings.Join(exportColumns, ",")+"\n", rec.Body.String())
+}
+
+// TestGetStocks_ExportError verifica que un error antes de enviar datos se informe como JSON
+func TestGetStocks_ExportError(t *testing.T) {
+	c, rec := newExportContext("/stocks?format=ndjson", "")
+
+	mockService := new(mockStockService)
+	mockService.On("ExportStocks", domain.StockFilter{Page: 1, Size: 10, Currency: "USD"}).
+		Return([]domain.Stock{}, errors.New("db error"))
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "application/json")
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+}
+
+// TestParseExportFormat verifica la prioridad del parámetro format sobre el encabezado Accept
+func TestParseExportFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		accept   string
+		expected string
+		wantErr  bool
+	}{
+		{name: "por defecto", target: "/stocks", expected: exportFormatJSON},
+		{name: "accept genérico", target: "/stocks", accept: "*/*", expected: exportFormatJSON},
+		{name: "accept csv con parámetros", target: "/stocks", accept: "text/html, text/csv;q=0.9", expected: exportFormatCSV},
+		{name: "accept xlsx", target: "/stocks", accept: exportContentTypes[exportFormatXLSX], expected: exportFormatXLSX},
+		{name: "format sobre accept", target: "/stocks?format=JSON", accept: "text/csv", expected: exportFormatJSON},
+		{name: "format desconocido", target: "/stocks?format=xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newExportContext(tt.target, tt.accept)
+
+			format, err := parseExportFormat(c)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+// TestXLSXColumnName verifica los nombres de columna de la hoja
+func TestXLSXColumnName(t *testing.T) {
+	assert.Equal(t, "A", xlsxColumnName(0))
+	assert.Equal(t, "Z", xlsxColumnName(25))
+	assert.Equal(t, "AA", xlsxColumnName(26))
+	assert.Equal(t, "AZ", xlsxColumnName(51))
+	assert.Equal(t, "BA", xlsxColumnName(52))
+}
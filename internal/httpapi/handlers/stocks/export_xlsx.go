@@ -0,0 +1,144 @@
+package stocks
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// xlsxStaticParts son las partes fijas del libro XLSX: un libro con una sola hoja llamada "stocks"
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="stocks" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+// xlsxStockWriter escribe los stocks como un libro XLSX. La hoja se genera fila a fila dentro del ZIP,
+// con textos en línea y celdas numéricas, para no mantener el documento completo en memoria.
+type xlsxStockWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	row   int
+}
+
+func newXLSXStockWriter(w io.Writer) *xlsxStockWriter {
+	return &xlsxStockWriter{zip: zip.NewWriter(w)}
+}
+
+func (w *xlsxStockWriter) Write(stock domain.Stock) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	return w.writeRow(exportValues(stock))
+}
+
+// start escribe las partes fijas, la apertura de la hoja y el encabezado antes de la primera fila
+func (w *xlsxStockWriter) start() error {
+	if w.sheet != nil {
+		return nil
+	}
+
+	for _, part := range xlsxStaticParts {
+		file, err := w.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := w.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	w.sheet = sheet
+	if _, err := io.WriteString(w.sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	return w.writeRow(header)
+}
+
+// writeRow escribe una fila de la hoja; los int64 y float64 como números y el resto como texto
+func (w *xlsxStockWriter) writeRow(values []interface{}) error {
+	w.row++
+	rowRef := strconv.Itoa(w.row)
+
+	var b strings.Builder
+	b.WriteString(`<row r="` + rowRef + `">`)
+	for i, value := range values {
+		ref := xlsxColumnName(i) + rowRef
+		switch value.(type) {
+		case int64, float64:
+			b.WriteString(`<c r="` + ref + `"><v>` + formatExportValue(value) + `</v></c>`)
+		default:
+			b.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(&b, []byte(formatExportValue(value)))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(w.sheet, b.String())
+	return err
+}
+
+func (w *xlsxStockWriter) Flush() error {
+	return w.zip.Flush()
+}
+
+func (w *xlsxStockWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// xlsxColumnName devuelve el nombre de la columna de índice i (base 0): A, B, ..., Z, AA, AB, ...
+func xlsxColumnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
@@ -1,31 +1,45 @@
 package stocks
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
 	"github.com/labstack/echo/v4"
 )
 
-// StockParams contiene los parámetros para filtrar acciones
-type StockParams struct {
-	Query       string
-	Page        int
-	Size        int
-	Recommends  bool
-	MinTargetTo float64
-	MaxTargetTo float64
-	Currency    string
+// dateOnlyLayout es el formato de fecha sin hora aceptado en los filtros from/to
+const dateOnlyLayout = "2006-01-02"
+
+// stockSortFields son los campos aceptados por el parámetro sort: las columnas indexadas de domain.Stock
+// y la variación porcentual del precio objetivo
+var stockSortFields = []string{
+	domain.StockSortID,
+	domain.StockSortTicker,
+	domain.StockSortCompany,
+	domain.StockSortTargetTo,
+	domain.StockSortCurrency,
+	domain.StockSortRecommendScore,
+	domain.StockSortRatedAt,
+	domain.StockSortTargetChangePct,
 }
 
 // GetStocks
 // @Summary Obtener lista de stocks
-// @Description Recupera una lista filtrada y paginada de acciones bursátiles
+// @Description Recupera una lista filtrada y paginada de acciones bursátiles.
+// @Description Con format (o el encabezado Accept: text/csv, application/x-ndjson o el tipo de XLSX) exporta todos los stocks filtrados como archivo,
+// @Description sin paginación y enviando las filas a medida que se leen de la base de datos.
+// @Description Con cursor pagina por clave (keyset) en lugar de page: la respuesta incluye next_cursor y solo cuenta el total con total=true
 // @Tags stocks
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param query query string false "Texto de búsqueda general (ticker, company, brokerage, etc.)"
 // @Param page query int false "Número de página (por defecto: 1)" default(1)
 // @Param size query int false "Registros por página (por defecto: 10)" default(10)
@@ -33,12 +47,45 @@ type StockParams struct {
 // @Param minTargetTo query number false "Valor mínimo del precio objetivo"
 // @Param maxTargetTo query number false "Valor máximo del precio objetivo"
 // @Param currency query string false "Moneda de los precios (por defecto: USD)" default(USD)
-// @Success 200 {object} response.APIResponse{data=response.PaginatedData} "Consulta de acciones exitosa"
+// @Param from query string false "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)"
+// @Param to query string false "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)"
+// @Param recent query bool false "Ordenar por fecha de calificación más reciente"
+// @Param sort query string false "Orden por campos separados por coma, descendente con '-' (ej. -target_to,ticker); reemplaza a recommends y recent. Campos: id, ticker, company, target_to, currency, recommend_score, rated_at, target_change_pct"
+// @Param ticker query []string false "Tickers exactos, separados por coma" collectionFormat(csv)
+// @Param brokerage query string false "Brokerage exacto"
+// @Param action query []string false "Tipos de acción, separados por coma" collectionFormat(csv) Enums(upgraded, downgraded, target_raised, target_lowered, target_set, initiated, reiterated)
+// @Param ratingTo query []string false "Categorías de la calificación nueva, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param ratingFrom query []string false "Categorías de la calificación anterior, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param minScore query number false "Puntaje de recomendación mínimo"
+// @Param maxScore query number false "Puntaje de recomendación máximo"
+// @Param minTargetFrom query number false "Valor mínimo del precio objetivo anterior"
+// @Param maxTargetFrom query number false "Valor máximo del precio objetivo anterior"
+// @Param minTargetChangePct query number false "Variación porcentual mínima del precio objetivo"
+// @Param maxTargetChangePct query number false "Variación porcentual máxima del precio objetivo"
+// @Param cursor query string false "Paginación por cursor: vacío para la primera página o el next_cursor de la respuesta anterior; no se combina con page"
+// @Param total query bool false "Incluir el total de registros en la paginación por cursor (por defecto: false)"
+// @Param format query string false "Formato de la respuesta; tiene prioridad sobre el encabezado Accept (por defecto: json)" Enums(json, csv, ndjson, xlsx)
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData} "Consulta de acciones exitosa, o el archivo exportado"
 // @Failure 400 {object} response.APIResponse "Parámetros inválidos"
 // @Failure 500 {object} response.APIResponse "Error interno del servidor"
 // @Router /stocks [get]
 func (h *handler) GetStocks(c echo.Context) error {
 	params, err := parseStockParams(c)
+	if err == nil {
+		err = parseSortParam(c, &params)
+	}
+	if err == nil {
+		err = parseCursorParams(c, &params)
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	format, err := parseExportFormat(c)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, response.NewError(
 			http.StatusBadRequest,
@@ -47,16 +94,17 @@ func (h *handler) GetStocks(c echo.Context) error {
 		))
 	}
 
+	// Los formatos de archivo exportan todos los stocks filtrados, sin paginación
+	if format != exportFormatJSON {
+		return h.exportStocks(c, params, format)
+	}
+
+	if params.UseCursor {
+		return h.getStocksByCursor(c, params)
+	}
+
 	// Delegamos la búsqueda con paginación al servicio
-	stocksList, total, err := h.service.GetStocks(
-		params.Query,
-		params.Page,
-		params.Size,
-		params.Recommends,
-		params.MinTargetTo,
-		params.MaxTargetTo,
-		params.Currency,
-	)
+	stocksList, total, err := h.service.GetStocks(params)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, response.NewError(
 			http.StatusInternalServerError,
@@ -73,9 +121,87 @@ func (h *handler) GetStocks(c echo.Context) error {
 	))
 }
 
+// getStocksByCursor responde una página de stocks paginada por cursor
+func (h *handler) getStocksByCursor(c echo.Context, params domain.StockFilter) error {
+	page, err := h.service.GetStocksByCursor(params)
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error buscando stocks",
+			err.Error(),
+		))
+	}
+
+	paginated := response.NewCursorPaginated(page.Stocks, page.Total, params.Size, page.NextCursor)
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		paginated,
+		"Consulta de acciones exitosa",
+	))
+}
+
+// parseSortParam extrae el orden explícito del parámetro sort, por ejemplo "-target_to,ticker".
+// Cada campo debe estar en stockSortFields y aparecer una sola vez; el prefijo '-' indica orden descendente.
+func parseSortParam(c echo.Context, params *domain.StockFilter) error {
+	sortStr := c.QueryParam("sort")
+	if sortStr == "" {
+		return nil
+	}
+
+	for _, part := range strings.Split(sortStr, ",") {
+		// El prefijo '+' llega como espacio si no se codifica en la URL
+		field := strings.ToLower(strings.TrimSpace(part))
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(strings.TrimPrefix(field, "-"), "+")
+
+		if !slices.Contains(stockSortFields, field) {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("Sort no admite el campo %q; campos permitidos: %s", field, strings.Join(stockSortFields, ", ")))
+		}
+		if slices.ContainsFunc(params.Sort, func(sort domain.StockSort) bool { return sort.Field == field }) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Sort repite el campo %q", field))
+		}
+		params.Sort = append(params.Sort, domain.StockSort{Field: field, Desc: desc})
+	}
+
+	return nil
+}
+
+// parseCursorParams extrae los parámetros de la paginación por cursor de GET /stocks.
+// La presencia de cursor, aunque esté vacío, activa el modo; total indica si se cuenta el total de registros.
+func parseCursorParams(c echo.Context, params *domain.StockFilter) error {
+	if !c.QueryParams().Has("cursor") {
+		return nil
+	}
+	if c.QueryParam("page") != "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Page no puede combinarse con cursor")
+	}
+
+	params.UseCursor = true
+	params.Cursor = c.QueryParam("cursor")
+
+	// Parsing de total
+	if totalStr := c.QueryParam("total"); totalStr != "" {
+		total, err := strconv.ParseBool(totalStr)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Total debe ser un booleano")
+		}
+		params.WithTotal = total
+	}
+
+	return nil
+}
+
 // parseStockParams extrae y valida los parámetros de la solicitud
-func parseStockParams(c echo.Context) (StockParams, error) {
-	params := StockParams{
+func parseStockParams(c echo.Context) (domain.StockFilter, error) {
+	params := domain.StockFilter{
 		Query:    c.QueryParam("query"),
 		Page:     1,     // Valor por defecto
 		Size:     10,    // Valor por defecto
@@ -84,23 +210,13 @@ func parseStockParams(c echo.Context) (StockParams, error) {
 
 	log.Printf("Parámetros de consulta: %v", c.QueryParams())
 
-	// Parsing de page
-	if pageStr := c.QueryParam("page"); pageStr != "" {
-		page, err := strconv.Atoi(pageStr)
-		if err != nil || page < 1 {
-			return params, echo.NewHTTPError(http.StatusBadRequest, "Page debe ser un entero positivo")
-		}
-		params.Page = page
-	}
-
-	// Parsing de size
-	if sizeStr := c.QueryParam("size"); sizeStr != "" {
-		size, err := strconv.Atoi(sizeStr)
-		if err != nil || size < 1 || size > 100 {
-			return params, echo.NewHTTPError(http.StatusBadRequest, "Size debe ser un entero positivo y menor a 100")
-		}
-		params.Size = size
+	// Parsing de page y size
+	page, size, err := parsePagination(c)
+	if err != nil {
+		return params, err
 	}
+	params.Page = page
+	params.Size = size
 
 	// Parsing de recommends
 	if recommendsStr := c.QueryParam("recommends"); recommendsStr != "" {
@@ -134,5 +250,204 @@ func parseStockParams(c echo.Context) (StockParams, error) {
 		params.Currency = currencyStr
 	}
 
+	// Parsing de recent
+	if recentStr := c.QueryParam("recent"); recentStr != "" {
+		recent, err := strconv.ParseBool(recentStr)
+		if err != nil {
+			return params, echo.NewHTTPError(http.StatusBadRequest, "Recent debe ser un booleano")
+		}
+		params.Recent = recent
+	}
+
+	// Parsing de from
+	if fromStr := c.QueryParam("from"); fromStr != "" {
+		from, _, err := parseDateParam(fromStr)
+		if err != nil {
+			return params, echo.NewHTTPError(http.StatusBadRequest, "From debe ser una fecha YYYY-MM-DD o RFC3339")
+		}
+		params.From = from
+	}
+
+	// Parsing de to (si es solo fecha, se incluye el día completo)
+	if toStr := c.QueryParam("to"); toStr != "" {
+		to, dateOnly, err := parseDateParam(toStr)
+		if err != nil {
+			return params, echo.NewHTTPError(http.StatusBadRequest, "To debe ser una fecha YYYY-MM-DD o RFC3339")
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		params.To = to
+	}
+
+	// Validar que el rango de fechas sea coherente
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return params, echo.NewHTTPError(http.StatusBadRequest, "From debe ser anterior a To")
+	}
+
+	// Parsing de los filtros por campo
+	if err := parseFieldFilters(c, &params); err != nil {
+		return params, err
+	}
+
 	return params, nil
 }
+
+// parseFieldFilters extrae los filtros por campo, que se combinan con AND: ticker, brokerage, action,
+// ratingTo, ratingFrom y los rangos de puntaje, precio objetivo anterior y variación del precio objetivo
+func parseFieldFilters(c echo.Context, params *domain.StockFilter) error {
+	// Parsing de ticker (exacto, varios valores)
+	for _, ticker := range parseListParam(c, "ticker") {
+		params.Tickers = append(params.Tickers, strings.ToUpper(ticker))
+	}
+
+	// Parsing de brokerage (exacto)
+	if brokerageStr := strings.TrimSpace(c.QueryParam("brokerage")); brokerageStr != "" {
+		params.Brokerage = brokerageStr
+	}
+
+	// Parsing de action (tipos de acción, varios valores)
+	for _, action := range parseListParam(c, "action") {
+		action = strings.ToLower(action)
+		if _, ok := domain.StockActionPrefixes[action]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("Action no admite %q; tipos permitidos: %s", action, strings.Join(stockActionTypes(), ", ")))
+		}
+		params.Actions = append(params.Actions, action)
+	}
+
+	// Parsing de ratingTo y ratingFrom (categorías de calificación, varios valores)
+	var err error
+	if params.RatingTo, err = parseRatingBuckets(c, "ratingTo", "RatingTo"); err != nil {
+		return err
+	}
+	if params.RatingFrom, err = parseRatingBuckets(c, "ratingFrom", "RatingFrom"); err != nil {
+		return err
+	}
+
+	// Parsing de minScore y maxScore
+	if params.MinScore, err = parseOptionalFloat(c, "minScore", "MinScore"); err != nil {
+		return err
+	}
+	if params.MaxScore, err = parseOptionalFloat(c, "maxScore", "MaxScore"); err != nil {
+		return err
+	}
+	if params.MinScore != nil && params.MaxScore != nil && *params.MinScore > *params.MaxScore {
+		return echo.NewHTTPError(http.StatusBadRequest, "MinScore no puede ser mayor que MaxScore")
+	}
+
+	// Parsing de minTargetFrom y maxTargetFrom
+	if params.MinTargetFrom, err = parseOptionalFloat(c, "minTargetFrom", "MinTargetFrom"); err != nil {
+		return err
+	}
+	if params.MaxTargetFrom, err = parseOptionalFloat(c, "maxTargetFrom", "MaxTargetFrom"); err != nil {
+		return err
+	}
+	if params.MinTargetFrom != nil && params.MaxTargetFrom != nil && *params.MinTargetFrom > *params.MaxTargetFrom {
+		return echo.NewHTTPError(http.StatusBadRequest, "MinTargetFrom no puede ser mayor que MaxTargetFrom")
+	}
+
+	// Parsing de minTargetChangePct y maxTargetChangePct
+	if params.MinTargetChangePct, err = parseOptionalFloat(c, "minTargetChangePct", "MinTargetChangePct"); err != nil {
+		return err
+	}
+	if params.MaxTargetChangePct, err = parseOptionalFloat(c, "maxTargetChangePct", "MaxTargetChangePct"); err != nil {
+		return err
+	}
+	if params.MinTargetChangePct != nil && params.MaxTargetChangePct != nil && *params.MinTargetChangePct > *params.MaxTargetChangePct {
+		return echo.NewHTTPError(http.StatusBadRequest, "MinTargetChangePct no puede ser mayor que MaxTargetChangePct")
+	}
+
+	return nil
+}
+
+// parseListParam obtiene los valores de un parámetro que admite varios, repetido o separado por comas
+func parseListParam(c echo.Context, name string) []string {
+	var values []string
+	for _, raw := range c.QueryParams()[name] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// parseRatingBuckets obtiene las categorías de calificación de un parámetro: buy, hold, sell u other
+func parseRatingBuckets(c echo.Context, name, label string) ([]string, error) {
+	var buckets []string
+	for _, bucket := range parseListParam(c, name) {
+		bucket = strings.ToLower(bucket)
+		switch bucket {
+		case domain.RatingBucketBuy, domain.RatingBucketHold, domain.RatingBucketSell, domain.RatingBucketOther:
+			buckets = append(buckets, bucket)
+		default:
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s debe ser buy, hold, sell u other", label))
+		}
+	}
+	return buckets, nil
+}
+
+// parseOptionalFloat interpreta un parámetro numérico opcional; nil si no se indica
+func parseOptionalFloat(c echo.Context, name, label string) (*float64, error) {
+	valueStr := c.QueryParam(name)
+	if valueStr == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s debe ser un número", label))
+	}
+	return &value, nil
+}
+
+// stockActionTypes devuelve los tipos de acción admitidos por el filtro action, ordenados
+func stockActionTypes() []string {
+	types := make([]string, 0, len(domain.StockActionPrefixes))
+	for action := range domain.StockActionPrefixes {
+		types = append(types, action)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// parsePagination extrae los parámetros page y size, con valores por defecto 1 y 10
+func parsePagination(c echo.Context) (int, int, error) {
+	page, size := 1, 10
+
+	// Parsing de page
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		parsed, err := strconv.Atoi(pageStr)
+		if err != nil || parsed < 1 {
+			return 0, 0, echo.NewHTTPError(http.StatusBadRequest, "Page debe ser un entero positivo")
+		}
+		page = parsed
+	}
+
+	// Parsing de size
+	if sizeStr := c.QueryParam("size"); sizeStr != "" {
+		parsed, err := strconv.Atoi(sizeStr)
+		if err != nil || parsed < 1 || parsed > 100 {
+			return 0, 0, echo.NewHTTPError(http.StatusBadRequest, "Size debe ser un entero positivo y menor a 100")
+		}
+		size = parsed
+	}
+
+	return page, size, nil
+}
+
+// parseDateParam interpreta una fecha en formato YYYY-MM-DD o RFC3339.
+// Indica además si el valor recibido era solo una fecha sin hora.
+func parseDateParam(value string) (time.Time, bool, error) {
+	if date, err := time.Parse(dateOnlyLayout, value); err == nil {
+		return date, true, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return date.UTC(), false, nil
+}
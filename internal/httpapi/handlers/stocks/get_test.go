@@ -5,12 +5,15 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // TestGetStocks_Success verifica que una solicitud válida devuelva stocks correctamente
@@ -39,7 +42,7 @@ func TestGetStocks_Success(t *testing.T) {
 
 	// Crear el servicio mock
 	mockService := new(mockStockService)
-	mockService.On("GetStocks", "AAPL", 1, 10, false, 0.0, 0.0, "USD").Return(mockStocks, int64(1), nil)
+	mockService.On("GetStocks", domain.StockFilter{Query: "AAPL", Page: 1, Size: 10, Currency: "USD"}).Return(mockStocks, int64(1), nil)
 
 	// Crear el handler con el servicio mock
 	h := &handler{service: mockService}
@@ -96,7 +99,7 @@ func TestGetStocks_WithRecommendation(t *testing.T) {
 
 	// Crear el servicio mock
 	mockService := new(mockStockService)
-	mockService.On("GetStocks", "TECH", 1, 10, true, 0.0, 0.0, "USD").Return(mockStocks, int64(1), nil)
+	mockService.On("GetStocks", domain.StockFilter{Query: "TECH", Page: 1, Size: 10, Recommends: true, Currency: "USD"}).Return(mockStocks, int64(1), nil)
 
 	// Crear el handler con el servicio mock
 	h := &handler{service: mockService}
@@ -144,7 +147,7 @@ func TestGetStocks_WithPriceFilter(t *testing.T) {
 
 	// Crear el servicio mock
 	mockService := new(mockStockService)
-	mockService.On("GetStocks", "", 1, 10, false, 100.0, 200.0, "EUR").Return(mockStocks, int64(1), nil)
+	mockService.On("GetStocks", domain.StockFilter{Page: 1, Size: 10, MinTargetTo: 100.0, MaxTargetTo: 200.0, Currency: "EUR"}).Return(mockStocks, int64(1), nil)
 
 	// Crear el handler con el servicio mock
 	h := &handler{service: mockService}
@@ -203,7 +206,7 @@ func TestGetStocks_ServiceError(t *testing.T) {
 	// Crear el servicio mock que devolverá un error
 	mockService := new(mockStockService)
 	expectedError := errors.New("error de base de datos")
-	mockService.On("GetStocks", "", 1, 10, false, 0.0, 0.0, "USD").Return([]domain.Stock{}, int64(0), expectedError)
+	mockService.On("GetStocks", domain.StockFilter{Page: 1, Size: 10, Currency: "USD"}).Return([]domain.Stock{}, int64(0), expectedError)
 
 	// Crear el handler con el servicio mock
 	h := &handler{service: mockService}
@@ -226,3 +229,295 @@ func TestGetStocks_ServiceError(t *testing.T) {
 	// Verificar que se llamó al método del servicio
 	mockService.AssertExpectations(t)
 }
+
+// TestGetStocks_WithDateRange verifica los filtros por fecha y el orden por recencia
+func TestGetStocks_WithDateRange(t *testing.T) {
+	// Configurar el contexto Echo con rango de fechas y orden por recencia
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/stocks?from=2025-01-06&to=2025-01-12&recent=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// El día final se incluye completo, por lo que el límite superior es el día siguiente
+	expectedFilter := domain.StockFilter{
+		Page:     1,
+		Size:     10,
+		Recent:   true,
+		Currency: "USD",
+		From:     time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Crear el servicio mock
+	mockService := new(mockStockService)
+	mockService.On("GetStocks", expectedFilter).Return([]domain.Stock{}, int64(0), nil)
+
+	// Crear el handler con el servicio mock
+	h := &handler{service: mockService}
+
+	// Ejecutar el handler
+	err := h.GetStocks(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Verificar que se llamó al método del servicio con el rango correcto
+	mockService.AssertExpectations(t)
+}
+
+// TestGetStocks_InvalidDateRange verifica que se rechacen fechas inválidas o invertidas
+func TestGetStocks_InvalidDateRange(t *testing.T) {
+	testCases := []string{
+		"/stocks?from=13-01-2025",
+		"/stocks?to=invalid",
+		"/stocks?from=2025-01-13&to=2025-01-06",
+	}
+
+	for _, target := range testCases {
+		t.Run(target, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Crear el servicio mock (no debería ser llamado)
+			mockService := new(mockStockService)
+			h := &handler{service: mockService}
+
+			// Ejecutar el handler
+			err := h.GetStocks(c)
+
+			// Verificar resultados
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockService.AssertNotCalled(t, "GetStocks")
+		})
+	}
+}
+
+// TestGetStocks_Cursor verifica la paginación por cursor: el cursor vacío inicia el recorrido y el total es opcional
+func TestGetStocks_Cursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		filter     domain.StockFilter
+		total      *int64
+		hasTotal   bool
+		nextCursor string
+	}{
+		{
+			name:       "primera página sin total",
+			target:     "/stocks?cursor=&size=2&recommends=true",
+			filter:     domain.StockFilter{Page: 1, Size: 2, Currency: "USD", Recommends: true, UseCursor: true},
+			nextCursor: "next",
+		},
+		{
+			name:     "página siguiente con total",
+			target:   "/stocks?cursor=abc&total=true",
+			filter:   domain.StockFilter{Page: 1, Size: 10, Currency: "USD", UseCursor: true, Cursor: "abc", WithTotal: true},
+			total:    func() *int64 { total := int64(12); return &total }(),
+			hasTotal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, tt.target, nil), rec)
+
+			mockService := new(mockStockService)
+			mockService.On("GetStocksByCursor", tt.filter).
+				Return(domain.StockPage{Stocks: []domain.Stock{{ID: 1, Ticker: "AAPL"}}, Total: tt.total, NextCursor: tt.nextCursor}, nil)
+
+			h := &handler{service: mockService}
+
+			assert.NoError(t, h.GetStocks(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var res response.APIResponse
+			json.Unmarshal(rec.Body.Bytes(), &res)
+			data := res.Data.(map[string]interface{})
+			assert.Len(t, data["content"], 1)
+			assert.Equal(t, float64(tt.filter.Size), data["size"])
+			assert.NotContains(t, data, "page")
+			_, hasTotal := data["total"]
+			assert.Equal(t, tt.hasTotal, hasTotal)
+			if tt.nextCursor != "" {
+				assert.Equal(t, tt.nextCursor, data["next_cursor"])
+			} else {
+				assert.NotContains(t, data, "next_cursor")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestGetStocks_CursorErrors verifica los errores de la paginación por cursor
+func TestGetStocks_CursorErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		err          error
+		expectedCode int
+	}{
+		{name: "cursor con page", target: "/stocks?cursor=&page=2", expectedCode: http.StatusBadRequest},
+		{name: "total inválido", target: "/stocks?cursor=&total=maybe", expectedCode: http.StatusBadRequest},
+		{name: "cursor inválido", target: "/stocks?cursor=xyz", err: domain.ErrInvalidCursor, expectedCode: http.StatusBadRequest},
+		{name: "error del servicio", target: "/stocks?cursor=", err: errors.New("db error"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, tt.target, nil), rec)
+
+			mockService := new(mockStockService)
+			if tt.err != nil {
+				mockService.On("GetStocksByCursor", mock.Anything).Return(domain.StockPage{}, tt.err)
+			}
+
+			h := &handler{service: mockService}
+
+			assert.NoError(t, h.GetStocks(c))
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestParseSortParam verifica el parámetro sort: dirección, campos permitidos y repetidos
+func TestParseSortParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     string
+		expected []domain.StockSort
+		wantErr  bool
+	}{
+		{name: "sin orden", sort: ""},
+		{
+			name: "varios campos",
+			sort: "-target_to,ticker",
+			expected: []domain.StockSort{
+				{Field: domain.StockSortTargetTo, Desc: true},
+				{Field: domain.StockSortTicker},
+			},
+		},
+		{
+			name: "prefijo + sin codificar y mayúsculas",
+			sort: " Target_Change_Pct, -Rated_At",
+			expected: []domain.StockSort{
+				{Field: domain.StockSortTargetChangePct},
+				{Field: domain.StockSortRatedAt, Desc: true},
+			},
+		},
+		{name: "campo no permitido", sort: "brokerage", wantErr: true},
+		{name: "inyección", sort: "ticker;DROP TABLE stocks", wantErr: true},
+		{name: "campo vacío", sort: "ticker,,id", wantErr: true},
+		{name: "campo repetido", sort: "ticker,-ticker", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/stocks", nil)
+			req.URL.RawQuery = url.Values{"sort": {tt.sort}}.Encode()
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			var params domain.StockFilter
+			err := parseSortParam(c, &params)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, params.Sort)
+		})
+	}
+}
+
+// TestGetStocks_Sort verifica que el orden llegue al servicio y que un campo no permitido se rechace
+func TestGetStocks_Sort(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/stocks?sort=-target_to,ticker&recommends=true", nil), rec)
+
+	mockService := new(mockStockService)
+	mockService.On("GetStocks", domain.StockFilter{Page: 1, Size: 10, Currency: "USD", Recommends: true, Sort: []domain.StockSort{
+		{Field: domain.StockSortTargetTo, Desc: true},
+		{Field: domain.StockSortTicker},
+	}}).Return([]domain.Stock{}, int64(0), nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+
+	rec = httptest.NewRecorder()
+	c = echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/stocks?sort=action", nil), rec)
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestGetStocks_FieldFilters verifica que los filtros por campo lleguen al servicio normalizados
+func TestGetStocks_FieldFilters(t *testing.T) {
+	target := "/stocks?ticker=aapl,msft&ticker=NVDA&brokerage=Goldman%20Sachs&action=Upgraded,target_raised" +
+		"&ratingTo=buy&ratingFrom=hold,other&minScore=-5&maxScore=20&minTargetFrom=100&maxTargetFrom=200&minTargetChangePct=10"
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)
+
+	minScore, maxScore, minChange := -5.0, 20.0, 10.0
+	minTarget, maxTarget := 100.0, 200.0
+	mockService := new(mockStockService)
+	mockService.On("GetStocks", domain.StockFilter{
+		Page:               1,
+		Size:               10,
+		Currency:           "USD",
+		Brokerage:          "Goldman Sachs",
+		Tickers:            []string{"AAPL", "MSFT", "NVDA"},
+		Actions:            []string{domain.StockActionUpgraded, domain.StockActionTargetRaised},
+		RatingTo:           []string{domain.RatingBucketBuy},
+		RatingFrom:         []string{domain.RatingBucketHold, domain.RatingBucketOther},
+		MinScore:           &minScore,
+		MaxScore:           &maxScore,
+		MinTargetFrom:      &minTarget,
+		MaxTargetFrom:      &maxTarget,
+		MinTargetChangePct: &minChange,
+	}).Return([]domain.Stock{}, int64(0), nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetStocks_InvalidFieldFilters verifica la validación de los filtros por campo
+func TestGetStocks_InvalidFieldFilters(t *testing.T) {
+	targets := []string{
+		"/stocks?action=merged",
+		"/stocks?ratingTo=strong",
+		"/stocks?ratingFrom=buy,maybe",
+		"/stocks?minScore=abc",
+		"/stocks?minScore=10&maxScore=5",
+		"/stocks?maxTargetFrom=x",
+		"/stocks?minTargetFrom=200&maxTargetFrom=100",
+		"/stocks?minTargetFrom=5&maxTargetFrom=0",
+		"/stocks?minTargetChangePct=5&maxTargetChangePct=-5",
+	}
+
+	for _, target := range targets {
+		t.Run(target, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)
+
+			mockService := new(mockStockService)
+			h := &handler{service: mockService}
+
+			assert.NoError(t, h.GetStocks(c))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			mockService.AssertNotCalled(t, "GetStocks", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package stocks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportFileSize es el tamaño máximo del archivo de una importación
+const maxImportFileSize = 32 << 20
+
+// maxImportRequestSize es el tamaño máximo del cuerpo de una importación: el archivo más los demás campos
+// y los delimitadores del formulario
+const maxImportRequestSize = maxImportFileSize + 1<<20
+
+// @Summary Importar stocks desde un archivo
+// @Description Importa calificaciones desde un archivo CSV (con encabezado) o NDJSON (un objeto por línea) con los campos de la API externa.
+// @Description Cada fila se convierte y puntúa como en la sincronización y las filas inválidas se registran en los rechazos con la línea del archivo.
+// @Description Los stocks se guardan por lotes a medida que se leen; si la importación falla, los lotes ya guardados se conservan. Con mode=replace se eliminan además, después del último lote, los stocks que no están en el archivo; un archivo sin filas válidas no modifica los stocks
+// @Tags stocks
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Archivo CSV o NDJSON (máximo 32 MB)"
+// @Param format formData string false "Formato del archivo; por defecto se deduce de la extensión (.csv, .ndjson, .jsonl)" Enums(csv, ndjson)
+// @Param mode formData string false "append (por defecto) agrega y actualiza; replace además elimina los stocks ausentes" Enums(append, replace)
+// @Success 200 {object} response.APIResponse{data=domain.ImportResult} "Resumen de la importación"
+// @Failure 400 {object} response.APIResponse "Archivo o parámetros inválidos"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 413 {object} response.APIResponse "Archivo demasiado grande"
+// @Failure 500 {object} response.APIResponse "Error del servidor"
+// @Security AdminToken
+// @Router /stocks/import [post]
+func (h *handler) ImportStocks(c echo.Context) error {
+	// Limitar el cuerpo antes de leer el formulario, para no cargar en memoria ni en disco un archivo mayor al permitido
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, maxImportRequestSize)
+
+	file, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return c.JSON(http.StatusRequestEntityTooLarge, response.NewError(
+			http.StatusRequestEntityTooLarge,
+			"El archivo es demasiado grande",
+			fmt.Sprintf("el tamaño máximo es %d MB", maxImportFileSize>>20),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"El archivo 'file' es obligatorio",
+			err.Error(),
+		))
+	}
+	if file.Size > maxImportFileSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, response.NewError(
+			http.StatusRequestEntityTooLarge,
+			"El archivo es demasiado grande",
+			fmt.Sprintf("el tamaño máximo es %d MB", maxImportFileSize>>20),
+		))
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.FormValue("format")))
+	if format == "" {
+		format = domain.ImportFormatFromName(file.Filename)
+	}
+	if format == "" {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"No se pudo determinar el formato del archivo",
+			"indica 'format' (csv o ndjson) o usa la extensión .csv, .ndjson o .jsonl",
+		))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Error al leer el archivo",
+			err.Error(),
+		))
+	}
+	defer src.Close()
+
+	opts := domain.ImportOptions{
+		Format: format,
+		Mode:   strings.ToLower(strings.TrimSpace(c.FormValue("mode"))),
+	}
+	result, err := h.service.ImportStocks(c.Request().Context(), src, opts)
+	if errors.Is(err, domain.ErrInvalidImport) {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Importación inválida",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error importando stocks",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		result,
+		"Importación completada exitosamente",
+	))
+}
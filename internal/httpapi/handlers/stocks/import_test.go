@@ -0,0 +1,126 @@
+package stocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newImportRequest construye una solicitud multipart con el archivo y los campos indicados
+func newImportRequest(t *testing.T, filename, content string, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if filename != "" {
+		part, err := writer.CreateFormFile("file", filename)
+		assert.NoError(t, err)
+		part.Write([]byte(content))
+	}
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/stocks/import", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+// TestImportStocks_Success verifica que el formato se deduzca de la extensión y se informe el resumen
+func TestImportStocks_Success(t *testing.T) {
+	content := "ticker,brokerage,target_from,target_to\nAAPL,Example,$10,$12\n"
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(newImportRequest(t, "stocks.csv", content, map[string]string{"mode": "Replace"}), rec)
+
+	mockService := new(mockStockService)
+	mockService.On("ImportStocks", content, domain.ImportOptions{Format: "csv", Mode: "replace"}).
+		Return(domain.ImportResult{Format: "csv", Mode: "replace", RowsRead: 1, ItemsParsed: 1, Inserted: 1}, nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.ImportStocks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	data := res.Data.(map[string]interface{})
+	assert.Equal(t, float64(1), data["inserted"])
+	assert.Equal(t, "replace", data["mode"])
+	mockService.AssertExpectations(t)
+}
+
+// TestImportStocks_Errors verifica los códigos de respuesta de las solicitudes inválidas y de los errores del servicio
+func TestImportStocks_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		filename     string
+		fields       map[string]string
+		err          error
+		expectedCode int
+	}{
+		{name: "sin archivo", expectedCode: http.StatusBadRequest},
+		{name: "formato desconocido", filename: "stocks.txt", expectedCode: http.StatusBadRequest},
+		{name: "importación inválida", filename: "stocks.txt", fields: map[string]string{"format": "ndjson"},
+			err: fmt.Errorf("%w: modo desconocido", domain.ErrInvalidImport), expectedCode: http.StatusBadRequest},
+		{name: "error del servicio", filename: "stocks.jsonl", err: errors.New("db error"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(newImportRequest(t, tt.filename, "{}", tt.fields), rec)
+
+			mockService := new(mockStockService)
+			if tt.err != nil {
+				mockService.On("ImportStocks", "{}", mock.Anything).Return(domain.ImportResult{}, tt.err)
+			}
+
+			h := &handler{service: mockService}
+
+			assert.NoError(t, h.ImportStocks(c))
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// countingReader cuenta los bytes leídos del cuerpo de una solicitud
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+// TestImportStocks_TooLarge verifica que un archivo mayor al máximo se rechace sin leer el cuerpo completo
+func TestImportStocks_TooLarge(t *testing.T) {
+	req := newImportRequest(t, "stocks.csv", strings.Repeat("a", maxImportRequestSize+1<<20), nil)
+	body := &countingReader{r: req.Body}
+	req.Body = io.NopCloser(body)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.ImportStocks(c))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.LessOrEqual(t, body.read, maxImportRequestSize+64<<10, "No debe leerse el cuerpo más allá del límite")
+	mockService.AssertNotCalled(t, "ImportStocks", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,46 @@
+package stocks
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// RescoreStocks
+// @Summary Recalcular puntajes de recomendación
+// @Description Encola un recálculo por lotes del puntaje de todos los stocks guardados con los factores de recomendación actuales, sin sincronizar con la fuente externa. Se ejecuta en la cola de sincronización; al terminar, el job consultado en /stocks/sync/{id} registra en rescore cuántos puntajes cambiaron y la distribución antes y después
+// @Tags stocks
+// @Produce json
+// @Success 202 {object} response.APIResponse{data=domain.SyncJob} "Recálculo encolado"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Failure 503 {object} response.APIResponse "Cola de sincronización llena"
+// @Security AdminToken
+// @Router /stocks/rescore [post]
+func (h *handler) RescoreStocks(c echo.Context) error {
+	job, err := h.service.EnqueueRescore()
+	if errors.Is(err, domain.ErrSyncQueueFull) {
+		return c.JSON(http.StatusServiceUnavailable, response.NewError(
+			http.StatusServiceUnavailable,
+			"No es posible encolar el recálculo",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error recalculando puntajes",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusAccepted, response.NewSuccess(
+		http.StatusAccepted,
+		job,
+		"Recálculo de puntajes encolado",
+	))
+}
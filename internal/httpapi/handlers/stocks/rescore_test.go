@@ -0,0 +1,81 @@
+package stocks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRescoreStocks_Success verifica que se devuelva el job del recálculo encolado
+func TestRescoreStocks_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/stocks/rescore", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	mockService.On("EnqueueRescore").Return(domain.SyncJob{
+		ID:    4,
+		Kind:  domain.SyncJobKindRescore,
+		State: domain.SyncJobQueued,
+	}, nil)
+
+	h := &handler{service: mockService}
+	err := h.RescoreStocks(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var response response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	data, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, float64(4), data["id"])
+	assert.Equal(t, domain.SyncJobKindRescore, data["kind"])
+
+	mockService.AssertExpectations(t)
+}
+
+// TestRescoreStocks_QueueFull verifica que una cola llena devuelva un error 503
+func TestRescoreStocks_QueueFull(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/stocks/rescore", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	mockService.On("EnqueueRescore").Return(domain.SyncJob{}, domain.ErrSyncQueueFull)
+
+	h := &handler{service: mockService}
+	err := h.RescoreStocks(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestRescoreStocks_ServiceError verifica que un error del servicio devuelva un error 500
+func TestRescoreStocks_ServiceError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/stocks/rescore", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	mockService.On("EnqueueRescore").Return(domain.SyncJob{}, errors.New("error de base de datos"))
+
+	h := &handler{service: mockService}
+	err := h.RescoreStocks(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,55 @@
+package stocks
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// GetStockScore
+// @Summary Explicar el puntaje de recomendación de un stock
+// @Description Devuelve los componentes del puntaje (cambio porcentual, calificación, acción y bonificación absoluta), los factores externos aplicados, las ponderaciones y los modificadores de contexto evaluados
+// @Tags stocks
+// @Produce json
+// @Param id path int true "ID del stock"
+// @Success 200 {object} response.APIResponse{data=domain.ScoreBreakdown} "Desglose del puntaje"
+// @Failure 400 {object} response.APIResponse "ID inválido"
+// @Failure 404 {object} response.APIResponse "Stock no encontrado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /stocks/{id}/score [get]
+func (h *handler) GetStockScore(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"El ID del stock debe ser un entero positivo",
+			"",
+		))
+	}
+
+	breakdown, err := h.service.ExplainScore(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, response.NewError(
+			http.StatusNotFound,
+			"Stock no encontrado",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error calculando el puntaje",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		breakdown,
+		"Desglose del puntaje exitoso",
+	))
+}
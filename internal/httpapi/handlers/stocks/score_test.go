@@ -0,0 +1,100 @@
+package stocks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// newStockScoreContext crea un contexto Echo para GET /stocks/:id/score
+func newStockScoreContext(id string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/stocks/"+id+"/score", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/stocks/:id/score")
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
+// TestGetStockScore_Success verifica que se devuelva el desglose del puntaje
+func TestGetStockScore_Success(t *testing.T) {
+	c, rec := newStockScoreContext("5")
+
+	mockService := new(mockStockService)
+	mockService.On("ExplainScore", int64(5)).Return(domain.ScoreBreakdown{
+		StockID:    5,
+		Ticker:     "AAPL",
+		Components: domain.ScoreComponents{PercentDiff: 20, Rating: 20},
+		Modifiers: []domain.ScoreModifier{
+			{Name: domain.ScoreModifierDecreasingTarget, Factor: 0.4},
+		},
+		FinalScore: 12.5,
+	}, nil)
+
+	h := &handler{service: mockService}
+	err := h.GetStockScore(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	data, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, 12.5, data["final_score"])
+	assert.Equal(t, float64(20), data["components"].(map[string]interface{})["percent_diff"])
+	assert.Len(t, data["modifiers"], 1)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetStockScore_InvalidID verifica que un ID inválido devuelva un error 400
+func TestGetStockScore_InvalidID(t *testing.T) {
+	c, rec := newStockScoreContext("abc")
+
+	mockService := new(mockStockService)
+	h := &handler{service: mockService}
+	err := h.GetStockScore(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "ExplainScore")
+}
+
+// TestGetStockScore_NotFound verifica que un stock inexistente devuelva un error 404
+func TestGetStockScore_NotFound(t *testing.T) {
+	c, rec := newStockScoreContext("99")
+
+	mockService := new(mockStockService)
+	mockService.On("ExplainScore", int64(99)).Return(domain.ScoreBreakdown{}, domain.ErrNotFound)
+	h := &handler{service: mockService}
+	err := h.GetStockScore(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetStockScore_ServiceError verifica que un error del servicio devuelva un error 500
+func TestGetStockScore_ServiceError(t *testing.T) {
+	c, rec := newStockScoreContext("1")
+
+	mockService := new(mockStockService)
+	mockService.On("ExplainScore", int64(1)).Return(domain.ScoreBreakdown{}, errors.New("error de base de datos"))
+	h := &handler{service: mockService}
+	err := h.GetStockScore(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockService.AssertExpectations(t)
+}
@@ -4,13 +4,16 @@ import (
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
 
+	"github.com/julianloaiza/stock-advisor/config"
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/middleware"
 	"github.com/julianloaiza/stock-advisor/internal/services/stocks"
 )
 
 // handler implementa la interfaz handlers.Handler.
 type handler struct {
-	service stocks.Service
+	service    stocks.Service
+	adminToken string
 }
 
 // Result es el tipo para publicar el handler en el grupo de handlers.
@@ -21,15 +24,34 @@ type Result struct {
 }
 
 // New construye el handler de stocks y lo expone como parte del grupo "handlers".
-func New(service stocks.Service) Result {
+func New(service stocks.Service, cfg *config.Config) Result {
 	return Result{
-		Handler: &handler{service: service},
+		Handler: &handler{service: service, adminToken: cfg.AdminToken},
 	}
 }
 
-// RegisterRoutes registra las rutas de stocks.
+// RegisterRoutes registra las rutas de stocks y de sus agregaciones por ticker y por brokerage.
+// Las rutas que modifican los stocks (recálculo, importación y sincronización) se protegen con ADMIN_TOKEN.
 func (h *handler) RegisterRoutes(e *echo.Echo) {
+	admin := middleware.RequireAdminToken(h.adminToken)
+
 	group := e.Group("/stocks")
 	group.GET("", h.GetStocks)
-	group.POST("/sync", h.SyncStocks)
+	group.GET("/:id/score", h.GetStockScore)
+	group.POST("/rescore", h.RescoreStocks, admin)
+	group.POST("/import", h.ImportStocks, admin)
+	group.POST("/sync", h.SyncStocks, admin)
+	group.GET("/sync/rejects", h.GetSyncRejects)
+	group.GET("/sync/runs", h.GetSyncRuns)
+	group.GET("/sync/runs/:id/diff", h.GetSyncRunDiff)
+	group.GET("/sync/:id", h.GetSyncJob)
+
+	tickers := e.Group("/tickers")
+	tickers.GET("", h.GetTickers)
+	tickers.GET("/:ticker", h.GetTicker)
+	tickers.GET("/:ticker/history", h.GetTickerHistory)
+
+	brokerages := e.Group("/brokerages")
+	brokerages.GET("", h.GetBrokerages)
+	brokerages.GET("/:name/stocks", h.GetBrokerageStocks)
 }
@@ -1,27 +1,36 @@
 package stocks
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
+	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
 	"github.com/labstack/echo/v4"
 )
 
 // SyncRequest estructura para la solicitud de sincronización
 type SyncRequest struct {
-	Limit int `json:"limit" example:"5" minimum:"1"` // Número de iteraciones para la sincronización
+	Limit  int  `json:"limit" example:"5" minimum:"1"` // Número de iteraciones para la sincronización
+	Resume bool `json:"resume" example:"false"`        // Reanudar la última sincronización fallida desde su último checkpoint
+	DryRun bool `json:"dryRun" example:"false"`        // Obtener y procesar las páginas sin guardar nada y registrar en el job lo que cambiaría
 }
 
 // @Summary Sincronizar stocks desde fuente externa
-// @Description Actualiza la base de datos con información de acciones desde un servicio externo
+// @Description Encola una sincronización con el servicio externo y devuelve el job para consultar su estado.
+// @Description Con dryRun, el job obtiene y procesa las páginas sin modificar la base de datos y al terminar registra en preview lo que cambiaría: totales, una muestra de stocks, los motivos de rechazo y la distribución de puntajes
 // @Tags stocks
 // @Accept json
 // @Produce json
 // @Param request body SyncRequest true "Parámetros de sincronización"
-// @Success 200 {object} response.APIResponse "Sincronización exitosa"
+// @Success 202 {object} response.APIResponse{data=domain.SyncJob} "Sincronización encolada"
 // @Failure 400 {object} response.APIResponse "Error en la solicitud"
+// @Failure 401 {object} response.APIResponse "Token de administración ausente o inválido"
+// @Failure 403 {object} response.APIResponse "ADMIN_TOKEN no configurado"
 // @Failure 500 {object} response.APIResponse "Error del servidor"
+// @Failure 503 {object} response.APIResponse "Cola de sincronización llena"
+// @Security AdminToken
 // @Router /stocks/sync [post]
 func (h *handler) SyncStocks(c echo.Context) error {
 	// Usar un struct para bindear el body
@@ -44,8 +53,16 @@ func (h *handler) SyncStocks(c echo.Context) error {
 	}
 	log.Printf("Se utilizará el parámetro 'limit': %d", req.Limit)
 
-	// Ejecutar la sincronización en el servicio.
-	if err := h.service.SyncStocks(c.Request().Context(), req.Limit); err != nil {
+	// Encolar la sincronización en el servicio; se ejecuta fuera del ciclo de la petición, también en dry run.
+	job, err := h.service.EnqueueSync(domain.SyncOptions{Limit: req.Limit, Resume: req.Resume, DryRun: req.DryRun})
+	if errors.Is(err, domain.ErrSyncQueueFull) {
+		return c.JSON(http.StatusServiceUnavailable, response.NewError(
+			http.StatusServiceUnavailable,
+			"No es posible encolar la sincronización",
+			err.Error(),
+		))
+	}
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, response.NewError(
 			http.StatusInternalServerError,
 			"Error sincronizando stocks",
@@ -53,9 +70,9 @@ func (h *handler) SyncStocks(c echo.Context) error {
 		))
 	}
 
-	return c.JSON(http.StatusOK, response.NewSuccess(
-		http.StatusOK,
-		nil,
-		"Sincronización completada exitosamente",
+	return c.JSON(http.StatusAccepted, response.NewSuccess(
+		http.StatusAccepted,
+		job,
+		"Sincronización encolada exitosamente",
 	))
 }
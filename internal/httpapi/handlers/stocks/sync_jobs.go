@@ -0,0 +1,55 @@
+package stocks
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// GetSyncJob
+// @Summary Consultar el estado de una sincronización
+// @Description Devuelve el estado (queued, running, succeeded, failed) y el progreso de un job de sincronización
+// @Tags stocks
+// @Produce json
+// @Param id path int true "ID del job de sincronización"
+// @Success 200 {object} response.APIResponse{data=domain.SyncJob} "Estado de la sincronización"
+// @Failure 400 {object} response.APIResponse "ID inválido"
+// @Failure 404 {object} response.APIResponse "Job no encontrado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /stocks/sync/{id} [get]
+func (h *handler) GetSyncJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"El ID del job debe ser un entero positivo",
+			"",
+		))
+	}
+
+	job, err := h.service.GetSyncJob(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, response.NewError(
+			http.StatusNotFound,
+			"Job de sincronización no encontrado",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error consultando la sincronización",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		job,
+		"Consulta de sincronización exitosa",
+	))
+}
@@ -0,0 +1,116 @@
+package stocks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSyncJobContext crea un contexto Echo para GET /stocks/sync/:id
+func newSyncJobContext(id string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/stocks/sync/"+id, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/stocks/sync/:id")
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
+// TestGetSyncJob_Success verifica que se devuelva el estado del job
+func TestGetSyncJob_Success(t *testing.T) {
+	c, rec := newSyncJobContext("42")
+
+	// Crear el servicio mock
+	mockService := new(mockStockService)
+	mockService.On("GetSyncJob", int64(42)).Return(domain.SyncJob{
+		ID:         42,
+		State:      domain.SyncJobRunning,
+		Limit:      5,
+		SyncResult: domain.SyncResult{PagesFetched: 2, ItemsParsed: 19, ItemsRejected: 1},
+	}, nil)
+
+	// Crear el handler con el servicio mock
+	h := &handler{service: mockService}
+
+	// Ejecutar el handler
+	err := h.GetSyncJob(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Verificar respuesta JSON
+	var response response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	data, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, domain.SyncJobRunning, data["state"])
+	assert.Equal(t, float64(2), data["pages_fetched"])
+	assert.Equal(t, float64(19), data["items_parsed"])
+	assert.Equal(t, float64(1), data["items_rejected"])
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetSyncJob_InvalidID verifica que un ID inválido devuelva un error 400
+func TestGetSyncJob_InvalidID(t *testing.T) {
+	c, rec := newSyncJobContext("abc")
+
+	// Crear el servicio mock (no debería ser llamado)
+	mockService := new(mockStockService)
+	h := &handler{service: mockService}
+
+	// Ejecutar el handler
+	err := h.GetSyncJob(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "GetSyncJob")
+}
+
+// TestGetSyncJob_NotFound verifica que un job inexistente devuelva un error 404
+func TestGetSyncJob_NotFound(t *testing.T) {
+	c, rec := newSyncJobContext("99")
+
+	// Crear el servicio mock que no encuentra el job
+	mockService := new(mockStockService)
+	mockService.On("GetSyncJob", int64(99)).Return(domain.SyncJob{}, domain.ErrNotFound)
+	h := &handler{service: mockService}
+
+	// Ejecutar el handler
+	err := h.GetSyncJob(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetSyncJob_ServiceError verifica que un error del servicio devuelva un error 500
+func TestGetSyncJob_ServiceError(t *testing.T) {
+	c, rec := newSyncJobContext("1")
+
+	// Crear el servicio mock que devuelve un error
+	mockService := new(mockStockService)
+	mockService.On("GetSyncJob", int64(1)).Return(domain.SyncJob{}, errors.New("error de base de datos"))
+	h := &handler{service: mockService}
+
+	// Ejecutar el handler
+	err := h.GetSyncJob(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockService.AssertExpectations(t)
+}
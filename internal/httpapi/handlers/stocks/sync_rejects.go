@@ -0,0 +1,45 @@
+package stocks
+
+import (
+	"net/http"
+
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// GetSyncRejects
+// @Summary Consultar elementos rechazados en las sincronizaciones
+// @Description Devuelve, del más reciente al más antiguo, los elementos de la API externa que no pudieron convertirse en stocks, con la iteración, el error y el contenido original
+// @Tags stocks
+// @Produce json
+// @Param page query int false "Número de página (por defecto: 1)" default(1)
+// @Param size query int false "Registros por página (por defecto: 10)" default(10)
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData{content=[]domain.SyncReject}} "Consulta de rechazos exitosa"
+// @Failure 400 {object} response.APIResponse "Parámetros inválidos"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /stocks/sync/rejects [get]
+func (h *handler) GetSyncRejects(c echo.Context) error {
+	page, size, err := parsePagination(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	rejects, total, err := h.service.GetSyncRejects(page, size)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error consultando elementos rechazados",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		response.NewPaginated(rejects, total, page, size),
+		"Consulta de rechazos exitosa",
+	))
+}
@@ -0,0 +1,84 @@
+package stocks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetSyncRejects_Success verifica que se devuelvan los rechazos paginados
+func TestGetSyncRejects_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/stocks/sync/rejects?page=2&size=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Crear el servicio mock
+	mockService := new(mockStockService)
+	mockService.On("GetSyncRejects", 2, 5).Return([]domain.SyncReject{
+		{ID: 7, Iteration: 3, Error: "error convirtiendo target_to", RawItem: map[string]interface{}{"ticker": "BAD"}},
+	}, int64(6), nil)
+
+	// Ejecutar el handler
+	h := &handler{service: mockService}
+	err := h.GetSyncRejects(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	data, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, float64(6), data["total"])
+
+	content := data["content"].([]interface{})
+	assert.Len(t, content, 1)
+	reject := content[0].(map[string]interface{})
+	assert.Equal(t, float64(3), reject["iteration"])
+	assert.Equal(t, "BAD", reject["raw_item"].(map[string]interface{})["ticker"])
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetSyncRejects_InvalidSize verifica que un tamaño de página inválido devuelva un error 400
+func TestGetSyncRejects_InvalidSize(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/stocks/sync/rejects?size=500", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	h := &handler{service: mockService}
+	err := h.GetSyncRejects(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "GetSyncRejects")
+}
+
+// TestGetSyncRejects_ServiceError verifica que un error del servicio devuelva un error 500
+func TestGetSyncRejects_ServiceError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/stocks/sync/rejects", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	mockService.On("GetSyncRejects", 1, 10).Return([]domain.SyncReject(nil), int64(0), errors.New("error de base de datos"))
+	h := &handler{service: mockService}
+	err := h.GetSyncRejects(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,93 @@
+package stocks
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// GetSyncRuns
+// @Summary Consultar el historial de ejecuciones de la sincronización
+// @Description Devuelve, de la más reciente a la más antigua, cada ejecución de la sincronización: inicio y fin, límite solicitado y efectivo, motivo por el que terminó la paginación (empty_next_page, cycle o limit), totales y error si falló
+// @Tags stocks
+// @Produce json
+// @Param page query int false "Número de página (por defecto: 1)" default(1)
+// @Param size query int false "Registros por página (por defecto: 10)" default(10)
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData{content=[]domain.SyncRun}} "Consulta de ejecuciones exitosa"
+// @Failure 400 {object} response.APIResponse "Parámetros inválidos"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /stocks/sync/runs [get]
+func (h *handler) GetSyncRuns(c echo.Context) error {
+	page, size, err := parsePagination(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	runs, total, err := h.service.GetSyncRuns(page, size)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error obteniendo ejecuciones de sincronización",
+			err.Error(),
+		))
+	}
+
+	paginated := response.NewPaginated(runs, total, page, size)
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		paginated,
+		"Consulta de ejecuciones exitosa",
+	))
+}
+
+// GetSyncRunDiff
+// @Summary Comparar una ejecución de la sincronización con la anterior
+// @Description Devuelve los tickers agregados y eliminados respecto de la última ejecución anterior con historial, y los brokerages cuya calificación o precio objetivo cambió
+// @Tags stocks
+// @Produce json
+// @Param id path int true "ID de la ejecución"
+// @Success 200 {object} response.APIResponse{data=domain.SyncRunDiff} "Diferencias de la ejecución"
+// @Failure 400 {object} response.APIResponse "ID inválido"
+// @Failure 404 {object} response.APIResponse "Ejecución no encontrada"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /stocks/sync/runs/{id}/diff [get]
+func (h *handler) GetSyncRunDiff(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"El ID de la ejecución debe ser un entero positivo",
+			"",
+		))
+	}
+
+	diff, err := h.service.GetSyncRunDiff(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, response.NewError(
+			http.StatusNotFound,
+			"Ejecución de sincronización no encontrada",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error comparando la ejecución de sincronización",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		diff,
+		"Comparación de la ejecución exitosa",
+	))
+}
@@ -0,0 +1,72 @@
+package stocks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestGetSyncRuns_Success verifica que se devuelva el historial de ejecuciones paginado
+func TestGetSyncRuns_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/stocks/sync/runs?page=1&size=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	mockService.On("GetSyncRuns", 1, 2).Return([]domain.SyncRun{
+		{ID: 3, RequestedLimit: 50, EffectiveLimit: 10, StopReason: domain.SyncStopLimit},
+	}, int64(3), nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetSyncRuns(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	content := res.Data.(map[string]interface{})["content"].([]interface{})
+	assert.Equal(t, "limit", content[0].(map[string]interface{})["stop_reason"])
+	mockService.AssertExpectations(t)
+}
+
+// TestGetSyncRunDiff verifica los códigos de respuesta de la comparación de ejecuciones
+func TestGetSyncRunDiff(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		diff         domain.SyncRunDiff
+		err          error
+		expectedCode int
+	}{
+		{name: "ID inválido", id: "abc", expectedCode: http.StatusBadRequest},
+		{name: "ejecución inexistente", id: "9", err: domain.ErrNotFound, expectedCode: http.StatusNotFound},
+		{name: "comparación exitosa", id: "2", diff: domain.SyncRunDiff{RunID: 2, Added: []string{"NVDA"}}, expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/stocks/sync/runs/"+tt.id+"/diff", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			mockService := new(mockStockService)
+			mockService.On("GetSyncRunDiff", mock.Anything).Return(tt.diff, tt.err)
+
+			h := &handler{service: mockService}
+
+			assert.NoError(t, h.GetSyncRunDiff(c))
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -21,17 +22,99 @@ type mockStockService struct {
 	mock.Mock
 }
 
-func (m *mockStockService) GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error) {
-	args := m.Called(query, page, size, recommends, minTargetTo, maxTargetTo, currency)
+func (m *mockStockService) GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]domain.Stock), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *mockStockService) SyncStocks(ctx context.Context, limit int) error {
-	args := m.Called(ctx, limit)
-	return args.Error(0)
+func (m *mockStockService) GetStocksByCursor(filter domain.StockFilter) (domain.StockPage, error) {
+	args := m.Called(filter)
+	return args.Get(0).(domain.StockPage), args.Error(1)
 }
 
-// TestSyncStocks_Success verifica que la sincronización exitosa devuelva un código 200
+// ExportStocks entrega a fn los stocks configurados en el primer valor de retorno del mock
+func (m *mockStockService) ExportStocks(ctx context.Context, filter domain.StockFilter, fn func(domain.Stock) error) error {
+	args := m.Called(filter)
+	for _, stock := range args.Get(0).([]domain.Stock) {
+		if err := fn(stock); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *mockStockService) GetTickers(filter domain.StockFilter) ([]domain.TickerSummary, int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.TickerSummary), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockStockService) GetTicker(ticker string, filter domain.StockFilter) (domain.TickerDetail, error) {
+	args := m.Called(ticker, filter)
+	return args.Get(0).(domain.TickerDetail), args.Error(1)
+}
+
+func (m *mockStockService) GetSyncRuns(page, size int) ([]domain.SyncRun, int64, error) {
+	args := m.Called(page, size)
+	return args.Get(0).([]domain.SyncRun), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockStockService) GetSyncRunDiff(id int64) (domain.SyncRunDiff, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.SyncRunDiff), args.Error(1)
+}
+
+func (m *mockStockService) GetTickerHistory(ticker, brokerage string, page, size int) ([]domain.StockRatingHistory, int64, error) {
+	args := m.Called(ticker, brokerage, page, size)
+	return args.Get(0).([]domain.StockRatingHistory), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockStockService) GetBrokerages(filter domain.StockFilter) ([]domain.BrokerageSummary, int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.BrokerageSummary), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockStockService) GetBrokerageStocks(name string, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+	args := m.Called(name, filter)
+	return args.Get(0).([]domain.Stock), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockStockService) EnqueueRescore() (domain.SyncJob, error) {
+	args := m.Called()
+	return args.Get(0).(domain.SyncJob), args.Error(1)
+}
+
+func (m *mockStockService) ExplainScore(id int64) (domain.ScoreBreakdown, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.ScoreBreakdown), args.Error(1)
+}
+
+func (m *mockStockService) SyncStocks(ctx context.Context, opts domain.SyncOptions) (domain.SyncResult, error) {
+	args := m.Called(ctx, opts)
+	return args.Get(0).(domain.SyncResult), args.Error(1)
+}
+
+func (m *mockStockService) ImportStocks(ctx context.Context, r io.Reader, opts domain.ImportOptions) (domain.ImportResult, error) {
+	data, _ := io.ReadAll(r)
+	args := m.Called(string(data), opts)
+	return args.Get(0).(domain.ImportResult), args.Error(1)
+}
+
+func (m *mockStockService) EnqueueSync(opts domain.SyncOptions) (domain.SyncJob, error) {
+	args := m.Called(opts)
+	return args.Get(0).(domain.SyncJob), args.Error(1)
+}
+
+func (m *mockStockService) GetSyncJob(id int64) (domain.SyncJob, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.SyncJob), args.Error(1)
+}
+
+func (m *mockStockService) GetSyncRejects(page, size int) ([]domain.SyncReject, int64, error) {
+	args := m.Called(page, size)
+	return args.Get(0).([]domain.SyncReject), args.Get(1).(int64), args.Error(2)
+}
+
+// TestSyncStocks_Success verifica que la sincronización se encole y devuelva un código 202
 func TestSyncStocks_Success(t *testing.T) {
 	// Configurar el contexto Echo y la solicitud
 	e := echo.New()
@@ -44,7 +127,7 @@ func TestSyncStocks_Success(t *testing.T) {
 
 	// Crear el servicio mock y configurar comportamiento esperado
 	mockService := new(mockStockService)
-	mockService.On("SyncStocks", mock.Anything, 5).Return(nil)
+	mockService.On("EnqueueSync", domain.SyncOptions{Limit: 5}).Return(domain.SyncJob{ID: 42, State: domain.SyncJobQueued, Limit: 5}, nil)
 
 	// Crear el handler con el servicio mock
 	h := &handler{service: mockService}
@@ -54,21 +137,98 @@ func TestSyncStocks_Success(t *testing.T) {
 
 	// Verificar resultados
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
 
 	// Verificar respuesta JSON
 	var response response.APIResponse
 	json.Unmarshal(rec.Body.Bytes(), &response)
 
-	assert.Equal(t, http.StatusOK, response.Code)
-	assert.Equal(t, "Sincronización completada exitosamente", response.Message)
-	assert.Nil(t, response.Data)
+	assert.Equal(t, http.StatusAccepted, response.Code)
+	assert.Equal(t, "Sincronización encolada exitosamente", response.Message)
 	assert.Empty(t, response.Error)
 
+	// Verificar el job devuelto
+	data, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, float64(42), data["id"])
+	assert.Equal(t, domain.SyncJobQueued, data["state"])
+	assert.Equal(t, float64(5), data["limit"])
+
 	// Verificar que se llamó al método del servicio con los parámetros correctos
 	mockService.AssertExpectations(t)
 }
 
+// TestSyncStocks_Resume verifica que el flag resume se envíe al servicio
+func TestSyncStocks_Resume(t *testing.T) {
+	// Configurar el contexto Echo y la solicitud
+	e := echo.New()
+	jsonBody := []byte(`{"limit": 5, "resume": true}`)
+	req := httptest.NewRequest(http.MethodPost, "/stocks/sync", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Crear el servicio mock y configurar comportamiento esperado
+	mockService := new(mockStockService)
+	mockService.On("EnqueueSync", domain.SyncOptions{Limit: 5, Resume: true}).
+		Return(domain.SyncJob{ID: 43, State: domain.SyncJobQueued, Limit: 5, Resume: true}, nil)
+
+	// Ejecutar el handler
+	h := &handler{service: mockService}
+	err := h.SyncStocks(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var response response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	data, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, true, data["resume"])
+
+	mockService.AssertExpectations(t)
+}
+
+// TestSyncStocks_DryRun verifica que dryRun encole un job de dry run en lugar de ejecutarlo en la petición
+func TestSyncStocks_DryRun(t *testing.T) {
+	// Configurar el contexto Echo y la solicitud
+	e := echo.New()
+	jsonBody := []byte(`{"limit": 3, "dryRun": true}`)
+	req := httptest.NewRequest(http.MethodPost, "/stocks/sync", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Crear el servicio mock y configurar comportamiento esperado
+	mockService := new(mockStockService)
+	mockService.On("EnqueueSync", domain.SyncOptions{Limit: 3, DryRun: true}).Return(domain.SyncJob{
+		ID:     9,
+		State:  domain.SyncJobQueued,
+		Limit:  3,
+		DryRun: true,
+	}, nil)
+
+	// Ejecutar el handler
+	h := &handler{service: mockService}
+	err := h.SyncStocks(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var response response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	data, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok, "Data debería ser un mapa")
+	assert.Equal(t, float64(9), data["id"])
+	assert.Equal(t, true, data["dry_run"])
+
+	mockService.AssertExpectations(t)
+}
+
 // TestSyncStocks_InvalidLimit verifica que un límite inválido devuelva un error 400
 func TestSyncStocks_InvalidLimit(t *testing.T) {
 	// Configurar el contexto Echo y la solicitud con límite inválido
@@ -102,7 +262,7 @@ func TestSyncStocks_InvalidLimit(t *testing.T) {
 	assert.Nil(t, response.Data)
 
 	// Verificar que NO se llamó al método del servicio
-	mockService.AssertNotCalled(t, "SyncStocks")
+	mockService.AssertNotCalled(t, "EnqueueSync")
 }
 
 // TestSyncStocks_InvalidBody verifica que un cuerpo de solicitud inválido devuelva un error 400
@@ -137,7 +297,7 @@ func TestSyncStocks_InvalidBody(t *testing.T) {
 	assert.Nil(t, response.Data)
 
 	// Verificar que NO se llamó al método del servicio
-	mockService.AssertNotCalled(t, "SyncStocks")
+	mockService.AssertNotCalled(t, "EnqueueSync")
 }
 
 // TestSyncStocks_ServiceError verifica que un error del servicio devuelva un error 500
@@ -154,7 +314,7 @@ func TestSyncStocks_ServiceError(t *testing.T) {
 	// Crear el servicio mock y configurar un error
 	mockService := new(mockStockService)
 	expectedError := errors.New("error de sincronización")
-	mockService.On("SyncStocks", mock.Anything, 5).Return(expectedError)
+	mockService.On("EnqueueSync", domain.SyncOptions{Limit: 5}).Return(domain.SyncJob{}, expectedError)
 
 	// Crear el handler con el servicio mock
 	h := &handler{service: mockService}
@@ -202,5 +362,61 @@ func TestSyncStocks_EmptyBody(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 
 	// Verificar que NO se llamó al método del servicio
-	mockService.AssertNotCalled(t, "SyncStocks")
+	mockService.AssertNotCalled(t, "EnqueueSync")
+}
+
+// TestSyncStocks_QueueFull verifica que una cola llena devuelva un error 503
+func TestSyncStocks_QueueFull(t *testing.T) {
+	// Configurar el contexto Echo y la solicitud
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/stocks/sync", bytes.NewReader([]byte(`{"limit": 5}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Crear el servicio mock sin capacidad en la cola
+	mockService := new(mockStockService)
+	mockService.On("EnqueueSync", domain.SyncOptions{Limit: 5}).Return(domain.SyncJob{}, domain.ErrSyncQueueFull)
+
+	// Crear el handler con el servicio mock
+	h := &handler{service: mockService}
+
+	// Ejecutar el handler
+	err := h.SyncStocks(c)
+
+	// Verificar resultados
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestRegisterRoutes_RequiresAdminToken verifica que las rutas que modifican los stocks exijan ADMIN_TOKEN
+// y que las consultas sigan abiertas
+func TestRegisterRoutes_RequiresAdminToken(t *testing.T) {
+	mockService := new(mockStockService)
+	mockService.On("EnqueueRescore").Return(domain.SyncJob{ID: 1, Kind: domain.SyncJobKindRescore}, nil)
+	mockService.On("GetSyncJob", int64(1)).Return(domain.SyncJob{ID: 1}, nil)
+
+	e := echo.New()
+	h := &handler{service: mockService, adminToken: "secret"}
+	h.RegisterRoutes(e)
+
+	for _, path := range []string{"/stocks/rescore", "/stocks/import?mode=replace", "/stocks/sync"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, path)
+	}
+	mockService.AssertNotCalled(t, "EnqueueSync", mock.Anything)
+	mockService.AssertNotCalled(t, "ImportStocks", mock.Anything, mock.Anything)
+
+	req := httptest.NewRequest(http.MethodPost, "/stocks/rescore", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stocks/sync/1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
 }
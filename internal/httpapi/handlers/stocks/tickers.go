@@ -0,0 +1,154 @@
+package stocks
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// GetTickers
+// @Summary Obtener el consenso por ticker
+// @Description Agrupa las acciones de los brokerages por ticker: cobertura, precio objetivo promedio/mediano/mínimo/máximo, distribución de calificaciones, upgrades netos y puntaje promedio. Acepta los mismos filtros que GET /stocks
+// @Tags tickers
+// @Produce json
+// @Param query query string false "Texto de búsqueda general (ticker, company, brokerage, etc.)"
+// @Param page query int false "Número de página (por defecto: 1)" default(1)
+// @Param size query int false "Registros por página (por defecto: 10)" default(10)
+// @Param recommends query bool false "Ordenar por puntaje de recomendación promedio"
+// @Param minTargetTo query number false "Valor mínimo del precio objetivo"
+// @Param maxTargetTo query number false "Valor máximo del precio objetivo"
+// @Param currency query string false "Moneda de los precios (por defecto: USD)" default(USD)
+// @Param from query string false "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)"
+// @Param to query string false "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)"
+// @Param recent query bool false "Ordenar por fecha de calificación más reciente"
+// @Param ticker query []string false "Tickers exactos, separados por coma" collectionFormat(csv)
+// @Param brokerage query string false "Brokerage exacto"
+// @Param action query []string false "Tipos de acción, separados por coma" collectionFormat(csv) Enums(upgraded, downgraded, target_raised, target_lowered, target_set, initiated, reiterated)
+// @Param ratingTo query []string false "Categorías de la calificación nueva, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param ratingFrom query []string false "Categorías de la calificación anterior, separadas por coma" collectionFormat(csv) Enums(buy, hold, sell, other)
+// @Param minScore query number false "Puntaje de recomendación mínimo"
+// @Param maxScore query number false "Puntaje de recomendación máximo"
+// @Param minTargetFrom query number false "Valor mínimo del precio objetivo anterior"
+// @Param maxTargetFrom query number false "Valor máximo del precio objetivo anterior"
+// @Param minTargetChangePct query number false "Variación porcentual mínima del precio objetivo"
+// @Param maxTargetChangePct query number false "Variación porcentual máxima del precio objetivo"
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData{content=[]domain.TickerSummary}} "Consulta de tickers exitosa"
+// @Failure 400 {object} response.APIResponse "Parámetros inválidos"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /tickers [get]
+func (h *handler) GetTickers(c echo.Context) error {
+	params, err := parseStockParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	tickers, total, err := h.service.GetTickers(params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error obteniendo tickers",
+			err.Error(),
+		))
+	}
+
+	paginated := response.NewPaginated(tickers, total, params.Page, params.Size)
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		paginated,
+		"Consulta de tickers exitosa",
+	))
+}
+
+// GetTicker
+// @Summary Obtener el consenso de un ticker
+// @Description Devuelve el resumen de consenso de un ticker junto con las acciones de los brokerages que lo cubren, de la más reciente a la más antigua
+// @Tags tickers
+// @Produce json
+// @Param ticker path string true "Ticker"
+// @Param currency query string false "Moneda de los precios (por defecto: USD)" default(USD)
+// @Param from query string false "Fecha mínima de calificación (YYYY-MM-DD o RFC3339)"
+// @Param to query string false "Fecha máxima de calificación, inclusiva si es solo fecha (YYYY-MM-DD o RFC3339)"
+// @Success 200 {object} response.APIResponse{data=domain.TickerDetail} "Consenso del ticker"
+// @Failure 400 {object} response.APIResponse "Parámetros inválidos"
+// @Failure 404 {object} response.APIResponse "Ticker no encontrado"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /tickers/{ticker} [get]
+func (h *handler) GetTicker(c echo.Context) error {
+	params, err := parseStockParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	detail, err := h.service.GetTicker(c.Param("ticker"), params)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, response.NewError(
+			http.StatusNotFound,
+			"Ticker no encontrado",
+			err.Error(),
+		))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error obteniendo el ticker",
+			err.Error(),
+		))
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		detail,
+		"Consulta del ticker exitosa",
+	))
+}
+
+// GetTickerHistory
+// @Summary Obtener el historial de calificaciones de un ticker
+// @Description Devuelve las calificaciones y precios objetivo de un ticker observados en cada sincronización, de la más antigua a la más reciente. Permite ver cómo evolucionó la opinión de cada brokerage aunque la tabla de stocks se actualice
+// @Tags tickers
+// @Produce json
+// @Param ticker path string true "Ticker"
+// @Param brokerage query string false "Limitar el historial a un brokerage"
+// @Param page query int false "Número de página (por defecto: 1)" default(1)
+// @Param size query int false "Registros por página (por defecto: 10)" default(10)
+// @Success 200 {object} response.APIResponse{data=response.PaginatedData{content=[]domain.StockRatingHistory}} "Historial del ticker"
+// @Failure 400 {object} response.APIResponse "Parámetros inválidos"
+// @Failure 500 {object} response.APIResponse "Error interno del servidor"
+// @Router /tickers/{ticker}/history [get]
+func (h *handler) GetTickerHistory(c echo.Context) error {
+	page, size, err := parsePagination(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.NewError(
+			http.StatusBadRequest,
+			"Parámetros inválidos",
+			err.Error(),
+		))
+	}
+
+	history, total, err := h.service.GetTickerHistory(c.Param("ticker"), c.QueryParam("brokerage"), page, size)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.NewError(
+			http.StatusInternalServerError,
+			"Error obteniendo el historial del ticker",
+			err.Error(),
+		))
+	}
+
+	paginated := response.NewPaginated(history, total, page, size)
+	return c.JSON(http.StatusOK, response.NewSuccess(
+		http.StatusOK,
+		paginated,
+		"Consulta del historial del ticker exitosa",
+	))
+}
@@ -0,0 +1,101 @@
+package stocks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetTickers_Success verifica que se devuelva el consenso por ticker paginado
+func TestGetTickers_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/tickers?recommends=true&page=2&size=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	mockService.On("GetTickers", domain.StockFilter{Page: 2, Size: 5, Currency: "USD", Recommends: true}).
+		Return([]domain.TickerSummary{{Ticker: "AAPL", Brokerages: 3, NetUpgrades: 1}}, int64(6), nil)
+
+	h := &handler{service: mockService}
+	err := h.GetTickers(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	data := res.Data.(map[string]interface{})
+	assert.Equal(t, float64(6), data["total"])
+	content := data["content"].([]interface{})
+	assert.Len(t, content, 1)
+	assert.Equal(t, float64(3), content[0].(map[string]interface{})["brokerages"])
+	mockService.AssertExpectations(t)
+}
+
+// TestGetTickers_InvalidParams verifica que los parámetros inválidos devuelvan 400
+func TestGetTickers_InvalidParams(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/tickers?size=0", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := new(mockStockService)
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetTickers(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "GetTickers")
+}
+
+// TestGetTicker_NotFound verifica que un ticker sin acciones devuelva 404
+func TestGetTicker_NotFound(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/tickers/ZZZZ", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("ticker")
+	c.SetParamValues("ZZZZ")
+
+	mockService := new(mockStockService)
+	mockService.On("GetTicker", "ZZZZ", domain.StockFilter{Page: 1, Size: 10, Currency: "USD"}).
+		Return(domain.TickerDetail{}, domain.ErrNotFound)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetTicker(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetTickerHistory_Success verifica que se devuelva el historial paginado filtrado por brokerage
+func TestGetTickerHistory_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/tickers/AAPL/history?brokerage=Goldman%20Sachs&size=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("ticker")
+	c.SetParamValues("AAPL")
+
+	mockService := new(mockStockService)
+	mockService.On("GetTickerHistory", "AAPL", "Goldman Sachs", 1, 5).
+		Return([]domain.StockRatingHistory{{ID: 1, SyncRunID: 3, Ticker: "AAPL", RatingTo: "Buy"}}, int64(1), nil)
+
+	h := &handler{service: mockService}
+
+	assert.NoError(t, h.GetTickerHistory(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res response.APIResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	content := res.Data.(map[string]interface{})["content"].([]interface{})
+	assert.Len(t, content, 1)
+	assert.Equal(t, float64(3), content[0].(map[string]interface{})["sync_run_id"])
+	mockService.AssertExpectations(t)
+}
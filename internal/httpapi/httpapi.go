@@ -1,6 +1,7 @@
 package httpapi
 
 import (
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/factors"
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/health"
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/stocks"
 	"go.uber.org/fx"
@@ -10,4 +11,5 @@ import (
 var Module = fx.Module("httpapi", fx.Provide(
 	stocks.New,
 	health.New,
+	factors.New,
 ))
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers/response"
+	"github.com/labstack/echo/v4"
+)
+
+// RequireAdminToken protege las rutas de administración y las que modifican los stocks con el token de ADMIN_TOKEN,
+// que debe enviarse en el encabezado Authorization como "Bearer <token>". Sin un token configurado las rutas quedan deshabilitadas.
+func RequireAdminToken(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if token == "" {
+				return c.JSON(http.StatusForbidden, response.NewError(
+					http.StatusForbidden,
+					"Administración deshabilitada",
+					"configure ADMIN_TOKEN para habilitar esta ruta",
+				))
+			}
+
+			provided, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), []byte(token)) != 1 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return c.JSON(http.StatusUnauthorized, response.NewError(
+					http.StatusUnauthorized,
+					"No autorizado",
+					"se requiere el encabezado Authorization: Bearer <ADMIN_TOKEN>",
+				))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequireAdminToken verifica que solo se acepten las solicitudes con el token configurado
+func TestRequireAdminToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "token válido", token: "secret", authorization: "Bearer secret", expectedCode: http.StatusOK},
+		{name: "sin encabezado", token: "secret", expectedCode: http.StatusUnauthorized},
+		{name: "token incorrecto", token: "secret", authorization: "Bearer other", expectedCode: http.StatusUnauthorized},
+		{name: "esquema incorrecto", token: "secret", authorization: "Basic secret", expectedCode: http.StatusUnauthorized},
+		{name: "sin token configurado", token: "", authorization: "Bearer ", expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/factors/companies", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			handler := RequireAdminToken(tt.token)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			assert.NoError(t, handler(c))
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
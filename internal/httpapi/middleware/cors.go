@@ -15,6 +15,8 @@ func ApplyCORS(e *echo.Echo, cfg *config.Config) {
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: allowedOrigins,
 		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		// Content-Disposition permite al frontend leer el nombre de los archivos exportados
+		ExposeHeaders: []string{echo.HeaderContentDisposition},
 	}))
 }
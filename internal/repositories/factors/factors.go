@@ -0,0 +1,127 @@
+package factors
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// seedMarkerID es el ID de la única fila de recommendation_factor_seeds
+const seedMarkerID = 1
+
+// Repository define las operaciones disponibles para manejar los factores de recomendación.
+type Repository interface {
+	// GetFactors obtiene todos los factores del tipo indicado, ordenados por clave.
+	GetFactors(kind string) ([]domain.RecommendationFactor, error)
+
+	// GetFactor obtiene un factor por su tipo y clave.
+	GetFactor(kind, key string) (domain.RecommendationFactor, error)
+
+	// SaveFactor crea o actualiza un factor.
+	SaveFactor(factor *domain.RecommendationFactor) error
+
+	// DeleteFactor elimina un factor.
+	DeleteFactor(kind, key string) error
+
+	// SeedFactors inserta los factores indicados solo la primera vez que se inicializa la tabla.
+	// Devuelve true si se insertaron.
+	SeedFactors(factors []domain.RecommendationFactor) (bool, error)
+}
+
+// repository implementa la interfaz Repository.
+type repository struct {
+	db *gorm.DB
+}
+
+// New crea una nueva instancia del repositorio de factores.
+func New(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetFactors obtiene todos los factores del tipo indicado, ordenados por clave.
+func (r *repository) GetFactors(kind string) ([]domain.RecommendationFactor, error) {
+	var factors []domain.RecommendationFactor
+	if err := r.db.Where("kind = ?", kind).Order("key ASC").Find(&factors).Error; err != nil {
+		log.Printf("Error obteniendo factores de tipo %s: %v", kind, err)
+		return nil, err
+	}
+	return factors, nil
+}
+
+// GetFactor obtiene un factor por su tipo y clave.
+func (r *repository) GetFactor(kind, key string) (domain.RecommendationFactor, error) {
+	var factor domain.RecommendationFactor
+	if err := r.db.Where("kind = ? AND key = ?", kind, key).First(&factor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.RecommendationFactor{}, domain.ErrNotFound
+		}
+		log.Printf("Error obteniendo factor %s/%s: %v", kind, key, err)
+		return domain.RecommendationFactor{}, err
+	}
+	return factor, nil
+}
+
+// SaveFactor crea o actualiza un factor según su tipo y clave.
+func (r *repository) SaveFactor(factor *domain.RecommendationFactor) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"factor", "updated_at"}),
+	}).Create(factor).Error
+	if err != nil {
+		log.Printf("Error guardando factor %s/%s: %v", factor.Kind, factor.Key, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteFactor elimina un factor. Devuelve domain.ErrNotFound si no existe.
+func (r *repository) DeleteFactor(kind, key string) error {
+	result := r.db.Where("kind = ? AND key = ?", kind, key).Delete(&domain.RecommendationFactor{})
+	if result.Error != nil {
+		log.Printf("Error eliminando factor %s/%s: %v", kind, key, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SeedFactors inserta los factores indicados solo la primera vez que se inicializa la tabla, en una única transacción.
+// La inicialización se registra en recommendation_factor_seeds aunque no haya factores que insertar; si la tabla
+// ya tenía factores de una versión anterior, solo se registra la inicialización.
+func (r *repository) SeedFactors(factors []domain.RecommendationFactor) (bool, error) {
+	seeded := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		marker := domain.RecommendationFactorSeed{ID: seedMarkerID, SeededAt: time.Now()}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&marker)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		var count int64
+		if err := tx.Model(&domain.RecommendationFactor{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 || len(factors) == 0 {
+			return nil
+		}
+		if err := tx.Create(&factors).Error; err != nil {
+			return err
+		}
+		seeded = true
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error inicializando factores de recomendación: %v", err)
+		return false, err
+	}
+	return seeded, nil
+}
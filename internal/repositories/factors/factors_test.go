@@ -0,0 +1,55 @@
+package factors
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// newDryRunDB crea una conexión que genera SQL sin ejecutarlo
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	assert.NoError(t, err)
+	return db
+}
+
+// TestFactorQueries verifica el SQL generado para consultar, guardar y eliminar factores
+func TestFactorQueries(t *testing.T) {
+	db := newDryRunDB(t)
+
+	selectSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var factors []domain.RecommendationFactor
+		return tx.Where("kind = ?", domain.FactorKindCompany).Order("key ASC").Find(&factors)
+	})
+	assert.Contains(t, selectSQL, `FROM "recommendation_factors" WHERE kind = 'company' ORDER BY key ASC`)
+
+	upsertSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		factor := domain.RecommendationFactor{Kind: domain.FactorKindBrokerage, Key: "Citigroup", Factor: 5}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "kind"}, {Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"factor", "updated_at"}),
+		}).Create(&factor)
+	})
+	assert.Contains(t, upsertSQL, `INSERT INTO "recommendation_factors"`)
+	assert.Contains(t, upsertSQL, `ON CONFLICT ("kind","key") DO UPDATE SET "factor"="excluded"."factor","updated_at"="excluded"."updated_at"`)
+
+	deleteSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("kind = ? AND key = ?", domain.FactorKindCompany, "AAPL").Delete(&domain.RecommendationFactor{})
+	})
+	assert.Contains(t, deleteSQL, `DELETE FROM "recommendation_factors" WHERE kind = 'company' AND key = 'AAPL'`)
+
+	// La inicialización se registra una sola vez, aunque se eliminen todos los factores
+	seedSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		marker := domain.RecommendationFactorSeed{ID: seedMarkerID}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&marker)
+	})
+	assert.Contains(t, seedSQL, `INSERT INTO "recommendation_factor_seeds"`)
+	assert.Contains(t, seedSQL, `ON CONFLICT DO NOTHING`)
+}
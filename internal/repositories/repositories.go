@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"github.com/julianloaiza/stock-advisor/internal/repositories/factors"
 	"github.com/julianloaiza/stock-advisor/internal/repositories/stocks"
 	"go.uber.org/fx"
 )
@@ -8,4 +9,5 @@ import (
 // Module registra los repositorios.
 var Module = fx.Module("repositories", fx.Provide(
 	stocks.New,
+	factors.New,
 ))
@@ -0,0 +1,71 @@
+package stocks
+
+import (
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+)
+
+// GetBrokerageSummaries obtiene el resumen de comportamiento por brokerage de los stocks filtrados,
+// agregando en la base de datos y aplicando paginación sobre los brokerages.
+func (r *repository) GetBrokerageSummaries(filter domain.StockFilter) ([]domain.BrokerageSummary, int64, error) {
+	var summaries []domain.BrokerageSummary
+	var total int64
+
+	// Contamos los brokerages distintos sin paginar
+	if err := r.buildBaseQuery(filter).Distinct("brokerage").Count(&total).Error; err != nil {
+		log.Printf("Error contando brokerages: %v", err)
+		return nil, 0, err
+	}
+
+	dbQuery := r.buildBrokerageQuery(filter)
+
+	// Ordenamos por puntaje promedio o por la calificación más reciente; el nombre desempata
+	if filter.Recommends {
+		dbQuery = dbQuery.Order("avg_score DESC")
+	}
+	if filter.Recent {
+		dbQuery = dbQuery.Order("last_rated_at DESC")
+	}
+
+	offset := (filter.Page - 1) * filter.Size
+	if err := dbQuery.
+		Order("brokerage ASC").
+		Offset(offset).
+		Limit(filter.Size).
+		Scan(&summaries).Error; err != nil {
+		log.Printf("Error obteniendo resumen de brokerages: %v", err)
+		return nil, 0, err
+	}
+
+	for i := range summaries {
+		summaries[i].UpgradeRatio = upgradeRatio(summaries[i].Upgrades, summaries[i].Downgrades)
+	}
+
+	return summaries, total, nil
+}
+
+// upgradeRatio calcula la proporción de upgrades sobre el total de upgrades y downgrades (0 a 1).
+// Devuelve nil si el brokerage no registra ninguno de los dos.
+func upgradeRatio(upgrades, downgrades int) *float64 {
+	if upgrades+downgrades == 0 {
+		return nil
+	}
+	ratio := float64(upgrades) / float64(upgrades+downgrades)
+	return &ratio
+}
+
+// buildBrokerageQuery construye la consulta de agregación por brokerage sobre la consulta base filtrada
+func (r *repository) buildBrokerageQuery(filter domain.StockFilter) *gorm.DB {
+	return r.buildBaseQuery(filter).
+		Select(`brokerage,
+			COUNT(DISTINCT ticker) AS tickers,
+			COUNT(*) AS actions,
+			COUNT(*) FILTER (WHERE action ILIKE 'upgraded%') AS upgrades,
+			COUNT(*) FILTER (WHERE action ILIKE 'downgraded%') AS downgrades,
+			COALESCE(AVG((target_to - target_from) / target_from * 100) FILTER (WHERE target_from > 0), 0) AS avg_target_change_pct,
+			AVG(recommend_score) AS avg_score,
+			MAX(rated_at) AS last_rated_at`).
+		Group("brokerage")
+}
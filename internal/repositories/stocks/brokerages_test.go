@@ -0,0 +1,39 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestBrokerageFilter verifica que el filtro de brokerage sea una coincidencia exacta
+func TestBrokerageFilter(t *testing.T) {
+	sql := querySQL(t, domain.StockFilter{Brokerage: "Morgan Stanley"})
+
+	assert.Contains(t, sql, "AND brokerage = 'Morgan Stanley'")
+}
+
+// TestBrokerageQuery verifica el SQL de agregación por brokerage
+func TestBrokerageQuery(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var summaries []domain.BrokerageSummary
+		return (&repository{db: tx}).buildBrokerageQuery(domain.StockFilter{Currency: "USD"}).Scan(&summaries)
+	})
+
+	assert.Contains(t, sql, "COUNT(DISTINCT ticker) AS tickers")
+	assert.Contains(t, sql, "COUNT(*) FILTER (WHERE action ILIKE 'downgraded%') AS downgrades")
+	assert.Contains(t, sql, "AVG((target_to - target_from) / target_from * 100) FILTER (WHERE target_from > 0)")
+	assert.Contains(t, sql, `GROUP BY "brokerage"`)
+}
+
+// TestUpgradeRatio verifica la proporción de upgrades, también sin downgrades
+func TestUpgradeRatio(t *testing.T) {
+	assert.Equal(t, 0.75, *upgradeRatio(3, 1))
+	assert.Equal(t, 1.0, *upgradeRatio(4, 0))
+	assert.Equal(t, 0.0, *upgradeRatio(0, 2))
+	assert.Nil(t, upgradeRatio(0, 0))
+}
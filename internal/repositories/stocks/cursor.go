@@ -0,0 +1,228 @@
+package stocks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+)
+
+// Tipos de valor de las claves de orden, necesarios para decodificar el cursor
+const (
+	sortValueFloat = iota
+	sortValueTime
+	sortValueString
+	sortValueInt
+)
+
+// stockSortKey describe una clave de orden de los stocks: la expresión SQL, su dirección
+// y cómo obtener de un stock el valor que se guarda en el cursor.
+type stockSortKey struct {
+	name  string
+	expr  string
+	desc  bool
+	kind  int
+	value func(domain.Stock) interface{}
+}
+
+// stockSortColumns relaciona los campos de orden de domain.StockSort con su expresión SQL.
+// Solo estas expresiones llegan a la consulta; el nombre recibido nunca se interpola.
+// rated_at puede ser NULL en stocks anteriores a la columna, por lo que se ordena por la fecha cero,
+// el mismo valor con el que se lee en Go. La variación del precio objetivo se calcula en double precision
+// para que coincida exactamente con domain.Stock.TargetChangePct al comparar con el cursor.
+var stockSortColumns = map[string]stockSortKey{
+	domain.StockSortID: {
+		expr: "id", kind: sortValueInt,
+		value: func(s domain.Stock) interface{} { return s.ID },
+	},
+	domain.StockSortTicker: {
+		expr: "ticker", kind: sortValueString,
+		value: func(s domain.Stock) interface{} { return s.Ticker },
+	},
+	domain.StockSortCompany: {
+		expr: "company", kind: sortValueString,
+		value: func(s domain.Stock) interface{} { return s.Company },
+	},
+	domain.StockSortTargetTo: {
+		expr: "target_to", kind: sortValueFloat,
+		value: func(s domain.Stock) interface{} { return s.TargetTo },
+	},
+	domain.StockSortCurrency: {
+		expr: "currency", kind: sortValueString,
+		value: func(s domain.Stock) interface{} { return s.Currency },
+	},
+	domain.StockSortRecommendScore: {
+		expr: "recommend_score", kind: sortValueFloat,
+		value: func(s domain.Stock) interface{} { return s.RecommendScore },
+	},
+	domain.StockSortRatedAt: {
+		expr: "COALESCE(rated_at, '0001-01-01 00:00:00+00')", kind: sortValueTime,
+		value: func(s domain.Stock) interface{} { return s.RatedAt.UTC() },
+	},
+	domain.StockSortTargetChangePct: {
+		expr:  targetChangePctExpr,
+		kind:  sortValueFloat,
+		value: func(s domain.Stock) interface{} { return s.TargetChangePct() },
+	},
+}
+
+// stockSortKeys devuelve las claves de orden del filtro: el orden explícito de Sort o, si no se indica,
+// el de Recommends y Recent. El ID se agrega siempre al final como desempate en applyStockOrder.
+func stockSortKeys(filter domain.StockFilter) []stockSortKey {
+	sorts := filter.Sort
+	if len(sorts) == 0 {
+		if filter.Recommends {
+			sorts = append(sorts, domain.StockSort{Field: domain.StockSortRecommendScore, Desc: true})
+		}
+		if filter.Recent {
+			sorts = append(sorts, domain.StockSort{Field: domain.StockSortRatedAt, Desc: true})
+		}
+	}
+
+	var keys []stockSortKey
+	for _, sort := range sorts {
+		// Los campos desconocidos se descartan; el handler ya los rechaza con su lista de campos permitidos
+		key, ok := stockSortColumns[sort.Field]
+		if !ok {
+			continue
+		}
+		key.name = sort.Field
+		key.desc = sort.Desc
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// applyStockOrder ordena la consulta por las claves de orden del filtro y por ID, para que el orden sea estable
+func applyStockOrder(dbQuery *gorm.DB, filter domain.StockFilter) *gorm.DB {
+	for _, key := range stockSortKeys(filter) {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+		dbQuery = dbQuery.Order(key.expr + " " + direction)
+	}
+	return dbQuery.Order("id ASC")
+}
+
+// stockCursor es el contenido del cursor opaco: las claves de orden con las que se generó,
+// sus valores en el último stock de la página y su ID.
+type stockCursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+	ID     int64             `json:"id"`
+}
+
+// sortSignature identifica el orden de las claves para rechazar cursores generados con otro orden
+func sortSignature(keys []stockSortKey) string {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.name
+		if key.desc {
+			names[i] = "-" + key.name
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// encodeStockCursor genera el cursor que continúa después del stock indicado
+func encodeStockCursor(keys []stockSortKey, last domain.Stock) (string, error) {
+	cursor := stockCursor{Sort: sortSignature(keys), Values: make([]json.RawMessage, len(keys)), ID: last.ID}
+	for i, key := range keys {
+		value, err := json.Marshal(key.value(last))
+		if err != nil {
+			return "", err
+		}
+		cursor.Values[i] = value
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeStockCursor interpreta un cursor y devuelve los valores de las claves de orden y el ID.
+// Devuelve domain.ErrInvalidCursor si el cursor está mal formado o se generó con otro orden.
+func decodeStockCursor(keys []stockSortKey, raw string) ([]interface{}, int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, 0, domain.ErrInvalidCursor
+	}
+
+	var cursor stockCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, 0, domain.ErrInvalidCursor
+	}
+	if cursor.Sort != sortSignature(keys) || len(cursor.Values) != len(keys) {
+		return nil, 0, fmt.Errorf("%w: no corresponde al orden solicitado", domain.ErrInvalidCursor)
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		value, err := decodeSortValue(key.kind, cursor.Values[i])
+		if err != nil {
+			return nil, 0, domain.ErrInvalidCursor
+		}
+		values[i] = value
+	}
+	return values, cursor.ID, nil
+}
+
+// decodeSortValue interpreta el valor de una clave de orden guardado en el cursor según su tipo
+func decodeSortValue(kind int, raw json.RawMessage) (interface{}, error) {
+	switch kind {
+	case sortValueTime:
+		var value time.Time
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case sortValueString:
+		var value string
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case sortValueInt:
+		var value int64
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	default:
+		var value float64
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	}
+}
+
+// keysetCondition construye la condición que selecciona los stocks posteriores al cursor en el orden de las claves:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... OR (k1 = v1 AND ... AND id > ID), con < en las claves descendentes
+func keysetCondition(keys []stockSortKey, values []interface{}, id int64) (string, []interface{}) {
+	var terms []string
+	var args []interface{}
+	for i := 0; i <= len(keys); i++ {
+		var parts []string
+		var termArgs []interface{}
+		for j := 0; j < i; j++ {
+			parts = append(parts, keys[j].expr+" = ?")
+			termArgs = append(termArgs, values[j])
+		}
+
+		if i < len(keys) {
+			operator := ">"
+			if keys[i].desc {
+				operator = "<"
+			}
+			parts = append(parts, keys[i].expr+" "+operator+" ?")
+			termArgs = append(termArgs, values[i])
+		} else {
+			parts = append(parts, "id > ?")
+			termArgs = append(termArgs, id)
+		}
+
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+		args = append(args, termArgs...)
+	}
+	return strings.Join(terms, " OR "), args
+}
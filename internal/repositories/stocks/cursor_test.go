@@ -0,0 +1,155 @@
+package stocks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestStockCursor_RoundTrip verifica que el cursor conserve los valores de las claves de orden y el ID
+func TestStockCursor_RoundTrip(t *testing.T) {
+	keys := stockSortKeys(domain.StockFilter{Recommends: true, Recent: true})
+	ratedAt := time.Date(2025, 1, 13, 0, 30, 5, 123456789, time.UTC)
+
+	cursor, err := encodeStockCursor(keys, domain.Stock{ID: 42, RecommendScore: 36.125, RatedAt: ratedAt})
+	assert.NoError(t, err)
+
+	values, id, err := decodeStockCursor(keys, cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	assert.Equal(t, []interface{}{36.125, ratedAt}, values)
+}
+
+// TestStockCursor_Invalid verifica que se rechacen los cursores mal formados o generados con otro orden
+func TestStockCursor_Invalid(t *testing.T) {
+	byScore := stockSortKeys(domain.StockFilter{Recommends: true})
+	cursor, err := encodeStockCursor(byScore, domain.Stock{ID: 1, RecommendScore: 10})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		keys   []stockSortKey
+		cursor string
+	}{
+		{name: "no es base64", keys: byScore, cursor: "%%%"},
+		{name: "no es JSON", keys: byScore, cursor: "bm90LWpzb24"},
+		{name: "otro orden", keys: stockSortKeys(domain.StockFilter{Recent: true}), cursor: cursor},
+		{name: "sin orden", keys: nil, cursor: cursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeStockCursor(tt.keys, tt.cursor)
+			assert.True(t, errors.Is(err, domain.ErrInvalidCursor), "error inesperado: %v", err)
+		})
+	}
+}
+
+// TestKeysetCondition verifica la condición para continuar después del cursor según la dirección de cada clave
+func TestKeysetCondition(t *testing.T) {
+	keys := stockSortKeys(domain.StockFilter{Recommends: true, Recent: true})
+	ratedAt := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	condition, args := keysetCondition(keys, []interface{}{36.125, ratedAt}, 7)
+
+	assert.Equal(t, "(recommend_score < ?) OR "+
+		"(recommend_score = ? AND COALESCE(rated_at, '0001-01-01 00:00:00+00') < ?) OR "+
+		"(recommend_score = ? AND COALESCE(rated_at, '0001-01-01 00:00:00+00') = ? AND id > ?)", condition)
+	assert.Equal(t, []interface{}{36.125, 36.125, ratedAt, 36.125, ratedAt, int64(7)}, args)
+
+	// Sin claves de orden solo se pagina por ID
+	condition, args = keysetCondition(nil, nil, 7)
+	assert.Equal(t, "(id > ?)", condition)
+	assert.Equal(t, []interface{}{int64(7)}, args)
+}
+
+// TestBuildCursorQuery verifica la consulta de la paginación por cursor: sin OFFSET y con la condición del cursor
+func TestBuildCursorQuery(t *testing.T) {
+	filter := domain.StockFilter{Currency: "USD", Recommends: true, Size: 10}
+	keys := stockSortKeys(filter)
+	cursorSQL := func(filter domain.StockFilter) string {
+		return newDryRunDB(t).ToSQL(func(tx *gorm.DB) *gorm.DB {
+			var stocks []domain.Stock
+			dbQuery, err := (&repository{db: tx}).buildCursorQuery(filter, keys)
+			assert.NoError(t, err)
+			return dbQuery.Limit(filter.Size + 1).Find(&stocks)
+		})
+	}
+
+	// Primera página: sin condición de cursor
+	sql := cursorSQL(filter)
+	assert.Contains(t, sql, "ORDER BY recommend_score DESC,id ASC LIMIT 11")
+	assert.NotContains(t, sql, "OFFSET")
+	assert.NotContains(t, sql, "id >")
+
+	// Página siguiente: continúa después del último stock, junto a los demás filtros
+	filter.Cursor, _ = encodeStockCursor(keys, domain.Stock{ID: 7, RecommendScore: 36.125})
+	sql = cursorSQL(filter)
+	assert.Contains(t, sql, "currency = 'USD'")
+	assert.Contains(t, sql, "AND ((recommend_score < 36.125) OR (recommend_score = 36.125 AND id > 7)) AND")
+
+	// Un cursor inválido se informa antes de consultar
+	filter.Cursor = "invalid"
+	_, err := (&repository{db: newDryRunDB(t)}).buildCursorQuery(filter, keys)
+	assert.True(t, errors.Is(err, domain.ErrInvalidCursor))
+}
+
+// TestStockSortKeys verifica que el orden explícito reemplace al de recommends y recent y se descarten los campos desconocidos
+func TestStockSortKeys(t *testing.T) {
+	keys := stockSortKeys(domain.StockFilter{
+		Recommends: true,
+		Sort: []domain.StockSort{
+			{Field: domain.StockSortTargetTo, Desc: true},
+			{Field: "target_to; DROP TABLE stocks"},
+			{Field: domain.StockSortTicker},
+		},
+	})
+
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "-target_to,ticker", sortSignature(keys))
+
+	// Sin orden explícito se mantienen recommends y recent
+	keys = stockSortKeys(domain.StockFilter{Recommends: true, Recent: true})
+	assert.Equal(t, "-recommend_score,-rated_at", sortSignature(keys))
+}
+
+// TestApplyStockOrder_Sort verifica el SQL del orden explícito, incluida la variación porcentual del precio objetivo
+func TestApplyStockOrder_Sort(t *testing.T) {
+	filter := domain.StockFilter{Sort: []domain.StockSort{
+		{Field: domain.StockSortTargetChangePct, Desc: true},
+		{Field: domain.StockSortTicker},
+	}}
+
+	sql := newDryRunDB(t).ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var stocks []domain.Stock
+		return applyStockOrder(tx.Model(&domain.Stock{}), filter).Find(&stocks)
+	})
+
+	assert.Contains(t, sql, "ORDER BY (CASE WHEN target_from > 0 THEN (target_to::float8 - target_from::float8) / target_from::float8 * 100 ELSE 0 END) DESC,ticker ASC,id ASC")
+}
+
+// TestStockCursor_SortValues verifica el cursor con claves de texto, enteras y calculadas
+func TestStockCursor_SortValues(t *testing.T) {
+	keys := stockSortKeys(domain.StockFilter{Sort: []domain.StockSort{
+		{Field: domain.StockSortTicker},
+		{Field: domain.StockSortID, Desc: true},
+		{Field: domain.StockSortTargetChangePct},
+	}})
+	stock := domain.Stock{ID: 9, Ticker: "AAPL", TargetFrom: 150, TargetTo: 180}
+
+	cursor, err := encodeStockCursor(keys, stock)
+	assert.NoError(t, err)
+
+	values, id, err := decodeStockCursor(keys, cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), id)
+	assert.Equal(t, []interface{}{"AAPL", int64(9), stock.TargetChangePct()}, values)
+
+	condition, _ := keysetCondition(keys, values, id)
+	assert.True(t, strings.HasPrefix(condition, "(ticker > ?) OR (ticker = ? AND id < ?) OR"))
+}
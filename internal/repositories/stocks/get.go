@@ -1,27 +1,32 @@
 package stocks
 
 import (
+	"context"
+	"errors"
 	"log"
+	"strings"
 
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"gorm.io/gorm"
 )
 
+// targetChangePctExpr calcula la variación porcentual del precio objetivo como domain.Stock.TargetChangePct,
+// en double precision para que los resultados coincidan exactamente
+const targetChangePctExpr = "(CASE WHEN target_from > 0 THEN (target_to::float8 - target_from::float8) / target_from::float8 * 100 ELSE 0 END)"
+
 // GetStocks obtiene los stocks filtrados, aplicando paginación en la base de datos.
-func (r *repository) GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error) {
+func (r *repository) GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error) {
 	var stocks []domain.Stock
 	var total int64
 
 	// Calculamos el offset para la paginación
-	offset := (page - 1) * size
+	offset := (filter.Page - 1) * filter.Size
 
 	// Construimos la consulta base
-	dbQuery := r.buildBaseQuery(query, minTargetTo, maxTargetTo, currency)
+	dbQuery := r.buildBaseQuery(filter)
 
-	// Si se solicitan recomendaciones, ordenamos por el puntaje de recomendación en orden descendente
-	if recommends {
-		dbQuery = dbQuery.Order("recommend_score DESC")
-	}
+	// Aplicamos el orden solicitado
+	dbQuery = applyStockOrder(dbQuery, filter)
 
 	// Contamos el total de registros sin paginar
 	if err := dbQuery.Count(&total).Error; err != nil {
@@ -32,7 +37,7 @@ func (r *repository) GetStocks(query string, page, size int, recommends bool, mi
 	// Aplicamos paginación
 	if err := dbQuery.
 		Offset(offset).
-		Limit(size).
+		Limit(filter.Size).
 		Find(&stocks).Error; err != nil {
 		log.Printf("Error obteniendo stocks: %v", err)
 		return nil, 0, err
@@ -41,10 +46,106 @@ func (r *repository) GetStocks(query string, page, size int, recommends bool, mi
 	return stocks, total, nil
 }
 
+// StreamStocks recorre todos los stocks filtrados, sin paginación, en el mismo orden que GetStocks.
+// Las filas se leen del cursor de la base de datos una a una, sin cargar el resultado completo en memoria,
+// y el recorrido se detiene con el primer error devuelto por fn o al cancelarse el contexto.
+func (r *repository) StreamStocks(ctx context.Context, filter domain.StockFilter, fn func(domain.Stock) error) error {
+	rows, err := r.buildStreamQuery(filter).WithContext(ctx).Rows()
+	if err != nil {
+		log.Printf("Error consultando stocks para exportar: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stock domain.Stock
+		if err := r.db.ScanRows(rows, &stock); err != nil {
+			log.Printf("Error leyendo stock para exportar: %v", err)
+			return err
+		}
+		if err := fn(stock); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// buildStreamQuery construye la consulta de StreamStocks: los filtros y el orden de GetStocks
+func (r *repository) buildStreamQuery(filter domain.StockFilter) *gorm.DB {
+	return applyStockOrder(r.buildBaseQuery(filter), filter)
+}
+
+// GetStocksByCursor obtiene una página de stocks filtrados a continuación del cursor del filtro, sin OFFSET ni conteo.
+// Devuelve también el cursor de la página siguiente, vacío si no quedan stocks.
+func (r *repository) GetStocksByCursor(filter domain.StockFilter) ([]domain.Stock, string, error) {
+	keys := stockSortKeys(filter)
+
+	dbQuery, err := r.buildCursorQuery(filter, keys)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Se pide un stock adicional para saber si existe una página siguiente
+	var stocks []domain.Stock
+	if err := dbQuery.Limit(filter.Size + 1).Find(&stocks).Error; err != nil {
+		log.Printf("Error obteniendo stocks por cursor: %v", err)
+		return nil, "", err
+	}
+	if len(stocks) <= filter.Size {
+		return stocks, "", nil
+	}
+
+	stocks = stocks[:filter.Size]
+	nextCursor, err := encodeStockCursor(keys, stocks[len(stocks)-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return stocks, nextCursor, nil
+}
+
+// buildCursorQuery construye la consulta de GetStocksByCursor: los filtros, el orden de GetStocks
+// y, si el filtro trae un cursor, la condición para continuar después del último stock entregado
+func (r *repository) buildCursorQuery(filter domain.StockFilter, keys []stockSortKey) (*gorm.DB, error) {
+	dbQuery := applyStockOrder(r.buildBaseQuery(filter), filter)
+	if filter.Cursor == "" {
+		return dbQuery, nil
+	}
+
+	values, id, err := decodeStockCursor(keys, filter.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	condition, args := keysetCondition(keys, values, id)
+	return dbQuery.Where(condition, args...), nil
+}
+
+// CountStocks cuenta los stocks que cumplen el filtro.
+func (r *repository) CountStocks(filter domain.StockFilter) (int64, error) {
+	var total int64
+	if err := r.buildBaseQuery(filter).Count(&total).Error; err != nil {
+		log.Printf("Error contando registros: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+// GetStockByID obtiene un stock por su ID.
+func (r *repository) GetStockByID(id int64) (domain.Stock, error) {
+	var stock domain.Stock
+	if err := r.db.First(&stock, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Stock{}, domain.ErrNotFound
+		}
+		log.Printf("Error obteniendo stock %d: %v", id, err)
+		return domain.Stock{}, err
+	}
+	return stock, nil
+}
+
 // buildBaseQuery construye la consulta base con todos los filtros aplicados
-func (r *repository) buildBaseQuery(query string, minTargetTo, maxTargetTo float64, currency string) *gorm.DB {
+func (r *repository) buildBaseQuery(filter domain.StockFilter) *gorm.DB {
 	// Preparar filtro de búsqueda
-	likeQuery := "%" + query + "%"
+	likeQuery := "%" + filter.Query + "%"
 
 	// Construir consulta base con filtro de texto
 	dbQuery := r.db.Model(&domain.Stock{}).
@@ -52,18 +153,136 @@ func (r *repository) buildBaseQuery(query string, minTargetTo, maxTargetTo float
 			likeQuery, likeQuery, likeQuery, likeQuery, likeQuery, likeQuery)
 
 	// Aplicar filtro de currency
-	if currency != "" {
-		dbQuery = dbQuery.Where("currency = ?", currency)
+	if filter.Currency != "" {
+		dbQuery = dbQuery.Where("currency = ?", filter.Currency)
+	}
+
+	// Aplicar filtro de brokerage exacto
+	if filter.Brokerage != "" {
+		dbQuery = dbQuery.Where("brokerage = ?", filter.Brokerage)
 	}
 
 	// Aplicar filtros de target_to si están especificados
-	if minTargetTo > 0 {
-		dbQuery = dbQuery.Where("target_to >= ?", minTargetTo)
+	if filter.MinTargetTo > 0 {
+		dbQuery = dbQuery.Where("target_to >= ?", filter.MinTargetTo)
 	}
 
-	if maxTargetTo > 0 {
-		dbQuery = dbQuery.Where("target_to <= ?", maxTargetTo)
+	if filter.MaxTargetTo > 0 {
+		dbQuery = dbQuery.Where("target_to <= ?", filter.MaxTargetTo)
+	}
+
+	// Aplicar filtros de fecha de calificación si están especificados
+	if !filter.From.IsZero() {
+		dbQuery = dbQuery.Where("rated_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		dbQuery = dbQuery.Where("rated_at < ?", filter.To)
+	}
+
+	return applyFieldFilters(dbQuery, filter)
+}
+
+// applyFieldFilters aplica los filtros por campo del filtro; cada uno se combina con los demás con AND
+func applyFieldFilters(dbQuery *gorm.DB, filter domain.StockFilter) *gorm.DB {
+	// Tickers exactos
+	if len(filter.Tickers) > 0 {
+		dbQuery = dbQuery.Where("ticker IN ?", filter.Tickers)
+	}
+
+	// Tipos de acción, por el comienzo del texto de la acción
+	if len(filter.Actions) > 0 {
+		conditions := make([]string, 0, len(filter.Actions))
+		args := make([]interface{}, 0, len(filter.Actions))
+		for _, action := range filter.Actions {
+			if prefix, ok := domain.StockActionPrefixes[action]; ok {
+				conditions = append(conditions, "action ILIKE ?")
+				args = append(args, prefix+"%")
+			}
+		}
+		if len(conditions) > 0 {
+			dbQuery = dbQuery.Where(strings.Join(conditions, " OR "), args...)
+		}
+	}
+
+	// Categorías de calificación
+	if len(filter.RatingTo) > 0 {
+		condition, args := ratingBucketCondition("rating_to", filter.RatingTo, filter.RatingBuckets)
+		dbQuery = dbQuery.Where(condition, args...)
+	}
+
+	if len(filter.RatingFrom) > 0 {
+		condition, args := ratingBucketCondition("rating_from", filter.RatingFrom, filter.RatingBuckets)
+		dbQuery = dbQuery.Where(condition, args...)
+	}
+
+	// Puntaje de recomendación
+	if filter.MinScore != nil {
+		dbQuery = dbQuery.Where("recommend_score >= ?", *filter.MinScore)
+	}
+
+	if filter.MaxScore != nil {
+		dbQuery = dbQuery.Where("recommend_score <= ?", *filter.MaxScore)
+	}
+
+	// Precio objetivo anterior
+	if filter.MinTargetFrom != nil {
+		dbQuery = dbQuery.Where("target_from >= ?", *filter.MinTargetFrom)
+	}
+
+	if filter.MaxTargetFrom != nil {
+		dbQuery = dbQuery.Where("target_from <= ?", *filter.MaxTargetFrom)
+	}
+
+	// Variación porcentual del precio objetivo
+	if filter.MinTargetChangePct != nil {
+		dbQuery = dbQuery.Where(targetChangePctExpr+" >= ?", *filter.MinTargetChangePct)
+	}
+
+	if filter.MaxTargetChangePct != nil {
+		dbQuery = dbQuery.Where(targetChangePctExpr+" <= ?", *filter.MaxTargetChangePct)
 	}
 
 	return dbQuery
 }
+
+// ratingBucketCondition construye la condición que selecciona las calificaciones de la columna indicada
+// que pertenecen a alguna de las categorías. La categoría other agrupa las calificaciones que no están en ninguna otra.
+func ratingBucketCondition(column string, categories []string, buckets domain.RatingBuckets) (string, []interface{}) {
+	normalized := "LOWER(TRIM(" + column + "))"
+
+	var ratings []string
+	other := false
+	for _, category := range categories {
+		switch category {
+		case domain.RatingBucketBuy:
+			ratings = append(ratings, buckets.Buy...)
+		case domain.RatingBucketHold:
+			ratings = append(ratings, buckets.Hold...)
+		case domain.RatingBucketSell:
+			ratings = append(ratings, buckets.Sell...)
+		case domain.RatingBucketOther:
+			other = true
+		}
+	}
+
+	var conditions []string
+	var args []interface{}
+	if len(ratings) > 0 {
+		conditions = append(conditions, normalized+" IN ?")
+		args = append(args, ratings)
+	}
+	if other {
+		known := append(append(append([]string{}, buckets.Buy...), buckets.Hold...), buckets.Sell...)
+		if len(known) == 0 {
+			return "1 = 1", nil
+		}
+		conditions = append(conditions, normalized+" NOT IN ?")
+		args = append(args, known)
+	}
+	if len(conditions) == 0 {
+		// Las categorías solicitadas no tienen calificaciones configuradas
+		return "1 = 0", nil
+	}
+	return strings.Join(conditions, " OR "), args
+}
@@ -2,10 +2,13 @@ package stocks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 // MockDatabase simula un repositorio para pruebas
@@ -14,15 +17,15 @@ type MockDatabase struct {
 }
 
 // GetStocks simula la recuperación de stocks
-func (m *MockDatabase) GetStocks(query string, minTargetTo, maxTargetTo float64, currency string, page, size int) ([]domain.Stock, int64, error) {
-	args := m.Called(query, minTargetTo, maxTargetTo, currency, page, size)
+func (m *MockDatabase) GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]domain.Stock), args.Get(1).(int64), args.Error(2)
 }
 
-// ReplaceAllStocks simula el reemplazo de stocks (para cumplir con la interfaz)
-func (m *MockDatabase) ReplaceAllStocks(stocks []domain.Stock) error {
-	args := m.Called(stocks)
-	return args.Error(0)
+// UpsertStocks simula la sincronización de stocks (para cumplir con la interfaz)
+func (m *MockDatabase) UpsertStocks(stocks []domain.Stock, removeMissing bool) (domain.SyncResult, error) {
+	args := m.Called(stocks, removeMissing)
+	return args.Get(0).(domain.SyncResult), args.Error(1)
 }
 
 // Datos de prueba para stocks
@@ -68,11 +71,11 @@ func TestGetStocks_BasicSearch(t *testing.T) {
 	mockDB := new(MockDatabase)
 
 	// Configurar expectativas
-	mockDB.On("GetStocks", "Apple", 0.0, 0.0, "USD", 1, 10).
+	mockDB.On("GetStocks", domain.StockFilter{Query: "Apple", Page: 1, Size: 10, Currency: "USD"}).
 		Return([]domain.Stock{testStocks[0]}, int64(1), nil)
 
 	// Ejecutar búsqueda
-	stocks, total, err := mockDB.GetStocks("Apple", 0.0, 0.0, "USD", 1, 10)
+	stocks, total, err := mockDB.GetStocks(domain.StockFilter{Query: "Apple", Page: 1, Size: 10, Currency: "USD"})
 
 	// Verificaciones
 	assert.NoError(t, err)
@@ -90,11 +93,11 @@ func TestGetStocks_PriceFilter(t *testing.T) {
 	mockDB := new(MockDatabase)
 
 	// Configurar expectativas
-	mockDB.On("GetStocks", "", 1000.0, 2500.0, "USD", 1, 10).
+	mockDB.On("GetStocks", domain.StockFilter{Page: 1, Size: 10, MinTargetTo: 1000.0, MaxTargetTo: 2500.0, Currency: "USD"}).
 		Return([]domain.Stock{testStocks[1]}, int64(1), nil)
 
 	// Ejecutar búsqueda con filtro de precio mínimo
-	stocks, total, err := mockDB.GetStocks("", 1000.0, 2500.0, "USD", 1, 10)
+	stocks, total, err := mockDB.GetStocks(domain.StockFilter{Page: 1, Size: 10, MinTargetTo: 1000.0, MaxTargetTo: 2500.0, Currency: "USD"})
 
 	// Verificaciones
 	assert.NoError(t, err)
@@ -112,11 +115,11 @@ func TestGetStocks_CurrencyFilter(t *testing.T) {
 	mockDB := new(MockDatabase)
 
 	// Configurar expectativas
-	mockDB.On("GetStocks", "", 0.0, 0.0, "EUR", 1, 10).
+	mockDB.On("GetStocks", domain.StockFilter{Page: 1, Size: 10, Currency: "EUR"}).
 		Return([]domain.Stock{testStocks[2]}, int64(1), nil)
 
 	// Ejecutar búsqueda con filtro de moneda
-	stocks, total, err := mockDB.GetStocks("", 0.0, 0.0, "EUR", 1, 10)
+	stocks, total, err := mockDB.GetStocks(domain.StockFilter{Page: 1, Size: 10, Currency: "EUR"})
 
 	// Verificaciones
 	assert.NoError(t, err)
@@ -134,11 +137,11 @@ func TestGetStocks_Pagination(t *testing.T) {
 	mockDB := new(MockDatabase)
 
 	// Configurar expectativas
-	mockDB.On("GetStocks", "", 0.0, 0.0, "USD", 2, 1).
+	mockDB.On("GetStocks", domain.StockFilter{Page: 2, Size: 1, Currency: "USD"}).
 		Return([]domain.Stock{testStocks[1]}, int64(2), nil)
 
 	// Ejecutar búsqueda con límite de 1 y página 2
-	stocks, total, err := mockDB.GetStocks("", 0.0, 0.0, "USD", 2, 1)
+	stocks, total, err := mockDB.GetStocks(domain.StockFilter{Page: 2, Size: 1, Currency: "USD"})
 
 	// Verificaciones
 	assert.NoError(t, err)
@@ -149,3 +152,102 @@ func TestGetStocks_Pagination(t *testing.T) {
 	// Verificar que se llamó al método con los parámetros esperados
 	mockDB.AssertExpectations(t)
 }
+
+// newDryRunDB crea una conexión GORM en modo DryRun que genera SQL sin ejecutarlo
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	assert.NoError(t, err)
+	return db
+}
+
+// querySQL devuelve el SQL generado por buildBaseQuery para el filtro indicado
+func querySQL(t *testing.T, filter domain.StockFilter) string {
+	db := newDryRunDB(t)
+	return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var stocks []domain.Stock
+		return (&repository{db: tx}).buildBaseQuery(filter).Find(&stocks)
+	})
+}
+
+// TestBuildBaseQuery_DateFilters verifica los filtros por fecha de calificación
+func TestBuildBaseQuery_DateFilters(t *testing.T) {
+	from := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	sql := querySQL(t, domain.StockFilter{From: from, To: to})
+
+	assert.Contains(t, sql, "rated_at >= '2025-01-06 00:00:00'")
+	assert.Contains(t, sql, "rated_at < '2025-01-13 00:00:00'")
+	assert.Contains(t, sql, `"stocks"."deleted_at" IS NULL`)
+
+	// Sin fechas no se agregan filtros
+	assert.NotContains(t, querySQL(t, domain.StockFilter{}), "rated_at")
+}
+
+// TestBuildStreamQuery verifica que la exportación aplique los filtros y el orden de GetStocks, sin paginación
+func TestBuildStreamQuery(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var stocks []domain.Stock
+		filter := domain.StockFilter{Currency: "USD", Recommends: true, Page: 3, Size: 10}
+		return (&repository{db: tx}).buildStreamQuery(filter).Find(&stocks)
+	})
+
+	assert.Contains(t, sql, "currency = 'USD'")
+	assert.Contains(t, sql, "ORDER BY recommend_score DESC,id ASC")
+	assert.NotContains(t, sql, "LIMIT")
+	assert.NotContains(t, sql, "OFFSET")
+}
+
+// TestBuildBaseQuery_FieldFilters verifica que los filtros por campo se combinen con AND
+func TestBuildBaseQuery_FieldFilters(t *testing.T) {
+	minScore, maxScore := -5.0, 20.0
+	minTarget, maxTarget := -1.0, 0.0
+	minChange := 10.0
+
+	sql := querySQL(t, domain.StockFilter{
+		Tickers:            []string{"AAPL", "MSFT"},
+		Brokerage:          "Goldman Sachs",
+		Actions:            []string{domain.StockActionUpgraded, domain.StockActionTargetRaised, "unknown"},
+		RatingTo:           []string{domain.RatingBucketBuy},
+		RatingBuckets:      domain.RatingBuckets{Buy: []string{"buy", "outperform"}, Hold: []string{"hold"}, Sell: []string{"sell"}},
+		MinScore:           &minScore,
+		MaxScore:           &maxScore,
+		MinTargetFrom:      &minTarget,
+		MaxTargetFrom:      &maxTarget,
+		MinTargetChangePct: &minChange,
+	})
+
+	assert.Contains(t, sql, "ticker IN ('AAPL','MSFT')")
+	assert.Contains(t, sql, "brokerage = 'Goldman Sachs'")
+	assert.Contains(t, sql, "(action ILIKE 'upgraded%' OR action ILIKE 'target raised%')")
+	assert.Contains(t, sql, "LOWER(TRIM(rating_to)) IN ('buy','outperform')")
+	assert.Contains(t, sql, "recommend_score >= -5 AND recommend_score <= 20")
+	// Los límites en cero o negativos también se aplican
+	assert.Contains(t, sql, "target_from >= -1 AND target_from <= 0")
+	assert.Contains(t, sql, targetChangePctExpr+" >= 10")
+	assert.NotContains(t, sql, "LOWER(TRIM(rating_from))")
+	assert.NotContains(t, sql, targetChangePctExpr+" <=")
+}
+
+// TestRatingBucketCondition verifica la condición de las categorías de calificación, incluida other
+func TestRatingBucketCondition(t *testing.T) {
+	buckets := domain.RatingBuckets{Buy: []string{"buy"}, Hold: []string{"hold"}, Sell: []string{"sell"}}
+
+	condition, args := ratingBucketCondition("rating_from", []string{domain.RatingBucketSell, domain.RatingBucketOther}, buckets)
+	assert.Equal(t, "LOWER(TRIM(rating_from)) IN ? OR LOWER(TRIM(rating_from)) NOT IN ?", condition)
+	assert.Equal(t, []interface{}{[]string{"sell"}, []string{"buy", "hold", "sell"}}, args)
+
+	// Categorías sin calificaciones configuradas no coinciden con ningún stock
+	condition, args = ratingBucketCondition("rating_to", []string{domain.RatingBucketBuy}, domain.RatingBuckets{})
+	assert.Equal(t, "1 = 0", condition)
+	assert.Nil(t, args)
+
+	// Sin calificaciones conocidas, other coincide con todos
+	condition, _ = ratingBucketCondition("rating_to", []string{domain.RatingBucketOther}, domain.RatingBuckets{})
+	assert.Equal(t, "1 = 1", condition)
+}
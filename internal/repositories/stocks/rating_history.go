@@ -0,0 +1,43 @@
+package stocks
+
+import (
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+)
+
+// GetRatingHistory obtiene el historial de calificaciones de un ticker, opcionalmente de un solo brokerage,
+// ordenado del más antiguo al más reciente y con paginación.
+func (r *repository) GetRatingHistory(ticker, brokerage string, page, size int) ([]domain.StockRatingHistory, int64, error) {
+	var history []domain.StockRatingHistory
+	var total int64
+
+	dbQuery := r.buildRatingHistoryQuery(ticker, brokerage)
+
+	if err := dbQuery.Count(&total).Error; err != nil {
+		log.Printf("Error contando historial del ticker %s: %v", ticker, err)
+		return nil, 0, err
+	}
+
+	if err := dbQuery.
+		Order("observed_at ASC").
+		Order("id ASC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&history).Error; err != nil {
+		log.Printf("Error obteniendo historial del ticker %s: %v", ticker, err)
+		return nil, 0, err
+	}
+
+	return history, total, nil
+}
+
+// buildRatingHistoryQuery construye la consulta del historial de un ticker
+func (r *repository) buildRatingHistoryQuery(ticker, brokerage string) *gorm.DB {
+	dbQuery := r.db.Model(&domain.StockRatingHistory{}).Where("ticker = ?", ticker)
+	if brokerage != "" {
+		dbQuery = dbQuery.Where("brokerage = ?", brokerage)
+	}
+	return dbQuery
+}
@@ -0,0 +1,27 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestRatingHistoryQuery verifica el SQL del historial de un ticker, con y sin brokerage
+func TestRatingHistoryQuery(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var history []domain.StockRatingHistory
+		return (&repository{db: tx}).buildRatingHistoryQuery("AAPL", "").Find(&history)
+	})
+	assert.Contains(t, sql, `FROM "stock_rating_history" WHERE ticker = 'AAPL'`)
+	assert.NotContains(t, sql, "brokerage =")
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var history []domain.StockRatingHistory
+		return (&repository{db: tx}).buildRatingHistoryQuery("AAPL", "Goldman Sachs").Find(&history)
+	})
+	assert.Contains(t, sql, `WHERE ticker = 'AAPL' AND brokerage = 'Goldman Sachs'`)
+}
@@ -0,0 +1,60 @@
+package stocks
+
+import (
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// GetStocksAfterID obtiene hasta limit stocks activos con ID mayor que afterID, ordenados por ID.
+// Permite recorrer la tabla completa por lotes sin depender de OFFSET.
+func (r *repository) GetStocksAfterID(afterID int64, limit int) ([]domain.Stock, error) {
+	var stocks []domain.Stock
+	if err := r.db.
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&stocks).Error; err != nil {
+		log.Printf("Error obteniendo stocks desde el ID %d: %v", afterID, err)
+		return nil, err
+	}
+	return stocks, nil
+}
+
+// UpdateStockScores actualiza el puntaje de recomendación de los stocks indicados (ID -> puntaje)
+// con una única sentencia UPDATE ... FROM (VALUES ...).
+func (r *repository) UpdateStockScores(scores map[int64]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	query, args := scoreUpdateQuery(scores)
+	if err := r.db.Exec(query, args...).Error; err != nil {
+		log.Printf("Error actualizando puntajes de recomendación: %v", err)
+		return err
+	}
+	return nil
+}
+
+// scoreUpdateQuery construye la sentencia que actualiza los puntajes indicados y sus parámetros.
+// Las filas se ordenan por ID para que la sentencia sea determinista.
+func scoreUpdateQuery(scores map[int64]float64) (string, []interface{}) {
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	rows := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)*2)
+	for i, id := range ids {
+		rows[i] = "(?::bigint, ?::double precision)"
+		args = append(args, id, scores[id])
+	}
+
+	query := "UPDATE stocks SET recommend_score = v.score FROM (VALUES " + strings.Join(rows, ", ") + ") AS v(id, score) " +
+		"WHERE stocks.id = v.id AND stocks.deleted_at IS NULL"
+	return query, args
+}
@@ -0,0 +1,28 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestRescoreQueries verifica el SQL generado para recorrer los stocks por lotes y actualizar su puntaje
+func TestRescoreQueries(t *testing.T) {
+	db := newDryRunDB(t)
+
+	selectSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var stocks []domain.Stock
+		return tx.Where("id > ?", 500).Order("id ASC").Limit(500).Find(&stocks)
+	})
+	assert.Contains(t, selectSQL, `WHERE id > 500 AND "stocks"."deleted_at" IS NULL ORDER BY id ASC LIMIT 500`)
+
+	// Un lote completo se actualiza con una sola sentencia
+	query, args := scoreUpdateQuery(map[int64]float64{9: 3, 7: 12.5})
+	updateSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Exec(query, args...)
+	})
+	assert.Equal(t, "UPDATE stocks SET recommend_score = v.score FROM (VALUES (7::bigint, 12.5::double precision), "+
+		"(9::bigint, 3::double precision)) AS v(id, score) WHERE stocks.id = v.id AND stocks.deleted_at IS NULL", updateSQL)
+}
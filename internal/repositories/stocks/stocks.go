@@ -1,17 +1,110 @@
 package stocks
 
 import (
+	"context"
+
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"gorm.io/gorm"
 )
 
 // Repository define las operaciones disponibles para manejar stocks.
 type Repository interface {
-	// ReplaceAllStocks reemplaza todos los stocks en la base de datos.
-	ReplaceAllStocks(stocks []domain.Stock) error
+	// UpsertStocks inserta, actualiza y opcionalmente elimina stocks según su clave natural,
+	// registrando los stocks observados en el historial de la ejecución runID.
+	UpsertStocks(ctx context.Context, stocks []domain.Stock, removeMissing bool, runID int64) (domain.SyncResult, error)
+
+	// RemoveMissingStocks marca como eliminados los stocks activos que no fueron observados por la ejecución runID.
+	RemoveMissingStocks(runID int64) (int, error)
+
+	// PreviewUpsertStocks calcula los cambios que aplicaría UpsertStocks sin modificar la base de datos,
+	// junto con una muestra de hasta sampleSize stocks que se insertarían o actualizarían.
+	PreviewUpsertStocks(stocks []domain.Stock, sampleSize int) (domain.SyncResult, []domain.SyncPreviewRow, error)
+
+	// CountMissingStocks cuenta los stocks activos cuya clave natural no está en keys.
+	CountMissingStocks(keys []string) (int, error)
 
 	// GetStocks obtiene los stocks filtrados, aplicando paginación en la base de datos.
-	GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error)
+	GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error)
+
+	// GetStocksByCursor obtiene una página de stocks filtrados a continuación del cursor del filtro,
+	// junto con el cursor de la página siguiente (vacío si no quedan stocks).
+	GetStocksByCursor(filter domain.StockFilter) ([]domain.Stock, string, error)
+
+	// CountStocks cuenta los stocks que cumplen el filtro.
+	CountStocks(filter domain.StockFilter) (int64, error)
+
+	// StreamStocks recorre todos los stocks filtrados, sin paginación, leyéndolos de la base de datos con un cursor.
+	StreamStocks(ctx context.Context, filter domain.StockFilter, fn func(domain.Stock) error) error
+
+	// GetTickerSummaries obtiene el resumen de consenso por ticker de los stocks filtrados, con paginación.
+	GetTickerSummaries(filter domain.StockFilter, buckets domain.RatingBuckets) ([]domain.TickerSummary, int64, error)
+
+	// GetTickerSummary obtiene el resumen de consenso de un ticker.
+	GetTickerSummary(ticker string, filter domain.StockFilter, buckets domain.RatingBuckets) (domain.TickerSummary, error)
+
+	// GetTickerStocks obtiene las acciones de los brokerages sobre un ticker.
+	GetTickerStocks(ticker string, filter domain.StockFilter) ([]domain.Stock, error)
+
+	// GetBrokerageSummaries obtiene el resumen de comportamiento por brokerage de los stocks filtrados, con paginación.
+	GetBrokerageSummaries(filter domain.StockFilter) ([]domain.BrokerageSummary, int64, error)
+
+	// GetStockByID obtiene un stock por su ID.
+	GetStockByID(id int64) (domain.Stock, error)
+
+	// GetStocksAfterID obtiene hasta limit stocks activos con ID mayor que afterID, ordenados por ID.
+	GetStocksAfterID(afterID int64, limit int) ([]domain.Stock, error)
+
+	// UpdateStockScores actualiza el puntaje de recomendación de los stocks indicados (ID -> puntaje).
+	UpdateStockScores(scores map[int64]float64) error
+
+	// GetRatingHistory obtiene el historial de calificaciones de un ticker, opcionalmente de un solo brokerage,
+	// del más antiguo al más reciente.
+	GetRatingHistory(ticker, brokerage string, page, size int) ([]domain.StockRatingHistory, int64, error)
+
+	// CreateSyncRun registra el inicio de una ejecución de la sincronización.
+	CreateSyncRun(run *domain.SyncRun) error
+
+	// SaveSyncRun actualiza una ejecución de la sincronización.
+	SaveSyncRun(run *domain.SyncRun) error
+
+	// GetSyncRuns obtiene las ejecuciones de la sincronización, de la más reciente a la más antigua.
+	GetSyncRuns(page, size int) ([]domain.SyncRun, int64, error)
+
+	// GetSyncRun obtiene una ejecución de la sincronización por su ID.
+	GetSyncRun(id int64) (domain.SyncRun, error)
+
+	// GetPreviousSyncRunID obtiene el ID de la última ejecución anterior a runID que registró historial, o 0 si no existe.
+	GetPreviousSyncRunID(runID int64) (int64, error)
+
+	// GetSyncRunRatings obtiene las acciones observadas por una ejecución de la sincronización.
+	GetSyncRunRatings(runID int64) ([]domain.StockRatingHistory, error)
+
+	// CreateSyncJob registra un nuevo job de sincronización.
+	CreateSyncJob(job *domain.SyncJob) error
+
+	// SaveSyncJob actualiza el estado y el progreso de un job de sincronización.
+	SaveSyncJob(job *domain.SyncJob) error
+
+	// GetSyncJob obtiene un job de sincronización por su ID.
+	GetSyncJob(id int64) (domain.SyncJob, error)
+
+	// FindSyncJobsByState obtiene los jobs en alguno de los estados indicados.
+	FindSyncJobsByState(states ...string) ([]domain.SyncJob, error)
+
+	// SaveSyncCheckpoint registra una página procesada de la sincronización en curso y sus elementos rechazados.
+	SaveSyncCheckpoint(checkpoint *domain.SyncCheckpoint, rejects []domain.SyncReject) error
+
+	// GetSyncCheckpoints obtiene los checkpoints pendientes ordenados por iteración.
+	GetSyncCheckpoints() ([]domain.SyncCheckpoint, error)
+
+	// ClearSyncCheckpoints elimina todos los checkpoints de sincronización.
+	ClearSyncCheckpoints() error
+
+	// SaveSyncRejects registra elementos rechazados fuera de una sincronización, como las filas de una importación.
+	SaveSyncRejects(rejects []domain.SyncReject) error
+
+	// GetSyncRejects obtiene los elementos rechazados durante las sincronizaciones, del más reciente al más antiguo.
+	GetSyncRejects(page, size int) ([]domain.SyncReject, int64, error)
 }
 
 // repository implementa la interfaz Repository.
@@ -1,41 +1,315 @@
 package stocks
 
 import (
+	"context"
 	"log"
+	"time"
 
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// ReplaceAllStocks reemplaza la data existente en la tabla Stock por la nueva data.
-// Esta función es llamada desde el servicio después de haber obtenido los stocks
-// de la API externa y haberlos procesado.
-func (r *repository) ReplaceAllStocks(stocks []domain.Stock) error {
-	log.Printf("Reemplazando todos los stocks existentes con %d nuevos registros", len(stocks))
-
-	// Usar transacción para asegurar que ambas operaciones son atómicas
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Eliminar todos los registros existentes
-		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&domain.Stock{}).Error; err != nil {
-			log.Printf("Error eliminando stocks existentes: %v", err)
+const (
+	// batchSize es el tamaño de lote usado para inserciones masivas
+	batchSize = 100
+
+	// keyChunkSize limita la cantidad de claves enviadas en una cláusula IN
+	keyChunkSize = 500
+)
+
+// upsertColumns son las columnas que se actualizan cuando un stock ya existe.
+// deleted_at se incluye para reactivar stocks que habían sido eliminados.
+var upsertColumns = []string{
+	"ticker", "company", "brokerage", "action", "rating_from", "rating_to",
+	"target_from", "target_to", "currency", "recommend_score", "rated_at", "updated_at", "deleted_at",
+}
+
+// UpsertStocks sincroniza la tabla Stock con la nueva data usando la clave natural de cada stock.
+// Inserta los stocks nuevos, actualiza los que cambiaron y, si removeMissing es true,
+// marca como eliminados los que ya no están presentes en la nueva data.
+// Si runID no es 0, registra cada stock observado en el historial de calificaciones de esa ejecución.
+// La transacción se cancela si ctx termina antes de confirmarse.
+func (r *repository) UpsertStocks(ctx context.Context, stocks []domain.Stock, removeMissing bool, runID int64) (domain.SyncResult, error) {
+	var result domain.SyncResult
+
+	// Asignar claves naturales y descartar duplicados dentro del mismo lote
+	stocks = dedupeByNaturalKey(stocks)
+	log.Printf("Sincronizando %d stocks por clave natural", len(stocks))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Obtener los stocks existentes (incluyendo eliminados) para clasificar los cambios
+		existing, err := findByNaturalKeys(tx, naturalKeys(stocks))
+		if err != nil {
+			log.Printf("Error obteniendo stocks existentes: %v", err)
 			return err
 		}
 
-		// Si no hay stocks para insertar, terminamos aquí
-		if len(stocks) == 0 {
-			log.Println("No hay stocks para insertar, tabla limpiada")
-			return nil
+		// Clasificar cada stock como nuevo, modificado o sin cambios
+		var toWrite []domain.Stock
+		result, toWrite = classifyStocks(stocks, existing, nil)
+
+		// Insertar o actualizar en lotes según la clave natural
+		if len(toWrite) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "natural_key"}},
+				DoUpdates: clause.AssignmentColumns(upsertColumns),
+			}).CreateInBatches(&toWrite, batchSize).Error; err != nil {
+				log.Printf("Error insertando o actualizando stocks: %v", err)
+				return err
+			}
 		}
 
-		// Insertar los nuevos registros
-		// Usamos CreateInBatches para mejorar el rendimiento con grandes volúmenes de datos
-		batchSize := 100
-		if err := tx.CreateInBatches(&stocks, batchSize).Error; err != nil {
-			log.Printf("Error insertando nuevos stocks: %v", err)
-			return err
+		// Eliminar (soft delete) los stocks que ya no vienen en la fuente externa
+		if removeMissing {
+			removed, err := removeMissingStocks(tx, naturalKeys(stocks))
+			if err != nil {
+				log.Printf("Error eliminando stocks ausentes: %v", err)
+				return err
+			}
+			result.Removed = removed
+		}
+
+		// Registrar todas las acciones observadas, con o sin cambios, en el historial
+		if runID != 0 {
+			if err := appendRatingHistory(tx, stocks, runID); err != nil {
+				log.Printf("Error registrando historial de calificaciones: %v", err)
+				return err
+			}
 		}
 
-		log.Printf("Sincronización completada: %d stocks reemplazados exitosamente", len(stocks))
 		return nil
 	})
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+
+	log.Printf("Sincronización completada: %d insertados, %d actualizados, %d sin cambios, %d eliminados",
+		result.Inserted, result.Updated, result.Unchanged, result.Removed)
+	return result, nil
+}
+
+// RemoveMissingStocks marca como eliminados los stocks activos que no fueron observados por la ejecución runID.
+// Los stocks observados se obtienen del historial de calificaciones de la ejecución.
+// Sin una ejecución no hay historial con el que comparar, por lo que no se elimina ningún stock.
+func (r *repository) RemoveMissingStocks(runID int64) (int, error) {
+	if runID == 0 {
+		return 0, nil
+	}
+
+	res := r.buildRemoveMissingQuery(runID).Delete(&domain.Stock{})
+	if res.Error != nil {
+		log.Printf("Error eliminando stocks ausentes en la ejecución %d: %v", runID, res.Error)
+		return 0, res.Error
+	}
+
+	log.Printf("%d stocks ausentes en la ejecución %d marcados como eliminados", res.RowsAffected, runID)
+	return int(res.RowsAffected), nil
+}
+
+// buildRemoveMissingQuery construye la condición de los stocks no observados por una ejecución.
+// Los registros anteriores a la clave natural no pueden coincidir con la nueva data.
+func (r *repository) buildRemoveMissingQuery(runID int64) *gorm.DB {
+	observed := r.db.Model(&domain.StockRatingHistory{}).
+		Select("natural_key").
+		Where("sync_run_id = ?", runID)
+	return r.db.Where("natural_key IS NULL OR natural_key NOT IN (?)", observed)
+}
+
+// PreviewUpsertStocks calcula los cambios que aplicaría UpsertStocks sin modificar la base de datos.
+// Devuelve además hasta sampleSize stocks que se insertarían o actualizarían.
+func (r *repository) PreviewUpsertStocks(stocks []domain.Stock, sampleSize int) (domain.SyncResult, []domain.SyncPreviewRow, error) {
+	stocks = dedupeByNaturalKey(stocks)
+
+	existing, err := findByNaturalKeys(r.db, naturalKeys(stocks))
+	if err != nil {
+		log.Printf("Error obteniendo stocks existentes: %v", err)
+		return domain.SyncResult{}, nil, err
+	}
+
+	samples := make([]domain.SyncPreviewRow, 0, sampleSize)
+	result, _ := classifyStocks(stocks, existing, func(change string, stock domain.Stock) {
+		if len(samples) < sampleSize {
+			samples = append(samples, domain.SyncPreviewRow{Change: change, Stock: stock})
+		}
+	})
+
+	return result, samples, nil
+}
+
+// CountMissingStocks cuenta los stocks activos que se eliminarían si la nueva data tuviera solo las claves indicadas.
+func (r *repository) CountMissingStocks(keys []string) (int, error) {
+	missing, err := countMissingStocks(r.db, keys)
+	if err != nil {
+		log.Printf("Error contando stocks ausentes: %v", err)
+		return 0, err
+	}
+	return missing, nil
+}
+
+// classifyStocks clasifica cada stock como nuevo, modificado o sin cambios respecto de los existentes
+// y devuelve los que deben escribirse. onChange, si no es nil, se invoca por cada stock a escribir.
+func classifyStocks(stocks []domain.Stock, existing map[string]domain.Stock, onChange func(change string, stock domain.Stock)) (domain.SyncResult, []domain.Stock) {
+	var result domain.SyncResult
+	toWrite := make([]domain.Stock, 0, len(stocks))
+
+	for _, stock := range stocks {
+		current, found := existing[stock.NaturalKey]
+		change := ""
+		switch {
+		case !found:
+			result.Inserted++
+			change = domain.SyncChangeInsert
+		case current.DeletedAt.Valid || !current.SameContent(stock):
+			result.Updated++
+			change = domain.SyncChangeUpdate
+		default:
+			result.Unchanged++
+			continue
+		}
+
+		toWrite = append(toWrite, stock)
+		if onChange != nil {
+			onChange(change, stock)
+		}
+	}
+
+	return result, toWrite
+}
+
+// appendRatingHistory inserta en el historial los stocks observados en una ejecución
+func appendRatingHistory(tx *gorm.DB, stocks []domain.Stock, runID int64) error {
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	observedAt := time.Now()
+	history := make([]domain.StockRatingHistory, len(stocks))
+	for i, stock := range stocks {
+		history[i] = domain.NewStockRatingHistory(stock, runID, observedAt)
+	}
+
+	return tx.CreateInBatches(&history, batchSize).Error
+}
+
+// dedupeByNaturalKey asigna la clave natural a cada stock y conserva solo la última
+// aparición de cada clave, manteniendo el orden de llegada.
+func dedupeByNaturalKey(stocks []domain.Stock) []domain.Stock {
+	positions := make(map[string]int, len(stocks))
+	unique := make([]domain.Stock, 0, len(stocks))
+
+	for _, stock := range stocks {
+		stock.NaturalKey = stock.ComputeNaturalKey()
+		if pos, exists := positions[stock.NaturalKey]; exists {
+			unique[pos] = stock
+			continue
+		}
+		positions[stock.NaturalKey] = len(unique)
+		unique = append(unique, stock)
+	}
+
+	return unique
+}
+
+// naturalKeys devuelve las claves naturales de los stocks
+func naturalKeys(stocks []domain.Stock) []string {
+	keys := make([]string, len(stocks))
+	for i, stock := range stocks {
+		keys[i] = stock.NaturalKey
+	}
+	return keys
+}
+
+// findByNaturalKeys obtiene los stocks existentes indexados por clave natural
+func findByNaturalKeys(tx *gorm.DB, keys []string) (map[string]domain.Stock, error) {
+	existing := make(map[string]domain.Stock, len(keys))
+
+	for _, chunk := range chunkKeys(keys) {
+		var rows []domain.Stock
+		if err := tx.Unscoped().Where("natural_key IN ?", chunk).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			existing[row.NaturalKey] = row
+		}
+	}
+
+	return existing, nil
+}
+
+// removeMissingStocks marca como eliminados los stocks activos cuya clave no está en keys
+func removeMissingStocks(tx *gorm.DB, keys []string) (int, error) {
+	// Registros anteriores a la clave natural no pueden coincidir con la nueva data
+	legacy := tx.Where("natural_key IS NULL").Delete(&domain.Stock{})
+	if legacy.Error != nil {
+		return 0, legacy.Error
+	}
+	removed := int(legacy.RowsAffected)
+
+	missing, err := findMissingKeys(tx, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, chunk := range chunkKeys(missing) {
+		res := tx.Where("natural_key IN ?", chunk).Delete(&domain.Stock{})
+		if res.Error != nil {
+			return 0, res.Error
+		}
+		removed += int(res.RowsAffected)
+	}
+
+	return removed, nil
+}
+
+// chunkKeys divide las claves en grupos de tamaño keyChunkSize
+func chunkKeys(keys []string) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(keys); start += keyChunkSize {
+		end := start + keyChunkSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		chunks = append(chunks, keys[start:end])
+	}
+	return chunks
+}
+
+// countMissingStocks cuenta los stocks activos que removeMissingStocks marcaría como eliminados
+func countMissingStocks(tx *gorm.DB, keys []string) (int, error) {
+	var legacy int64
+	if err := tx.Model(&domain.Stock{}).Where("natural_key IS NULL").Count(&legacy).Error; err != nil {
+		return 0, err
+	}
+
+	missing, err := findMissingKeys(tx, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(legacy) + len(missing), nil
+}
+
+// findMissingKeys obtiene las claves de los stocks activos que no están en keys
+func findMissingKeys(tx *gorm.DB, keys []string) ([]string, error) {
+	var liveKeys []string
+	if err := tx.Model(&domain.Stock{}).
+		Where("natural_key IS NOT NULL").
+		Pluck("natural_key", &liveKeys).Error; err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		keep[key] = true
+	}
+
+	var missing []string
+	for _, key := range liveKeys {
+		if !keep[key] {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing, nil
 }
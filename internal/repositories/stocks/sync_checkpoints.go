@@ -0,0 +1,46 @@
+package stocks
+
+import (
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+)
+
+// SaveSyncCheckpoint registra una página procesada de la sincronización en curso
+// junto con sus elementos rechazados, en una única transacción.
+func (r *repository) SaveSyncCheckpoint(checkpoint *domain.SyncCheckpoint, rejects []domain.SyncReject) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(checkpoint).Error; err != nil {
+			return err
+		}
+		if len(rejects) > 0 {
+			return tx.CreateInBatches(&rejects, batchSize).Error
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error guardando checkpoint de la iteración %d: %v", checkpoint.Iteration, err)
+		return err
+	}
+	return nil
+}
+
+// GetSyncCheckpoints obtiene los checkpoints pendientes ordenados por iteración.
+func (r *repository) GetSyncCheckpoints() ([]domain.SyncCheckpoint, error) {
+	var checkpoints []domain.SyncCheckpoint
+	if err := r.db.Order("iteration ASC").Find(&checkpoints).Error; err != nil {
+		log.Printf("Error obteniendo checkpoints de sincronización: %v", err)
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// ClearSyncCheckpoints elimina todos los checkpoints de sincronización.
+func (r *repository) ClearSyncCheckpoints() error {
+	if err := r.db.Where("1 = 1").Delete(&domain.SyncCheckpoint{}).Error; err != nil {
+		log.Printf("Error eliminando checkpoints de sincronización: %v", err)
+		return err
+	}
+	return nil
+}
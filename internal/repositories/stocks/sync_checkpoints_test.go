@@ -0,0 +1,49 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestSyncCheckpointQueries verifica el SQL generado para leer y limpiar los checkpoints
+func TestSyncCheckpointQueries(t *testing.T) {
+	db := newDryRunDB(t)
+
+	selectSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var checkpoints []domain.SyncCheckpoint
+		return tx.Order("iteration ASC").Find(&checkpoints)
+	})
+	assert.Contains(t, selectSQL, `FROM "sync_checkpoints" ORDER BY iteration ASC`)
+
+	insertSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Create(&domain.SyncCheckpoint{Iteration: 1, NextPage: "page-2", Stocks: []domain.Stock{{Ticker: "AAPL"}}})
+	})
+	assert.Contains(t, insertSQL, `INSERT INTO "sync_checkpoints"`)
+	assert.Contains(t, insertSQL, `"ticker":"AAPL"`, "Los stocks deben serializarse como JSON")
+
+	deleteSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("1 = 1").Delete(&domain.SyncCheckpoint{})
+	})
+	assert.Equal(t, `DELETE FROM "sync_checkpoints" WHERE 1 = 1`, deleteSQL)
+}
+
+// TestSyncRejectQueries verifica el SQL generado para guardar y consultar los elementos rechazados
+func TestSyncRejectQueries(t *testing.T) {
+	db := newDryRunDB(t)
+
+	insertSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		rejects := []domain.SyncReject{{Iteration: 2, Error: "error convirtiendo target_to", RawItem: map[string]interface{}{"target_to": "n/a"}}}
+		return tx.Create(&rejects)
+	})
+	assert.Contains(t, insertSQL, `INSERT INTO "sync_rejects"`)
+	assert.Contains(t, insertSQL, `{"target_to":"n/a"}`, "El elemento original debe serializarse como JSON")
+
+	selectSQL := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var rejects []domain.SyncReject
+		return tx.Model(&domain.SyncReject{}).Order("id DESC").Offset(10).Limit(10).Find(&rejects)
+	})
+	assert.Contains(t, selectSQL, `FROM "sync_rejects" ORDER BY id DESC LIMIT 10 OFFSET 10`)
+}
@@ -0,0 +1,50 @@
+package stocks
+
+import (
+	"errors"
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CreateSyncJob registra un nuevo job de sincronización.
+func (r *repository) CreateSyncJob(job *domain.SyncJob) error {
+	if err := r.db.Create(job).Error; err != nil {
+		log.Printf("Error creando job de sincronización: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SaveSyncJob actualiza el estado y el progreso de un job de sincronización.
+func (r *repository) SaveSyncJob(job *domain.SyncJob) error {
+	if err := r.db.Save(job).Error; err != nil {
+		log.Printf("Error actualizando job de sincronización %d: %v", job.ID, err)
+		return err
+	}
+	return nil
+}
+
+// GetSyncJob obtiene un job de sincronización por su ID.
+func (r *repository) GetSyncJob(id int64) (domain.SyncJob, error) {
+	var job domain.SyncJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.SyncJob{}, domain.ErrNotFound
+		}
+		log.Printf("Error obteniendo job de sincronización %d: %v", id, err)
+		return domain.SyncJob{}, err
+	}
+	return job, nil
+}
+
+// FindSyncJobsByState obtiene los jobs en alguno de los estados indicados, del más antiguo al más reciente.
+func (r *repository) FindSyncJobsByState(states ...string) ([]domain.SyncJob, error) {
+	var jobs []domain.SyncJob
+	if err := r.db.Where("state IN ?", states).Order("id ASC").Find(&jobs).Error; err != nil {
+		log.Printf("Error obteniendo jobs de sincronización: %v", err)
+		return nil, err
+	}
+	return jobs, nil
+}
@@ -0,0 +1,45 @@
+package stocks
+
+import (
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// SaveSyncRejects registra elementos rechazados fuera de una sincronización, como las filas de una importación.
+func (r *repository) SaveSyncRejects(rejects []domain.SyncReject) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+	if err := r.db.CreateInBatches(&rejects, batchSize).Error; err != nil {
+		log.Printf("Error guardando elementos rechazados: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetSyncRejects obtiene los elementos rechazados durante las sincronizaciones, del más reciente al más antiguo.
+func (r *repository) GetSyncRejects(page, size int) ([]domain.SyncReject, int64, error) {
+	var rejects []domain.SyncReject
+	var total int64
+
+	dbQuery := r.db.Model(&domain.SyncReject{})
+
+	// Contamos el total de registros sin paginar
+	if err := dbQuery.Count(&total).Error; err != nil {
+		log.Printf("Error contando elementos rechazados: %v", err)
+		return nil, 0, err
+	}
+
+	// Aplicamos orden y paginación
+	if err := dbQuery.
+		Order("id DESC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&rejects).Error; err != nil {
+		log.Printf("Error obteniendo elementos rechazados: %v", err)
+		return nil, 0, err
+	}
+
+	return rejects, total, nil
+}
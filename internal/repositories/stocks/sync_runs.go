@@ -0,0 +1,100 @@
+package stocks
+
+import (
+	"errors"
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CreateSyncRun registra el inicio de una ejecución de la sincronización.
+func (r *repository) CreateSyncRun(run *domain.SyncRun) error {
+	if err := r.db.Create(run).Error; err != nil {
+		log.Printf("Error registrando ejecución de sincronización: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SaveSyncRun actualiza una ejecución de la sincronización.
+func (r *repository) SaveSyncRun(run *domain.SyncRun) error {
+	if err := r.db.Save(run).Error; err != nil {
+		log.Printf("Error actualizando ejecución de sincronización %d: %v", run.ID, err)
+		return err
+	}
+	return nil
+}
+
+// GetSyncRuns obtiene las ejecuciones de la sincronización, de la más reciente a la más antigua.
+func (r *repository) GetSyncRuns(page, size int) ([]domain.SyncRun, int64, error) {
+	var runs []domain.SyncRun
+	var total int64
+
+	dbQuery := r.db.Model(&domain.SyncRun{})
+
+	if err := dbQuery.Count(&total).Error; err != nil {
+		log.Printf("Error contando ejecuciones de sincronización: %v", err)
+		return nil, 0, err
+	}
+
+	if err := dbQuery.
+		Order("id DESC").
+		Offset((page - 1) * size).
+		Limit(size).
+		Find(&runs).Error; err != nil {
+		log.Printf("Error obteniendo ejecuciones de sincronización: %v", err)
+		return nil, 0, err
+	}
+
+	return runs, total, nil
+}
+
+// GetSyncRun obtiene una ejecución de la sincronización por su ID.
+func (r *repository) GetSyncRun(id int64) (domain.SyncRun, error) {
+	var run domain.SyncRun
+	if err := r.db.First(&run, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.SyncRun{}, domain.ErrNotFound
+		}
+		log.Printf("Error obteniendo ejecución de sincronización %d: %v", id, err)
+		return domain.SyncRun{}, err
+	}
+	return run, nil
+}
+
+// GetPreviousSyncRunID obtiene el ID de la última sincronización completa anterior a runID que registró historial
+// de calificaciones. Se omiten las importaciones, las ejecuciones fallidas y las que se detuvieron por el límite de
+// páginas, porque no observaron todos los tickers de la fuente. Devuelve 0 si no existe.
+func (r *repository) GetPreviousSyncRunID(runID int64) (int64, error) {
+	var previous *int64
+	if err := r.buildPreviousSyncRunQuery(runID).Scan(&previous).Error; err != nil {
+		log.Printf("Error obteniendo la ejecución anterior a %d: %v", runID, err)
+		return 0, err
+	}
+	if previous == nil {
+		return 0, nil
+	}
+	return *previous, nil
+}
+
+// GetSyncRunRatings obtiene las acciones observadas por una ejecución de la sincronización.
+func (r *repository) GetSyncRunRatings(runID int64) ([]domain.StockRatingHistory, error) {
+	var history []domain.StockRatingHistory
+	if err := r.db.Where("sync_run_id = ?", runID).Order("id ASC").Find(&history).Error; err != nil {
+		log.Printf("Error obteniendo el historial de la ejecución %d: %v", runID, err)
+		return nil, err
+	}
+	return history, nil
+}
+
+// buildPreviousSyncRunQuery construye la consulta de la sincronización completa anterior con historial
+func (r *repository) buildPreviousSyncRunQuery(runID int64) *gorm.DB {
+	return r.db.Model(&domain.StockRatingHistory{}).
+		Select("MAX(stock_rating_history.sync_run_id)").
+		Joins("JOIN sync_runs ON sync_runs.id = stock_rating_history.sync_run_id").
+		Where("stock_rating_history.sync_run_id < ?", runID).
+		Where("sync_runs.source = ?", domain.SyncRunSourceSync).
+		Where("sync_runs.finished_at IS NOT NULL AND COALESCE(sync_runs.error, '') = ''").
+		Where("sync_runs.stop_reason IN ?", []string{domain.SyncStopEmptyNextPage, domain.SyncStopCycle})
+}
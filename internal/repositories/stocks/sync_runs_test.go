@@ -0,0 +1,29 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestPreviousSyncRunQuery verifica que la ejecución anterior se busque solo entre las sincronizaciones completas
+// que registraron historial: con una importación (5) y una sincronización fallida (6) entre las sincronizaciones
+// 4 y 7, el diff de la 7 debe compararse con la 4
+func TestPreviousSyncRunQuery(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var previous *int64
+		return (&repository{db: tx}).buildPreviousSyncRunQuery(7).Scan(&previous)
+	})
+
+	assert.Contains(t, sql, `SELECT MAX(stock_rating_history.sync_run_id) FROM "stock_rating_history" `+
+		`JOIN sync_runs ON sync_runs.id = stock_rating_history.sync_run_id WHERE stock_rating_history.sync_run_id < 7`)
+	// Descarta la importación
+	assert.Contains(t, sql, `AND sync_runs.source = 'sync'`)
+	// Descarta la ejecución fallida o sin terminar
+	assert.Contains(t, sql, `AND (sync_runs.finished_at IS NOT NULL AND COALESCE(sync_runs.error, '') = '')`)
+	// Descarta las ejecuciones parciales que se detuvieron por el límite
+	assert.Contains(t, sql, `AND sync_runs.stop_reason IN ('empty_next_page','cycle')`)
+}
@@ -1,11 +1,15 @@
 package stocks
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 // MockSyncDatabase simula un repositorio para pruebas de sincronización
@@ -13,15 +17,15 @@ type MockSyncDatabase struct {
 	mock.Mock
 }
 
-// ReplaceAllStocks simula el reemplazo de stocks en la base de datos
-func (m *MockSyncDatabase) ReplaceAllStocks(stocks []domain.Stock) error {
-	args := m.Called(stocks)
-	return args.Error(0)
+// UpsertStocks simula la sincronización de stocks en la base de datos
+func (m *MockSyncDatabase) UpsertStocks(ctx context.Context, stocks []domain.Stock, removeMissing bool, runID int64) (domain.SyncResult, error) {
+	args := m.Called(stocks, removeMissing, runID)
+	return args.Get(0).(domain.SyncResult), args.Error(1)
 }
 
 // GetStocks es un método adicional para cumplir con la interfaz completa
-func (m *MockSyncDatabase) GetStocks(query string, minTargetTo, maxTargetTo float64, currency string, page, size int) ([]domain.Stock, int64, error) {
-	args := m.Called(query, minTargetTo, maxTargetTo, currency, page, size)
+func (m *MockSyncDatabase) GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]domain.Stock), args.Get(1).(int64), args.Error(2)
 }
 
@@ -37,6 +41,7 @@ var syncTestStocks = []domain.Stock{
 		TargetFrom: 150.0,
 		TargetTo:   180.0,
 		Currency:   "USD",
+		RatedAt:    time.Date(2025, 1, 13, 0, 30, 5, 0, time.UTC),
 	},
 	{
 		Ticker:     "GOOGL",
@@ -48,47 +53,32 @@ var syncTestStocks = []domain.Stock{
 		TargetFrom: 2000.0,
 		TargetTo:   2200.0,
 		Currency:   "USD",
+		RatedAt:    time.Date(2025, 1, 14, 0, 30, 5, 0, time.UTC),
 	},
 }
 
-// TestReplaceAllStocks_Success prueba el reemplazo exitoso de stocks
-func TestReplaceAllStocks_Success(t *testing.T) {
+// TestUpsertStocks_Success prueba la sincronización exitosa de stocks
+func TestUpsertStocks_Success(t *testing.T) {
 	// Crear mock de base de datos
 	mockDB := new(MockSyncDatabase)
 
 	// Configurar expectativas
-	mockDB.On("ReplaceAllStocks", syncTestStocks).Return(nil)
+	expected := domain.SyncResult{Inserted: 1, Updated: 1}
+	mockDB.On("UpsertStocks", syncTestStocks, true, int64(1)).Return(expected, nil)
 
-	// Ejecutar reemplazo de stocks
-	err := mockDB.ReplaceAllStocks(syncTestStocks)
+	// Ejecutar sincronización de stocks
+	result, err := mockDB.UpsertStocks(context.Background(), syncTestStocks, true, 1)
 
 	// Verificaciones
 	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
 
 	// Verificar que se llamó al método con los parámetros esperados
 	mockDB.AssertExpectations(t)
 }
 
-// TestReplaceAllStocks_EmptyList prueba el reemplazo con lista vacía
-func TestReplaceAllStocks_EmptyList(t *testing.T) {
-	// Crear mock de base de datos
-	mockDB := new(MockSyncDatabase)
-
-	// Configurar expectativas
-	mockDB.On("ReplaceAllStocks", []domain.Stock{}).Return(nil)
-
-	// Ejecutar reemplazo de stocks con lista vacía
-	err := mockDB.ReplaceAllStocks([]domain.Stock{})
-
-	// Verificaciones
-	assert.NoError(t, err)
-
-	// Verificar que se llamó al método con lista vacía
-	mockDB.AssertExpectations(t)
-}
-
-// TestReplaceAllStocks_DatabaseError prueba el manejo de errores de base de datos
-func TestReplaceAllStocks_DatabaseError(t *testing.T) {
+// TestUpsertStocks_DatabaseError prueba el manejo de errores de base de datos
+func TestUpsertStocks_DatabaseError(t *testing.T) {
 	// Crear mock de base de datos
 	mockDB := new(MockSyncDatabase)
 
@@ -96,10 +86,10 @@ func TestReplaceAllStocks_DatabaseError(t *testing.T) {
 	databaseError := assert.AnError
 
 	// Configurar expectativas con error
-	mockDB.On("ReplaceAllStocks", syncTestStocks).Return(databaseError)
+	mockDB.On("UpsertStocks", syncTestStocks, false, int64(1)).Return(domain.SyncResult{}, databaseError)
 
-	// Ejecutar reemplazo de stocks
-	err := mockDB.ReplaceAllStocks(syncTestStocks)
+	// Ejecutar sincronización de stocks
+	_, err := mockDB.UpsertStocks(context.Background(), syncTestStocks, false, 1)
 
 	// Verificaciones
 	assert.Error(t, err)
@@ -108,3 +98,87 @@ func TestReplaceAllStocks_DatabaseError(t *testing.T) {
 	// Verificar que se llamó al método con los parámetros esperados
 	mockDB.AssertExpectations(t)
 }
+
+// TestDedupeByNaturalKey verifica que se conserve la última aparición de cada clave
+func TestDedupeByNaturalKey(t *testing.T) {
+	updated := syncTestStocks[0]
+	updated.TargetTo = 200.0
+
+	stocks := []domain.Stock{syncTestStocks[0], syncTestStocks[1], updated}
+
+	result := dedupeByNaturalKey(stocks)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "AAPL", result[0].Ticker)
+	assert.Equal(t, 200.0, result[0].TargetTo, "Debe conservarse la última versión del stock")
+	assert.Equal(t, "GOOGL", result[1].Ticker)
+	assert.NotEmpty(t, result[0].NaturalKey)
+	assert.NotEqual(t, result[0].NaturalKey, result[1].NaturalKey)
+}
+
+// TestDedupeByNaturalKey_SeparateRatings verifica que las calificaciones de un ticker por el mismo brokerage
+// en distintas fechas, o sin fecha y con distinto contenido, no se combinen en un solo stock
+func TestDedupeByNaturalKey_SeparateRatings(t *testing.T) {
+	later := syncTestStocks[0]
+	later.RatedAt = later.RatedAt.Add(24 * time.Hour)
+	later.RatingTo = "Strong-Buy"
+
+	undated := syncTestStocks[0]
+	undated.RatedAt = time.Time{}
+	otherUndated := undated
+	otherUndated.Action = "target raised by"
+
+	result := dedupeByNaturalKey([]domain.Stock{syncTestStocks[0], later, undated, otherUndated, undated})
+
+	assert.Len(t, result, 4)
+	assert.Equal(t, "Buy", result[0].RatingTo)
+	assert.Equal(t, "Strong-Buy", result[1].RatingTo)
+	assert.NotEqual(t, result[2].NaturalKey, result[3].NaturalKey)
+}
+
+// TestChunkKeys verifica la división de claves en lotes
+func TestChunkKeys(t *testing.T) {
+	keys := make([]string, keyChunkSize*2+1)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+
+	chunks := chunkKeys(keys)
+
+	assert.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], keyChunkSize)
+	assert.Len(t, chunks[2], 1)
+	assert.Empty(t, chunkKeys(nil))
+}
+
+// TestClassifyStocks verifica la clasificación de stocks nuevos, modificados y sin cambios
+func TestClassifyStocks(t *testing.T) {
+	stocks := dedupeByNaturalKey(syncTestStocks)
+	existing := map[string]domain.Stock{
+		stocks[1].NaturalKey: stocks[1],
+	}
+
+	var changes []string
+	result, toWrite := classifyStocks(stocks, existing, func(change string, stock domain.Stock) {
+		changes = append(changes, change+":"+stock.Ticker)
+	})
+
+	assert.Equal(t, len(stocks)-1, result.Inserted)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Len(t, toWrite, len(stocks)-1)
+	assert.Contains(t, changes, "insert:AAPL")
+	assert.NotContains(t, changes, "insert:GOOGL")
+}
+
+// TestRemoveMissingQuery verifica que se eliminen los stocks no observados por la ejecución
+func TestRemoveMissingQuery(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return (&repository{db: tx}).buildRemoveMissingQuery(7).Delete(&domain.Stock{})
+	})
+
+	assert.Contains(t, sql, `UPDATE "stocks" SET "deleted_at"=`)
+	assert.Contains(t, sql, `(natural_key IS NULL OR natural_key NOT IN (SELECT "natural_key" FROM "stock_rating_history" WHERE sync_run_id = 7))`)
+	assert.Contains(t, sql, `"stocks"."deleted_at" IS NULL`)
+}
@@ -0,0 +1,107 @@
+package stocks
+
+import (
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"gorm.io/gorm"
+)
+
+// GetTickerSummaries obtiene el resumen de consenso por ticker de los stocks filtrados,
+// agregando en la base de datos y aplicando paginación sobre los tickers.
+func (r *repository) GetTickerSummaries(filter domain.StockFilter, buckets domain.RatingBuckets) ([]domain.TickerSummary, int64, error) {
+	var summaries []domain.TickerSummary
+	var total int64
+
+	// Contamos los tickers distintos sin paginar
+	if err := r.buildBaseQuery(filter).Distinct("ticker").Count(&total).Error; err != nil {
+		log.Printf("Error contando tickers: %v", err)
+		return nil, 0, err
+	}
+
+	dbQuery := r.buildTickerQuery(filter, buckets)
+
+	// Ordenamos por puntaje promedio o por la calificación más reciente; el ticker desempata
+	if filter.Recommends {
+		dbQuery = dbQuery.Order("score DESC")
+	}
+	if filter.Recent {
+		dbQuery = dbQuery.Order("last_rated_at DESC")
+	}
+
+	offset := (filter.Page - 1) * filter.Size
+	if err := dbQuery.
+		Order("ticker ASC").
+		Offset(offset).
+		Limit(filter.Size).
+		Scan(&summaries).Error; err != nil {
+		log.Printf("Error obteniendo resumen de tickers: %v", err)
+		return nil, 0, err
+	}
+
+	for i := range summaries {
+		completeTickerSummary(&summaries[i])
+	}
+
+	return summaries, total, nil
+}
+
+// GetTickerSummary obtiene el resumen de consenso de un ticker con los filtros indicados.
+func (r *repository) GetTickerSummary(ticker string, filter domain.StockFilter, buckets domain.RatingBuckets) (domain.TickerSummary, error) {
+	var summaries []domain.TickerSummary
+	if err := r.buildTickerQuery(filter, buckets).
+		Where("ticker = ?", ticker).
+		Scan(&summaries).Error; err != nil {
+		log.Printf("Error obteniendo resumen del ticker %s: %v", ticker, err)
+		return domain.TickerSummary{}, err
+	}
+
+	if len(summaries) == 0 {
+		return domain.TickerSummary{}, domain.ErrNotFound
+	}
+
+	completeTickerSummary(&summaries[0])
+	return summaries[0], nil
+}
+
+// GetTickerStocks obtiene las acciones de los brokerages sobre un ticker, de la más reciente a la más antigua.
+func (r *repository) GetTickerStocks(ticker string, filter domain.StockFilter) ([]domain.Stock, error) {
+	var stocks []domain.Stock
+	if err := r.buildBaseQuery(filter).
+		Where("ticker = ?", ticker).
+		Order("rated_at DESC").
+		Order("id DESC").
+		Find(&stocks).Error; err != nil {
+		log.Printf("Error obteniendo stocks del ticker %s: %v", ticker, err)
+		return nil, err
+	}
+	return stocks, nil
+}
+
+// buildTickerQuery construye la consulta de agregación por ticker sobre la consulta base filtrada
+func (r *repository) buildTickerQuery(filter domain.StockFilter, buckets domain.RatingBuckets) *gorm.DB {
+	return r.buildBaseQuery(filter).
+		Select(`ticker,
+			MAX(company) AS company,
+			COUNT(DISTINCT brokerage) AS brokerages,
+			COUNT(*) AS actions,
+			AVG(target_to) AS target_avg,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY target_to) AS target_median,
+			MIN(target_to) AS target_min,
+			MAX(target_to) AS target_max,
+			COUNT(*) FILTER (WHERE LOWER(TRIM(rating_to)) IN ?) AS rating_buy,
+			COUNT(*) FILTER (WHERE LOWER(TRIM(rating_to)) IN ?) AS rating_hold,
+			COUNT(*) FILTER (WHERE LOWER(TRIM(rating_to)) IN ?) AS rating_sell,
+			COUNT(*) FILTER (WHERE action ILIKE 'upgraded%') AS upgrades,
+			COUNT(*) FILTER (WHERE action ILIKE 'downgraded%') AS downgrades,
+			AVG(recommend_score) AS score,
+			MAX(rated_at) AS last_rated_at`,
+			buckets.Buy, buckets.Hold, buckets.Sell).
+		Group("ticker")
+}
+
+// completeTickerSummary calcula los campos derivados del resumen de un ticker
+func completeTickerSummary(summary *domain.TickerSummary) {
+	summary.NetUpgrades = summary.Upgrades - summary.Downgrades
+	summary.Ratings.Other = summary.Actions - summary.Ratings.Buy - summary.Ratings.Hold - summary.Ratings.Sell
+}
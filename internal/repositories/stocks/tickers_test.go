@@ -0,0 +1,57 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestTickerQuery verifica el SQL de agregación por ticker
+func TestTickerQuery(t *testing.T) {
+	db := newDryRunDB(t)
+	buckets := domain.RatingBuckets{Buy: []string{"buy", "outperform"}, Sell: []string{"sell"}}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var summaries []domain.TickerSummary
+		return (&repository{db: tx}).buildTickerQuery(domain.StockFilter{Currency: "USD"}, buckets).
+			Order("score DESC").
+			Scan(&summaries)
+	})
+
+	assert.Contains(t, sql, "COUNT(DISTINCT brokerage) AS brokerages")
+	assert.Contains(t, sql, "PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY target_to) AS target_median")
+	assert.Contains(t, sql, "COUNT(*) FILTER (WHERE LOWER(TRIM(rating_to)) IN ('buy','outperform')) AS rating_buy")
+	assert.Contains(t, sql, "COUNT(*) FILTER (WHERE LOWER(TRIM(rating_to)) IN (NULL)) AS rating_hold", "Una categoría vacía no debe contar filas")
+	assert.Contains(t, sql, "COUNT(*) FILTER (WHERE action ILIKE 'upgraded%') AS upgrades")
+	assert.Contains(t, sql, `AND currency = 'USD'`)
+	assert.Contains(t, sql, `GROUP BY "ticker" ORDER BY score DESC`)
+}
+
+// TestTickerCountQuery verifica que el total cuente tickers distintos
+func TestTickerCountQuery(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var total int64
+		return (&repository{db: tx}).buildBaseQuery(domain.StockFilter{}).Distinct("ticker").Count(&total)
+	})
+
+	assert.Contains(t, sql, `SELECT COUNT(DISTINCT("ticker")) FROM "stocks"`)
+}
+
+// TestCompleteTickerSummary verifica el cálculo de upgrades netos y calificaciones no reconocidas
+func TestCompleteTickerSummary(t *testing.T) {
+	summary := domain.TickerSummary{
+		Actions:    10,
+		Ratings:    domain.RatingDistribution{Buy: 5, Hold: 2, Sell: 1},
+		Upgrades:   3,
+		Downgrades: 4,
+	}
+
+	completeTickerSummary(&summary)
+
+	assert.Equal(t, -1, summary.NetUpgrades)
+	assert.Equal(t, 2, summary.Ratings.Other)
+}
@@ -0,0 +1,229 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule calcula el siguiente instante de ejecución a partir de un momento dado.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule interpreta una programación de sincronización. Acepta:
+//   - intervalos: "30m", "@every 1h30m"
+//   - descriptores: "@hourly", "@daily", "@midnight", "@weekly", "@monthly"
+//   - expresiones cron de 5 campos: "minuto hora día-mes mes día-semana"
+func ParseSchedule(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("la programación no puede estar vacía")
+	}
+
+	// Intervalo con prefijo @every
+	if strings.HasPrefix(expr, "@every ") {
+		return parseInterval(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+	}
+
+	// Descriptores predefinidos
+	if strings.HasPrefix(expr, "@") {
+		descriptor, ok := cronDescriptors[expr]
+		if !ok {
+			return nil, fmt.Errorf("descriptor de programación desconocido: %s", expr)
+		}
+		return parseCron(descriptor)
+	}
+
+	// Intervalo simple en formato de duración de Go
+	if !strings.Contains(expr, " ") {
+		return parseInterval(expr)
+	}
+
+	return parseCron(expr)
+}
+
+// cronDescriptors traduce los descriptores predefinidos a expresiones cron
+var cronDescriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// intervalSchedule ejecuta la tarea cada cierto intervalo fijo
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// parseInterval interpreta una duración como intervalo de ejecución
+func parseInterval(value string) (Schedule, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("intervalo inválido %q: %w", value, err)
+	}
+	if interval < time.Minute {
+		return nil, fmt.Errorf("el intervalo debe ser de al menos 1 minuto")
+	}
+	return intervalSchedule{interval: interval}, nil
+}
+
+// Next implementa Schedule.Next
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// cronSchedule representa una expresión cron de 5 campos
+type cronSchedule struct {
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+
+	// Indican si día del mes y día de la semana fueron restringidos (semántica estándar de cron)
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+// cronField describe los límites de cada campo de una expresión cron
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minuto", 0, 59},
+	{"hora", 0, 23},
+	{"día del mes", 1, 31},
+	{"mes", 1, 12},
+	{"día de la semana", 0, 7},
+}
+
+// parseCron interpreta una expresión cron de 5 campos
+func parseCron(expr string) (Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("la expresión cron debe tener %d campos: %q", len(cronFields), expr)
+	}
+
+	values := make([]map[int]bool, len(cronFields))
+	for i, field := range cronFields {
+		parsed, err := parseCronField(parts[i], field)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = parsed
+	}
+
+	// El domingo puede expresarse como 0 o 7
+	if values[4][7] {
+		values[4][0] = true
+		delete(values[4], 7)
+	}
+
+	return &cronSchedule{
+		minutes:            values[0],
+		hours:              values[1],
+		days:               values[2],
+		months:             values[3],
+		weekdays:           values[4],
+		daysRestricted:     parts[2] != "*",
+		weekdaysRestricted: parts[4] != "*",
+	}, nil
+}
+
+// parseCronField interpreta un campo cron con soporte para *, rangos, listas y pasos
+func parseCronField(value string, field cronField) (map[int]bool, error) {
+	result := make(map[int]bool)
+
+	for _, item := range strings.Split(value, ",") {
+		// Separar el paso (*/n, a-b/n, a/n)
+		step := 1
+		hasStep := false
+		if base, stepStr, found := strings.Cut(item, "/"); found {
+			parsedStep, err := strconv.Atoi(stepStr)
+			if err != nil || parsedStep <= 0 {
+				return nil, fmt.Errorf("paso inválido en %s: %q", field.name, item)
+			}
+			step = parsedStep
+			hasStep = true
+			item = base
+		}
+
+		// Determinar el rango
+		start, end := field.min, field.max
+		if item != "*" {
+			startStr, endStr, isRange := strings.Cut(item, "-")
+			var err error
+			if start, err = strconv.Atoi(startStr); err != nil {
+				return nil, fmt.Errorf("valor inválido en %s: %q", field.name, item)
+			}
+			// Un valor único con paso equivale al rango hasta el máximo del campo (5/10 es 5-59/10 en minutos)
+			end = start
+			if hasStep && !isRange {
+				end = field.max
+			}
+			if isRange {
+				if end, err = strconv.Atoi(endStr); err != nil {
+					return nil, fmt.Errorf("valor inválido en %s: %q", field.name, item)
+				}
+			}
+		}
+
+		if start < field.min || end > field.max || start > end {
+			return nil, fmt.Errorf("valor fuera de rango en %s: %q", field.name, item)
+		}
+
+		for v := start; v <= end; v += step {
+			result[v] = true
+		}
+	}
+
+	return result, nil
+}
+
+// maxCronSearch limita la búsqueda del siguiente instante para expresiones imposibles (ej. 30 de febrero)
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// Next implementa Schedule.Next buscando el siguiente minuto que cumpla la expresión
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronSearch)
+
+	for next.Before(limit) {
+		if !s.months[int(next.Month())] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.hours[next.Hour()] {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.minutes[next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+
+	return time.Time{}
+}
+
+// matchesDay aplica la semántica estándar de cron: si ambos campos de día están
+// restringidos, basta con que coincida cualquiera de ellos.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dayMatch := s.days[t.Day()]
+	weekdayMatch := s.weekdays[int(t.Weekday())]
+
+	if s.daysRestricted && s.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
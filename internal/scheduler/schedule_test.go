@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// base es un instante de referencia: miércoles 15 de enero de 2025, 10:17:30 UTC
+var base = time.Date(2025, time.January, 15, 10, 17, 30, 0, time.UTC)
+
+// TestParseSchedule_Next verifica el siguiente instante calculado para distintas programaciones
+func TestParseSchedule_Next(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{"Intervalo simple", "30m", base.Add(30 * time.Minute)},
+		{"Intervalo con @every", "@every 2h", base.Add(2 * time.Hour)},
+		{"Cada minuto", "* * * * *", time.Date(2025, 1, 15, 10, 18, 0, 0, time.UTC)},
+		{"Cada 15 minutos", "*/15 * * * *", time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"Lista de horas", "0 6,18 * * *", time.Date(2025, 1, 15, 18, 0, 0, 0, time.UTC)},
+		{"Rango de horas", "0 2-4 * * *", time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{"Paso desde un minuto", "5/10 * * * *", time.Date(2025, 1, 15, 10, 25, 0, 0, time.UTC)},
+		{"Paso desde una hora", "0 1/6 * * *", time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC)},
+		{"Descriptor diario", "@daily", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"Descriptor mensual", "@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"Domingo como 7", "0 0 * * 7", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"Días hábiles", "30 9 * * 1-5", time.Date(2025, 1, 16, 9, 30, 0, 0, time.UTC)},
+		{"Día del mes o de la semana", "0 0 20 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.expr)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, schedule.Next(base))
+		})
+	}
+}
+
+// TestParseCronField_Step verifica que un valor único con paso abarque hasta el máximo del campo
+func TestParseCronField_Step(t *testing.T) {
+	values, err := parseCronField("5/10", cronFields[0])
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]bool{5: true, 15: true, 25: true, 35: true, 45: true, 55: true}, values)
+
+	// Sin paso, un valor único sigue siendo un solo valor
+	values, err = parseCronField("5", cronFields[0])
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]bool{5: true}, values)
+}
+
+// TestParseSchedule_Invalid verifica el rechazo de programaciones inválidas
+func TestParseSchedule_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"10s",
+		"@yearly-ish",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every nunca",
+	}
+
+	for _, expr := range invalid {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseSchedule(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestCronSchedule_Impossible verifica que una fecha imposible no tenga próxima ejecución
+func TestCronSchedule_Impossible(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 30 2 *")
+
+	assert.NoError(t, err)
+	assert.True(t, schedule.Next(base).IsZero())
+}
@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/stocks"
+	"go.uber.org/fx"
+)
+
+// Module registra el programador de sincronizaciones periódicas.
+var Module = fx.Module("scheduler", fx.Invoke(New))
+
+// syncer es la parte del servicio de stocks que necesita el programador
+type syncer interface {
+	SyncStocks(ctx context.Context, opts domain.SyncOptions) (domain.SyncResult, error)
+}
+
+// Scheduler ejecuta sincronizaciones de stocks según la programación configurada.
+type Scheduler struct {
+	schedule Schedule
+	syncer   syncer
+	limit    int
+
+	// running evita que se solapen dos ejecuciones programadas
+	running atomic.Bool
+
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+// New crea el programador y lo registra en el ciclo de vida de la aplicación.
+// Si SYNC_SCHEDULE está vacío, el programador queda deshabilitado.
+func New(lc fx.Lifecycle, cfg *config.Config, service stocks.Service) (*Scheduler, error) {
+	if cfg.SyncSchedule == "" {
+		log.Println("ℹ️ Sincronización programada deshabilitada (SYNC_SCHEDULE vacío)")
+		return nil, nil
+	}
+
+	schedule, err := ParseSchedule(cfg.SyncSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scheduler{
+		schedule: schedule,
+		syncer:   service,
+		limit:    cfg.SyncScheduleLimit,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			s.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+
+	return s, nil
+}
+
+// Start inicia el ciclo de ejecución programada en segundo plano.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stop = cancel
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop detiene el programador y espera a que termine la sincronización en curso.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.stop == nil {
+		return nil
+	}
+
+	log.Println("🛑 Deteniendo sincronización programada...")
+	s.stop()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run espera hasta cada instante programado y lanza la sincronización
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		next := s.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Println("⚠️ La programación no tiene próximas ejecuciones. Deteniendo programador.")
+			return
+		}
+		log.Printf("⏰ Próxima sincronización programada: %s", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.trigger(ctx)
+		}
+	}
+}
+
+// trigger lanza una sincronización en segundo plano salvo que ya haya una en curso.
+// Devuelve false si la ejecución se omitió.
+func (s *Scheduler) trigger(ctx context.Context) bool {
+	if !s.running.CompareAndSwap(false, true) {
+		log.Println("⏭️ Sincronización programada omitida: la anterior sigue en curso")
+		return false
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.running.Store(false)
+		s.runSync(ctx)
+	}()
+
+	return true
+}
+
+// runSync ejecuta una sincronización y registra su resultado
+func (s *Scheduler) runSync(ctx context.Context) {
+	log.Printf("🔄 Ejecutando sincronización programada (limit=%d)", s.limit)
+
+	result, err := s.syncer.SyncStocks(ctx, domain.SyncOptions{Limit: s.limit})
+	if err != nil {
+		log.Printf("❌ Error en la sincronización programada: %v", err)
+		return
+	}
+
+	log.Printf("✅ Sincronización programada completada: %d insertados, %d actualizados, %d sin cambios, %d eliminados",
+		result.Inserted, result.Updated, result.Unchanged, result.Removed)
+}
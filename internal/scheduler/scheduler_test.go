@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingSyncer simula una sincronización que no termina hasta que se libera
+type blockingSyncer struct {
+	started chan int
+	release chan struct{}
+}
+
+// SyncStocks registra el inicio y espera a ser liberado o a la cancelación del contexto
+func (b *blockingSyncer) SyncStocks(ctx context.Context, opts domain.SyncOptions) (domain.SyncResult, error) {
+	b.started <- opts.Limit
+	select {
+	case <-b.release:
+		return domain.SyncResult{Inserted: 1}, nil
+	case <-ctx.Done():
+		return domain.SyncResult{}, ctx.Err()
+	}
+}
+
+// newBlockingSyncer crea un syncer de prueba
+func newBlockingSyncer() *blockingSyncer {
+	return &blockingSyncer{
+		started: make(chan int, 1),
+		release: make(chan struct{}),
+	}
+}
+
+// TestTrigger_SkipsOverlappingRuns verifica que no se solapen dos ejecuciones
+func TestTrigger_SkipsOverlappingRuns(t *testing.T) {
+	syncer := newBlockingSyncer()
+	s := &Scheduler{syncer: syncer, limit: 5}
+	ctx := context.Background()
+
+	assert.True(t, s.trigger(ctx))
+	assert.Equal(t, 5, <-syncer.started)
+
+	// Mientras la primera sigue en curso, la segunda se omite
+	assert.False(t, s.trigger(ctx))
+
+	// Al terminar la primera, se permite una nueva ejecución
+	close(syncer.release)
+	s.wg.Wait()
+	assert.True(t, s.trigger(ctx))
+	<-syncer.started
+	s.wg.Wait()
+}
+
+// TestStop_CancelsRunningSync verifica que Stop cancele la sincronización en curso y espere su fin
+func TestStop_CancelsRunningSync(t *testing.T) {
+	syncer := newBlockingSyncer()
+	s := &Scheduler{schedule: intervalSchedule{interval: time.Millisecond}, syncer: syncer, limit: 1}
+
+	// Con un intervalo mínimo, la primera ejecución empieza casi de inmediato
+	s.Start()
+	<-syncer.started
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Second)
+	defer stopCancel()
+
+	assert.NoError(t, s.Stop(stopCtx))
+	assert.False(t, s.running.Load())
+}
@@ -6,12 +6,20 @@ import (
 	"time"
 
 	"github.com/julianloaiza/stock-advisor/config"
+	"golang.org/x/time/rate"
 )
 
 // Client define la interfaz para comunicarse con APIs externas
 type Client interface {
-	// Get realiza una solicitud GET a la API
-	Get(ctx context.Context, path string, params map[string]string) ([]byte, error)
+	// Get realiza una solicitud GET a la API, reintentando los errores transitorios
+	Get(ctx context.Context, path string, params map[string]string) (Response, error)
+}
+
+// Response contiene el cuerpo de una respuesta exitosa y la cantidad de intentos realizados.
+// Attempts también se informa cuando la solicitud falla.
+type Response struct {
+	Body     []byte
+	Attempts int
 }
 
 // client implementa la interfaz Client
@@ -19,6 +27,8 @@ type client struct {
 	httpClient *http.Client
 	baseURL    string
 	authToken  string
+	retry      retryPolicy
+	limiter    *rate.Limiter // Limita la tasa de solicitudes hacia la API (nil si está deshabilitado)
 }
 
 // New crea una nueva instancia de Cliente API basada en la configuración
@@ -29,5 +39,19 @@ func New(cfg *config.Config) Client {
 		},
 		baseURL:   cfg.StockAPIURL,
 		authToken: cfg.StockAuthTkn,
+		retry: retryPolicy{
+			maxRetries: cfg.APIMaxRetries,
+			baseDelay:  time.Duration(cfg.APIRetryBaseDelayMs) * time.Millisecond,
+			maxDelay:   time.Duration(cfg.APIRetryMaxDelayMs) * time.Millisecond,
+		},
+		limiter: newLimiter(cfg.APIRateLimitRPS, cfg.APIRateLimitBurst),
+	}
+}
+
+// newLimiter crea un limitador token bucket; devuelve nil si rps no es positivo
+func newLimiter(rps float64, burst int) *rate.Limiter {
+	if rps <= 0 {
+		return nil
 	}
+	return rate.NewLimiter(rate.Limit(rps), burst)
 }
@@ -0,0 +1,87 @@
+package apiClient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLimiter(t *testing.T) {
+	// Sin tasa configurada no hay limitador
+	assert.Nil(t, newLimiter(0, 1))
+	assert.Nil(t, newLimiter(-1, 1))
+
+	limiter := newLimiter(2.5, 3)
+	assert.NotNil(t, limiter)
+	assert.Equal(t, 2.5, float64(limiter.Limit()))
+	assert.Equal(t, 3, limiter.Burst())
+}
+
+func TestGet_RateLimited(t *testing.T) {
+	server, calls := newStatusSequenceServer()
+	defer server.Close()
+
+	c := newRetryTestClient(server, 0)
+	c.limiter = newLimiter(20, 1)
+
+	// Con 20 req/s y ráfaga 1, cuatro solicitudes requieren al menos 150ms
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		_, err := c.Get(context.Background(), "", nil)
+		assert.NoError(t, err)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+}
+
+func TestGet_RateLimitRespectsContext(t *testing.T) {
+	server, calls := newStatusSequenceServer()
+	defer server.Close()
+
+	c := newRetryTestClient(server, 3)
+	c.limiter = newLimiter(0.1, 1)
+
+	// La primera solicitud consume la ráfaga
+	_, err := c.Get(context.Background(), "", nil)
+	assert.NoError(t, err)
+
+	// La segunda no obtiene cupo antes del vencimiento y no se reintenta
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	response, err := c.Get(ctx, "", nil)
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, errRateLimitWait))
+	assert.Equal(t, 1, response.Attempts)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+// TestGet_RateLimitCanceledContext verifica que no se envíen solicitudes con el contexto cancelado
+func TestGet_RateLimitCanceledContext(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(server, 3)
+	c.limiter = newLimiter(1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.Get(ctx, "", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
@@ -7,13 +7,60 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"time"
 )
 
-// Get implementa la interfaz Client.Get
-func (c *client) Get(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+// Get implementa la interfaz Client.Get.
+// Los errores transitorios se reintentan con backoff exponencial según la política configurada.
+func (c *client) Get(ctx context.Context, path string, params map[string]string) (Response, error) {
 	// Construir URL completa
 	endpoint := c.buildURL(path, params)
 
+	var response Response
+	for {
+		response.Attempts++
+
+		// Ejecutar solicitud
+		body, err := c.doGet(ctx, endpoint)
+		if err == nil {
+			response.Body = body
+			return response, nil
+		}
+
+		// Verificar si quedan reintentos y si el error es transitorio
+		retry := response.Attempts
+		if retry > c.retry.maxRetries || !isRetryable(err) {
+			if response.Attempts > 1 {
+				err = fmt.Errorf("%w (tras %d intentos)", err, response.Attempts)
+			}
+			return response, err
+		}
+
+		// Esperar antes del siguiente intento; no se reintenta si el servidor pide esperar más que la espera
+		// máxima o si el contexto vence antes
+		delay, ok := c.retry.backoff(retry, err)
+		if !ok {
+			return response, fmt.Errorf("%w (reintento omitido: Retry-After de %s supera la espera máxima)", err, delay)
+		}
+		if exceedsDeadline(ctx, delay) {
+			return response, fmt.Errorf("%w (reintento omitido: la espera de %s supera el plazo restante)", err, delay)
+		}
+		log.Printf("API retry: intento=%d espera=%s error=%v", response.Attempts, delay, err)
+		if waitErr := wait(ctx, delay); waitErr != nil {
+			return response, fmt.Errorf("%w (reintento cancelado: %v)", err, waitErr)
+		}
+	}
+}
+
+// doGet crea y ejecuta una solicitud GET sobre la URL indicada,
+// esperando antes un turno del limitador de tasa si está configurado
+func (c *client) doGet(ctx context.Context, endpoint string) ([]byte, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("%w: %v", errRateLimitWait, err)
+		}
+	}
+
 	// Crear la solicitud
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -66,7 +113,7 @@ func (c *client) executeRequest(req *http.Request) ([]byte, error) {
 	// Ejecutar solicitud
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error en solicitud HTTP: %w", err)
+		return nil, &transportError{fmt.Errorf("error en solicitud HTTP: %w", err)}
 	}
 	defer resp.Body.Close()
 
@@ -74,12 +121,20 @@ func (c *client) executeRequest(req *http.Request) ([]byte, error) {
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		log.Printf("API error: status=%d", resp.StatusCode)
-		return nil, fmt.Errorf("status code inesperado: %d, respuesta: %s",
-			resp.StatusCode, string(bodyBytes))
+
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			statusErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		return nil, statusErr
 	}
 
 	log.Printf("API success: status=%d", resp.StatusCode)
 
 	// Leer la respuesta
-	return io.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &transportError{fmt.Errorf("error leyendo respuesta: %w", err)}
+	}
+	return body, nil
 }
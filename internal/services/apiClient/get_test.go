@@ -55,8 +55,8 @@ func TestGet_SuccessResponse(t *testing.T) {
 
 	// Verificar resultados
 	assert.NoError(t, err)
-	assert.NotNil(t, response)
-	assert.Contains(t, string(response), `"success":true`)
+	assert.NotNil(t, response.Body)
+	assert.Contains(t, string(response.Body), `"success":true`)
 }
 
 func TestGet_ErrorResponse(t *testing.T) {
@@ -85,7 +85,7 @@ func TestGet_ErrorResponse(t *testing.T) {
 
 	// Verificar que se devuelva un error
 	assert.Error(t, err)
-	assert.Nil(t, response)
+	assert.Nil(t, response.Body)
 	assert.Contains(t, err.Error(), "status code inesperado: 500")
 }
 
@@ -172,6 +172,6 @@ func TestGet_WithPathAndNoParams(t *testing.T) {
 
 	// Verificar resultados
 	assert.NoError(t, err)
-	assert.NotNil(t, response)
-	assert.Contains(t, string(response), `"success":true`)
+	assert.NotNil(t, response.Body)
+	assert.Contains(t, string(response.Body), `"success":true`)
 }
@@ -0,0 +1,146 @@
+package apiClient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// errRateLimitWait indica que el contexto terminó (o terminaría) antes de obtener cupo del limitador
+var errRateLimitWait = errors.New("no se obtuvo cupo del límite de tasa")
+
+// retryPolicy define cuántas veces y con qué espera se reintentan las solicitudes
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// StatusError representa una respuesta HTTP con un código de estado no exitoso
+type StatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // Espera indicada por el header Retry-After (0 si no viene)
+}
+
+// Error implementa la interfaz error
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status code inesperado: %d, respuesta: %s", e.StatusCode, e.Body)
+}
+
+// transportError envuelve un fallo de red al enviar la solicitud o al leer la respuesta
+type transportError struct {
+	err error
+}
+
+// Error implementa la interfaz error
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap devuelve el error original
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable indica si un error de solicitud es transitorio y puede reintentarse.
+// Los errores de transporte se reintentan salvo que el contexto haya sido cancelado;
+// de los errores HTTP solo se reintentan timeouts, limitación de tasa y fallos del servidor.
+// El resto, como un error al construir la solicitud o la falta de cupo del limitador, no se reintenta.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	switch statusErr.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff calcula la espera antes del reintento indicado (1 para el primero) usando
+// backoff exponencial con jitter. Si el servidor envió Retry-After, se espera como mínimo lo indicado;
+// si supera la espera máxima configurada, devuelve false y no debe reintentarse.
+func (p retryPolicy) backoff(retry int, err error) (time.Duration, bool) {
+	delay := p.baseDelay << (retry - 1)
+	if delay <= 0 || delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+
+	// Jitter: la espera final queda entre la mitad y el total del valor calculado
+	if half := delay / 2; half > 0 {
+		delay = half + rand.N(half+1)
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+		if statusErr.RetryAfter > p.maxDelay {
+			return statusErr.RetryAfter, false
+		}
+		return statusErr.RetryAfter, true
+	}
+
+	return delay, true
+}
+
+// parseRetryAfter interpreta el header Retry-After, expresado en segundos o como fecha HTTP
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// exceedsDeadline indica si el contexto vencerá antes de que termine la espera indicada
+func exceedsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < delay
+}
+
+// wait espera el tiempo indicado o hasta que se cancele el contexto
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,245 @@
+package apiClient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newRetryTestClient crea un cliente contra el servidor de prueba con esperas mínimas
+func newRetryTestClient(server *httptest.Server, maxRetries int) *client {
+	return &client{
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		retry: retryPolicy{
+			maxRetries: maxRetries,
+			baseDelay:  time.Millisecond,
+			maxDelay:   5 * time.Millisecond,
+		},
+	}
+}
+
+// newStatusSequenceServer responde con los códigos indicados en orden y luego con 200
+func newStatusSequenceServer(statuses ...int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1))
+		if call <= len(statuses) {
+			w.WriteHeader(statuses[call-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}))
+	return server, &calls
+}
+
+func TestGet_RetriesTransientErrors(t *testing.T) {
+	server, calls := newStatusSequenceServer(http.StatusBadGateway, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	response, err := newRetryTestClient(server, 3).Get(context.Background(), "", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response.Attempts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Contains(t, string(response.Body), `"success":true`)
+}
+
+func TestGet_RetriesExhausted(t *testing.T) {
+	server, calls := newStatusSequenceServer(http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	defer server.Close()
+
+	response, err := newRetryTestClient(server, 2).Get(context.Background(), "", nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "tras 3 intentos")
+	assert.Equal(t, 3, response.Attempts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+}
+
+func TestGet_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newStatusSequenceServer(http.StatusUnauthorized)
+	defer server.Close()
+
+	response, err := newRetryTestClient(server, 3).Get(context.Background(), "", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, response.Attempts)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestGet_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(server, 1)
+	c.retry.maxDelay = 2 * time.Second
+
+	start := time.Now()
+	response, err := c.Get(context.Background(), "", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.Attempts)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "Debe esperar lo indicado por Retry-After")
+}
+
+func TestGet_GivesUpWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	response, err := newRetryTestClient(server, 1).Get(context.Background(), "", nil)
+
+	assert.ErrorContains(t, err, "reintento omitido")
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, 1, response.Attempts)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "No debe reintentar antes de lo indicado por Retry-After")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestGet_DoesNotRetryRequestErrors(t *testing.T) {
+	// Una URL inválida falla al construir la solicitud, antes de enviarla
+	c := &client{
+		httpClient: http.DefaultClient,
+		baseURL:    "http://[::1",
+		retry:      retryPolicy{maxRetries: 3, baseDelay: time.Millisecond, maxDelay: time.Millisecond},
+	}
+
+	response, err := c.Get(context.Background(), "", nil)
+
+	assert.ErrorContains(t, err, "error creando request GET")
+	assert.Equal(t, 1, response.Attempts)
+}
+
+func TestGet_SkipsRetryBeyondDeadline(t *testing.T) {
+	server, calls := newStatusSequenceServer(http.StatusServiceUnavailable)
+	defer server.Close()
+
+	c := newRetryTestClient(server, 3)
+	c.retry.baseDelay = time.Hour
+	c.retry.maxDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	response, err := c.Get(ctx, "", nil)
+
+	assert.ErrorContains(t, err, "reintento omitido")
+	assert.Equal(t, 1, response.Attempts)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.Less(t, time.Since(start), time.Second, "No debe esperar un reintento que vencería el contexto")
+}
+
+func TestGet_StopsRetryingWhenContextCanceled(t *testing.T) {
+	server, calls := newStatusSequenceServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	c := newRetryTestClient(server, 3)
+	c.retry.baseDelay = time.Hour
+	c.retry.maxDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	response, err := c.Get(ctx, "", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, response.Attempts)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Error de transporte", &transportError{errors.New("connection reset by peer")}, true},
+		{"Transporte con contexto cancelado", &transportError{context.Canceled}, false},
+		{"Error al construir la solicitud", errors.New("error creando request GET"), false},
+		{"Sin cupo del limitador", errRateLimitWait, false},
+		{"Contexto cancelado", context.Canceled, false},
+		{"Timeout del contexto", context.DeadlineExceeded, false},
+		{"Request timeout", &StatusError{StatusCode: http.StatusRequestTimeout}, true},
+		{"Too many requests", &StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"Bad gateway", &StatusError{StatusCode: http.StatusBadGateway}, true},
+		{"Gateway timeout", &StatusError{StatusCode: http.StatusGatewayTimeout}, true},
+		{"Not found", &StatusError{StatusCode: http.StatusNotFound}, false},
+		{"Not implemented", &StatusError{StatusCode: http.StatusNotImplemented}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isRetryable(tc.err))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := retryPolicy{maxRetries: 5, baseDelay: 100 * time.Millisecond, maxDelay: time.Second}
+	err := &StatusError{StatusCode: http.StatusBadGateway}
+
+	// La espera crece exponencialmente con jitter entre la mitad y el total
+	for retry, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+		delay, ok := policy.backoff(retry, err)
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, delay, expected/2)
+		assert.LessOrEqual(t, delay, expected)
+	}
+
+	// Nunca supera la espera máxima
+	delay, _ := policy.backoff(10, err)
+	assert.LessOrEqual(t, delay, time.Second)
+
+	// Retry-After mayor al backoff calculado tiene prioridad y se espera completo
+	limited := &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 800 * time.Millisecond}
+	delay, ok := policy.backoff(1, limited)
+	assert.True(t, ok)
+	assert.Equal(t, 800*time.Millisecond, delay)
+
+	// Si supera la espera máxima no se reintenta
+	limited.RetryAfter = time.Hour
+	_, ok = policy.backoff(1, limited)
+	assert.False(t, ok)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, 2*time.Minute, parseRetryAfter("Wed, 15 Jan 2025 10:02:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Wed, 15 Jan 2025 09:00:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-5", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("pronto", now))
+}
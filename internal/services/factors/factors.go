@@ -0,0 +1,207 @@
+package factors
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	repo "github.com/julianloaiza/stock-advisor/internal/repositories/factors"
+	"go.uber.org/fx"
+)
+
+// Service define las operaciones de administración de los factores de recomendación de empresas y brokerages.
+type Service interface {
+	// GetFactors obtiene todos los factores del tipo indicado.
+	GetFactors(kind string) ([]domain.RecommendationFactor, error)
+
+	// GetFactor obtiene el factor de una empresa (ticker) o de un brokerage (nombre).
+	GetFactor(kind, key string) (domain.RecommendationFactor, error)
+
+	// SaveFactor crea o actualiza un factor y lo aplica al modelo de puntuación vigente.
+	SaveFactor(kind, key string, factor float64) (domain.RecommendationFactor, error)
+
+	// DeleteFactor elimina un factor y lo retira del modelo de puntuación vigente.
+	DeleteFactor(kind, key string) error
+
+	// OnChange registra una función que se invoca cada vez que cambia el modelo de puntuación vigente,
+	// ya sea por un cambio de factores o por una recarga del archivo de factores.
+	OnChange(fn func())
+}
+
+// service implementa la interfaz Service.
+type service struct {
+	repo repo.Repository
+	cfg  *config.Config
+
+	// mu serializa las modificaciones para que el modelo publicado refleje el último estado de la base de datos
+	mu        sync.Mutex
+	listeners []func()
+}
+
+// New crea una nueva instancia del servicio de factores.
+// La primera vez que inicia la aplicación, la tabla se inicializa con los factores de recommendation_factors.json;
+// desde entonces los factores de empresas y brokerages se administran en la base de datos.
+func New(lc fx.Lifecycle, repo repo.Repository, cfg *config.Config, factorsWatcher *config.FactorsWatcher) Service {
+	s := &service{
+		repo: repo,
+		cfg:  cfg,
+	}
+
+	// El archivo recargado conserva los factores administrados; solo se notifica el cambio
+	factorsWatcher.OnChange(func(*config.RecommendationFactors) {
+		s.notify()
+	})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := s.seedFactors(); err != nil {
+				return err
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.applyFactors()
+		},
+	})
+
+	return s
+}
+
+// GetFactors obtiene todos los factores del tipo indicado.
+func (s *service) GetFactors(kind string) ([]domain.RecommendationFactor, error) {
+	return s.repo.GetFactors(kind)
+}
+
+// GetFactor obtiene el factor de una empresa o de un brokerage.
+func (s *service) GetFactor(kind, key string) (domain.RecommendationFactor, error) {
+	return s.repo.GetFactor(kind, normalizeFactorKey(kind, key))
+}
+
+// SaveFactor crea o actualiza un factor y lo aplica al modelo de puntuación vigente.
+func (s *service) SaveFactor(kind, key string, value float64) (domain.RecommendationFactor, error) {
+	factor := domain.RecommendationFactor{
+		Kind:   kind,
+		Key:    normalizeFactorKey(kind, key),
+		Factor: value,
+	}
+	if factor.Key == "" {
+		return domain.RecommendationFactor{}, fmt.Errorf("%w: la clave no puede estar vacía", domain.ErrInvalidFactor)
+	}
+	if factor.Factor <= -100 {
+		return domain.RecommendationFactor{}, fmt.Errorf("%w: el factor debe ser mayor que -100", domain.ErrInvalidFactor)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.repo.SaveFactor(&factor); err != nil {
+		return domain.RecommendationFactor{}, err
+	}
+	if err := s.applyFactors(); err != nil {
+		return domain.RecommendationFactor{}, err
+	}
+
+	log.Printf("Factor de recomendación %s/%s actualizado a %.2f", factor.Kind, factor.Key, factor.Factor)
+	s.notify()
+	return factor, nil
+}
+
+// DeleteFactor elimina un factor y lo retira del modelo de puntuación vigente.
+func (s *service) DeleteFactor(kind, key string) error {
+	key = normalizeFactorKey(kind, key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.repo.DeleteFactor(kind, key); err != nil {
+		return err
+	}
+	if err := s.applyFactors(); err != nil {
+		return err
+	}
+
+	log.Printf("Factor de recomendación %s/%s eliminado", kind, key)
+	s.notify()
+	return nil
+}
+
+// OnChange registra una función que se invoca cada vez que cambia el modelo de puntuación vigente.
+func (s *service) OnChange(fn func()) {
+	s.listeners = append(s.listeners, fn)
+}
+
+// seedFactors inicializa la tabla con los factores de empresas y brokerages del archivo si nunca se inicializó
+func (s *service) seedFactors() error {
+	fileFactors := s.cfg.FileRecommendationFactors()
+
+	seed := make([]domain.RecommendationFactor, 0, len(fileFactors.Companies)+len(fileFactors.Brokerages))
+	for ticker, value := range fileFactors.Companies {
+		seed = append(seed, domain.RecommendationFactor{Kind: domain.FactorKindCompany, Key: normalizeFactorKey(domain.FactorKindCompany, ticker), Factor: value})
+	}
+	for brokerage, value := range fileFactors.Brokerages {
+		seed = append(seed, domain.RecommendationFactor{Kind: domain.FactorKindBrokerage, Key: normalizeFactorKey(domain.FactorKindBrokerage, brokerage), Factor: value})
+	}
+
+	seeded, err := s.repo.SeedFactors(seed)
+	if err != nil {
+		return err
+	}
+	if seeded {
+		log.Printf("✅ Factores de recomendación inicializados desde el archivo: %d empresas y %d brokerages",
+			len(fileFactors.Companies), len(fileFactors.Brokerages))
+	}
+	return nil
+}
+
+// applyFactors publica en el modelo de puntuación vigente los factores guardados en la base de datos.
+// Debe llamarse con mu tomado.
+func (s *service) applyFactors() error {
+	companies, err := s.factorMap(domain.FactorKindCompany)
+	if err != nil {
+		return err
+	}
+	brokerages, err := s.factorMap(domain.FactorKindBrokerage)
+	if err != nil {
+		return err
+	}
+
+	s.cfg.SetFactorOverrides(&config.FactorOverrides{
+		Companies:  companies,
+		Brokerages: brokerages,
+	})
+	return nil
+}
+
+// factorMap obtiene los factores de un tipo como mapa clave -> factor
+func (s *service) factorMap(kind string) (map[string]float64, error) {
+	factors, err := s.repo.GetFactors(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]float64, len(factors))
+	for _, factor := range factors {
+		values[factor.Key] = factor.Factor
+	}
+	return values, nil
+}
+
+// notify invoca las funciones registradas con OnChange
+func (s *service) notify() {
+	for _, fn := range s.listeners {
+		fn()
+	}
+}
+
+// normalizeFactorKey normaliza la clave de un factor: los tickers se comparan en mayúsculas
+// y los nombres de brokerages tal como los publica la fuente externa.
+func normalizeFactorKey(kind, key string) string {
+	key = strings.TrimSpace(key)
+	if kind == domain.FactorKindCompany {
+		return strings.ToUpper(key)
+	}
+	return key
+}
@@ -0,0 +1,125 @@
+package factors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository es un mock del repositorio de factores
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetFactors(kind string) ([]domain.RecommendationFactor, error) {
+	args := m.Called(kind)
+	return args.Get(0).([]domain.RecommendationFactor), args.Error(1)
+}
+
+func (m *MockRepository) GetFactor(kind, key string) (domain.RecommendationFactor, error) {
+	args := m.Called(kind, key)
+	return args.Get(0).(domain.RecommendationFactor), args.Error(1)
+}
+
+func (m *MockRepository) SaveFactor(factor *domain.RecommendationFactor) error {
+	args := m.Called(factor)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteFactor(kind, key string) error {
+	args := m.Called(kind, key)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SeedFactors(factors []domain.RecommendationFactor) (bool, error) {
+	args := m.Called(factors)
+	return args.Bool(0), args.Error(1)
+}
+
+// newTestService crea un servicio con un modelo de archivo que define AAPL y Citigroup
+func newTestService(mockRepo *MockRepository) (*service, *config.Config) {
+	cfg := &config.Config{}
+	fileFactors := config.DefaultRecommendationFactors()
+	fileFactors.Companies = map[string]float64{"AAPL": 10}
+	fileFactors.Brokerages = map[string]float64{"Citigroup": 5}
+	cfg.SetRecommendationFactors(fileFactors)
+
+	return &service{repo: mockRepo, cfg: cfg}, cfg
+}
+
+// TestSeedFactors verifica que la tabla se inicialice con los factores del archivo
+func TestSeedFactors(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("SeedFactors", mock.MatchedBy(func(seed []domain.RecommendationFactor) bool {
+		return assert.ElementsMatch(t, []domain.RecommendationFactor{
+			{Kind: domain.FactorKindCompany, Key: "AAPL", Factor: 10},
+			{Kind: domain.FactorKindBrokerage, Key: "Citigroup", Factor: 5},
+		}, seed)
+	})).Return(true, nil)
+
+	svc, _ := newTestService(mockRepo)
+
+	assert.NoError(t, svc.seedFactors())
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSaveFactor_AppliesToModel verifica que un factor guardado reemplace a los del archivo en el modelo vigente
+func TestSaveFactor_AppliesToModel(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("SaveFactor", mock.MatchedBy(func(f *domain.RecommendationFactor) bool {
+		return f.Kind == domain.FactorKindCompany && f.Key == "MSFT" && f.Factor == 7
+	})).Return(nil)
+	mockRepo.On("GetFactors", domain.FactorKindCompany).Return([]domain.RecommendationFactor{
+		{Kind: domain.FactorKindCompany, Key: "MSFT", Factor: 7},
+	}, nil)
+	mockRepo.On("GetFactors", domain.FactorKindBrokerage).Return([]domain.RecommendationFactor{}, nil)
+
+	svc, cfg := newTestService(mockRepo)
+	changes := 0
+	svc.OnChange(func() { changes++ })
+
+	factor, err := svc.SaveFactor(domain.FactorKindCompany, " msft ", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "MSFT", factor.Key, "El ticker debe normalizarse")
+	assert.Equal(t, map[string]float64{"MSFT": 7}, cfg.RecommendationFactors().Companies)
+	assert.Empty(t, cfg.RecommendationFactors().Brokerages)
+	assert.Equal(t, map[string]float64{"AAPL": 10}, cfg.FileRecommendationFactors().Companies, "El modelo del archivo no debe modificarse")
+	assert.Equal(t, 1, changes)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSaveFactor_Invalid verifica que se rechacen factores fuera de rango y claves vacías
+func TestSaveFactor_Invalid(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc, _ := newTestService(mockRepo)
+
+	_, err := svc.SaveFactor(domain.FactorKindBrokerage, "Citigroup", -100)
+	assert.True(t, errors.Is(err, domain.ErrInvalidFactor))
+
+	_, err = svc.SaveFactor(domain.FactorKindCompany, "  ", 5)
+	assert.True(t, errors.Is(err, domain.ErrInvalidFactor))
+
+	mockRepo.AssertNotCalled(t, "SaveFactor", mock.Anything)
+}
+
+// TestDeleteFactor_NotFound verifica que no se modifique el modelo si el factor no existe
+func TestDeleteFactor_NotFound(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("DeleteFactor", domain.FactorKindCompany, "TSLA").Return(domain.ErrNotFound)
+
+	svc, cfg := newTestService(mockRepo)
+	changes := 0
+	svc.OnChange(func() { changes++ })
+
+	err := svc.DeleteFactor(domain.FactorKindCompany, "tsla")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, map[string]float64{"AAPL": 10}, cfg.RecommendationFactors().Companies)
+	assert.Zero(t, changes)
+	mockRepo.AssertExpectations(t)
+}
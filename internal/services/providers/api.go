@@ -0,0 +1,58 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/apiClient"
+)
+
+// apiProvider obtiene los stocks de la API externa, que pagina con el token next_page
+type apiProvider struct {
+	client apiClient.Client
+}
+
+// NewAPIProvider crea la fuente de datos de la API externa
+func NewAPIProvider(client apiClient.Client) Provider {
+	return &apiProvider{client: client}
+}
+
+// Name implementa la interfaz Provider.Name
+func (p *apiProvider) Name() string {
+	return ProviderAPI
+}
+
+// FetchPage implementa la interfaz Provider.FetchPage
+func (p *apiProvider) FetchPage(ctx context.Context, cursor string) (Page, error) {
+	// Preparar parámetros para la solicitud
+	params := make(map[string]string)
+	if cursor != "" {
+		params["next_page"] = cursor
+	}
+
+	// Realizar solicitud a la API
+	response, err := p.client.Get(ctx, "", params)
+	if err != nil {
+		return Page{Attempts: response.Attempts}, err
+	}
+
+	// Definir estructura para la respuesta
+	var result struct {
+		Items    []map[string]interface{} `json:"items"`
+		NextPage string                   `json:"next_page"`
+	}
+
+	// Deserializar la respuesta
+	if err := json.Unmarshal(response.Body, &result); err != nil {
+		return Page{Attempts: response.Attempts}, fmt.Errorf("error parseando JSON: %w", err)
+	}
+
+	return Page{Items: result.Items, NextPage: result.NextPage, Attempts: response.Attempts}, nil
+}
+
+// ParseItem implementa la interfaz Provider.ParseItem
+func (p *apiProvider) ParseItem(item map[string]interface{}) (domain.Stock, error) {
+	return ParseItem(item)
+}
@@ -0,0 +1,132 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// filePageSize es la cantidad de elementos por página de un archivo, igual a la de la API externa
+const filePageSize = 10
+
+// fileProvider obtiene los stocks de un archivo JSON local con un arreglo de elementos
+// en el mismo formato que la API externa. El cursor es la posición del primer elemento de la página.
+// El archivo se decodifica elemento a elemento: las páginas de una sincronización se piden en orden,
+// por lo que cada una continúa la lectura donde terminó la anterior y el archivo se recorre una sola vez.
+type fileProvider struct {
+	path string
+
+	// Lectura en curso: el archivo abierto, su decodificador y la posición del próximo elemento
+	mu     sync.Mutex
+	file   *os.File
+	dec    *json.Decoder
+	offset int
+}
+
+// NewFileProvider crea la fuente de datos de un archivo local
+func NewFileProvider(path string) Provider {
+	return &fileProvider{path: path}
+}
+
+// Name implementa la interfaz Provider.Name
+func (p *fileProvider) Name() string {
+	return ProviderFile
+}
+
+// FetchPage implementa la interfaz Provider.FetchPage.
+// Si el cursor no continúa la lectura en curso (primera página o reanudación), el archivo se vuelve a abrir
+// y se descartan los elementos anteriores al cursor. Al llegar al final del arreglo el archivo se cierra.
+func (p *fileProvider) FetchPage(ctx context.Context, cursor string) (Page, error) {
+	if err := ctx.Err(); err != nil {
+		return Page{}, err
+	}
+
+	offset := 0
+	if cursor != "" {
+		var err error
+		offset, err = strconv.Atoi(cursor)
+		if err != nil || offset < 0 {
+			return Page{}, fmt.Errorf("cursor inválido: %q", cursor)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.dec == nil || cursor == "" || offset != p.offset {
+		if err := p.open(offset); err != nil {
+			p.close()
+			return Page{}, err
+		}
+	}
+
+	var page Page
+	for len(page.Items) < filePageSize && p.dec.More() {
+		var item map[string]interface{}
+		if err := p.dec.Decode(&item); err != nil {
+			p.close()
+			return Page{}, fmt.Errorf("error parseando JSON: %w", err)
+		}
+		page.Items = append(page.Items, item)
+		p.offset++
+	}
+
+	// Sin más elementos la paginación termina y el archivo ya no es necesario
+	if !p.dec.More() {
+		p.close()
+		return page, nil
+	}
+
+	page.NextPage = strconv.Itoa(p.offset)
+	return page, nil
+}
+
+// open abre el archivo, valida que contenga un arreglo y se posiciona en el elemento offset.
+// Un offset mayor que la cantidad de elementos deja la lectura al final del arreglo.
+func (p *fileProvider) open(offset int) error {
+	p.close()
+
+	file, err := os.Open(p.path)
+	if err != nil {
+		return fmt.Errorf("error leyendo el archivo %s: %w", p.path, err)
+	}
+	p.file = file
+	p.dec = json.NewDecoder(file)
+
+	token, err := p.dec.Token()
+	if err != nil {
+		return fmt.Errorf("error parseando JSON: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("error parseando JSON: el archivo debe contener un arreglo de elementos")
+	}
+
+	for i := 0; i < offset && p.dec.More(); i++ {
+		var skipped json.RawMessage
+		if err := p.dec.Decode(&skipped); err != nil {
+			return fmt.Errorf("error parseando JSON: %w", err)
+		}
+	}
+	p.offset = offset
+	return nil
+}
+
+// close cierra el archivo de la lectura en curso, si hay uno
+func (p *fileProvider) close() {
+	if p.file != nil {
+		p.file.Close()
+	}
+	p.file = nil
+	p.dec = nil
+	p.offset = 0
+}
+
+// ParseItem implementa la interfaz Provider.ParseItem
+func (p *fileProvider) ParseItem(item map[string]interface{}) (domain.Stock, error) {
+	return ParseItem(item)
+}
@@ -1,20 +1,23 @@
-package stocks
+package providers
 
 import (
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 )
 
-// parseStock convierte un mapa a un objeto domain.Stock y le asigna una puntuación de recomendación
-func (s *service) parseStock(item map[string]interface{}) (domain.Stock, error) {
+// ParseItem convierte un elemento con el formato de la API de stocks a un objeto domain.Stock, sin puntuación.
+// Los formatos de la fecha y de los precios se interpretan de forma tolerante.
+func ParseItem(item map[string]interface{}) (domain.Stock, error) {
 	// Extraer campos de texto del mapa
-	textFields := s.extractTextFields(item)
+	textFields := extractTextFields(item)
 
 	// Procesar campos numéricos
-	targetFrom, targetTo, err := s.extractNumericFields(item)
+	targetFrom, targetTo, err := extractNumericFields(item)
 	if err != nil {
 		return domain.Stock{}, err
 	}
@@ -30,16 +33,14 @@ func (s *service) parseStock(item map[string]interface{}) (domain.Stock, error)
 		TargetFrom: targetFrom,
 		TargetTo:   targetTo,
 		Currency:   textFields["currency"],
+		RatedAt:    extractRatedAt(item),
 	}
 
-	// Calcular y asignar la puntuación de recomendación
-	stock.RecommendScore = s.recommendationScore(stock)
-
 	return stock, nil
 }
 
 // extractTextFields extrae los campos de texto del mapa de datos
-func (s *service) extractTextFields(item map[string]interface{}) map[string]string {
+func extractTextFields(item map[string]interface{}) map[string]string {
 	fields := make(map[string]string)
 
 	// Extraer valores de texto del mapa
@@ -60,14 +61,14 @@ func (s *service) extractTextFields(item map[string]interface{}) map[string]stri
 }
 
 // extractNumericFields procesa y extrae los campos numéricos
-func (s *service) extractNumericFields(item map[string]interface{}) (float64, float64, error) {
+func extractNumericFields(item map[string]interface{}) (float64, float64, error) {
 	// Procesar valores numéricos
 	targetFromStr, _ := item["target_from"].(string)
 	targetToStr, _ := item["target_to"].(string)
 
 	// Limpiar formatos monetarios
-	targetFromStr = s.cleanMonetaryFormat(targetFromStr)
-	targetToStr = s.cleanMonetaryFormat(targetToStr)
+	targetFromStr = cleanMonetaryFormat(targetFromStr)
+	targetToStr = cleanMonetaryFormat(targetToStr)
 
 	// Convertir target_from a número
 	targetFrom, err := strconv.ParseFloat(targetFromStr, 64)
@@ -84,7 +85,40 @@ func (s *service) extractNumericFields(item map[string]interface{}) (float64, fl
 	return targetFrom, targetTo, nil
 }
 
+// ratedAtLayouts son los formatos aceptados para la fecha de la calificación
+var ratedAtLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// extractRatedAt obtiene la fecha de la calificación desde el campo time.
+// Acepta cadenas en varios formatos y timestamps Unix; si no es posible
+// interpretarla devuelve la fecha cero sin descartar el stock.
+func extractRatedAt(item map[string]interface{}) time.Time {
+	switch value := item["time"].(type) {
+	case string:
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return time.Time{}
+		}
+		for _, layout := range ratedAtLayouts {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return parsed.UTC()
+			}
+		}
+		log.Printf("Formato de fecha no reconocido en campo time: %q", value)
+	case float64:
+		return time.Unix(int64(value), 0).UTC()
+	}
+
+	return time.Time{}
+}
+
 // cleanMonetaryFormat elimina símbolos de moneda y separadores de miles
-func (s *service) cleanMonetaryFormat(value string) string {
+func cleanMonetaryFormat(value string) string {
 	return strings.ReplaceAll(strings.TrimPrefix(value, "$"), ",", "")
 }
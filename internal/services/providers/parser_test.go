@@ -1,25 +1,14 @@
-package stocks
+package providers
 
 import (
 	"testing"
+	"time"
 
-	"github.com/julianloaiza/stock-advisor/config"
 	"github.com/stretchr/testify/assert"
 )
 
 // TestExtractTextFields verifica la extracción de campos de texto
 func TestExtractTextFields(t *testing.T) {
-	// Crear una configuración básica para el servicio
-	cfg := &config.Config{
-		SyncMaxIterations: 10,
-		SyncTimeout:       60,
-	}
-
-	// Crear instancia del servicio para pruebas
-	s := &service{
-		cfg: cfg,
-	}
-
 	// Caso completo
 	item := map[string]interface{}{
 		"ticker":      "AAPL",
@@ -31,7 +20,7 @@ func TestExtractTextFields(t *testing.T) {
 		"currency":    "EUR",
 	}
 
-	fields := s.extractTextFields(item)
+	fields := extractTextFields(item)
 
 	assert.Equal(t, "AAPL", fields["ticker"])
 	assert.Equal(t, "Apple Inc.", fields["company"])
@@ -48,7 +37,7 @@ func TestExtractTextFields(t *testing.T) {
 		// Otros campos faltantes
 	}
 
-	fieldsIncomplete := s.extractTextFields(itemIncomplete)
+	fieldsIncomplete := extractTextFields(itemIncomplete)
 
 	assert.Equal(t, "MSFT", fieldsIncomplete["ticker"])
 	assert.Equal(t, "Microsoft", fieldsIncomplete["company"])
@@ -58,17 +47,6 @@ func TestExtractTextFields(t *testing.T) {
 
 // TestExtractNumericFields verifica la extracción de campos numéricos
 func TestExtractNumericFields(t *testing.T) {
-	// Crear una configuración básica para el servicio
-	cfg := &config.Config{
-		SyncMaxIterations: 10,
-		SyncTimeout:       60,
-	}
-
-	// Crear instancia del servicio para pruebas
-	s := &service{
-		cfg: cfg,
-	}
-
 	// Caso valores correctos
 	t.Run("Valores correctos", func(t *testing.T) {
 		item := map[string]interface{}{
@@ -76,7 +54,7 @@ func TestExtractNumericFields(t *testing.T) {
 			"target_to":   "150.75",
 		}
 
-		from, to, err := s.extractNumericFields(item)
+		from, to, err := extractNumericFields(item)
 
 		assert.NoError(t, err)
 		assert.Equal(t, 100.50, from)
@@ -90,7 +68,7 @@ func TestExtractNumericFields(t *testing.T) {
 			"target_to":   "$2,345.67",
 		}
 
-		from, to, err := s.extractNumericFields(item)
+		from, to, err := extractNumericFields(item)
 
 		assert.NoError(t, err)
 		assert.Equal(t, 1234.56, from)
@@ -104,7 +82,7 @@ func TestExtractNumericFields(t *testing.T) {
 			"target_to":   "100.00",
 		}
 
-		_, _, err := s.extractNumericFields(item)
+		_, _, err := extractNumericFields(item)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error convirtiendo target_from")
@@ -117,7 +95,7 @@ func TestExtractNumericFields(t *testing.T) {
 			"target_to":   "invalid",
 		}
 
-		_, _, err := s.extractNumericFields(item)
+		_, _, err := extractNumericFields(item)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error convirtiendo target_to")
@@ -126,17 +104,6 @@ func TestExtractNumericFields(t *testing.T) {
 
 // TestCleanMonetaryFormat verifica la limpieza de formatos monetarios
 func TestCleanMonetaryFormat(t *testing.T) {
-	// Crear una configuración básica para el servicio
-	cfg := &config.Config{
-		SyncMaxIterations: 10,
-		SyncTimeout:       60,
-	}
-
-	// Crear instancia del servicio para pruebas
-	s := &service{
-		cfg: cfg,
-	}
-
 	testCases := []struct {
 		input    string
 		expected string
@@ -151,8 +118,41 @@ func TestCleanMonetaryFormat(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.input, func(t *testing.T) {
-			result := s.cleanMonetaryFormat(tc.input)
+			result := cleanMonetaryFormat(tc.input)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
 }
+
+// TestExtractRatedAt verifica la interpretación tolerante del campo time
+func TestExtractRatedAt(t *testing.T) {
+	expected := time.Date(2025, 1, 13, 0, 30, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected time.Time
+	}{
+		{"RFC3339 con nanosegundos", "2025-01-13T00:30:05.000000000Z", expected},
+		{"RFC3339 con zona horaria", "2025-01-12T19:30:05-05:00", expected},
+		{"Sin zona horaria", "2025-01-13T00:30:05", expected},
+		{"Con espacio", "2025-01-13 00:30:05", expected},
+		{"Solo fecha", "2025-01-13", time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{"Timestamp Unix", float64(expected.Unix()), expected},
+		{"Formato inválido", "13/01/2025", time.Time{}},
+		{"Vacío", "", time.Time{}},
+		{"Ausente", nil, time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := map[string]interface{}{}
+			if tc.value != nil {
+				item["time"] = tc.value
+			}
+
+			result := extractRatedAt(item)
+			assert.True(t, tc.expected.Equal(result), "esperado %v, obtenido %v", tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/apiClient"
+)
+
+// Provider define una fuente de datos de stocks para la sincronización.
+// La orquestación (paginación, checkpoints, puntuación y escritura) no depende de la fuente.
+type Provider interface {
+	// Name devuelve el nombre con el que se selecciona la fuente en la configuración.
+	Name() string
+
+	// FetchPage obtiene la página indicada por cursor; el cursor vacío corresponde a la primera página.
+	// Page.Attempts también se informa cuando la solicitud falla.
+	FetchPage(ctx context.Context, cursor string) (Page, error)
+
+	// ParseItem convierte un elemento de una página a un stock, sin puntuación de recomendación.
+	ParseItem(item map[string]interface{}) (domain.Stock, error)
+}
+
+// Page es una página de elementos obtenida de una fuente
+type Page struct {
+	Items    []map[string]interface{}
+	NextPage string // Cursor de la página siguiente; vacío si no hay más páginas
+	Attempts int    // Solicitudes realizadas para obtener la página
+}
+
+// Nombres de las fuentes disponibles (STOCK_PROVIDER)
+const (
+	ProviderAPI  = "api"
+	ProviderFile = "file"
+)
+
+// New crea la fuente de datos seleccionada en la configuración
+func New(cfg *config.Config, client apiClient.Client) (Provider, error) {
+	switch cfg.StockProvider {
+	case ProviderAPI:
+		return NewAPIProvider(client), nil
+	case ProviderFile:
+		return NewFileProvider(cfg.StockProviderFile), nil
+	default:
+		return nil, fmt.Errorf("fuente de stocks desconocida: %q", cfg.StockProvider)
+	}
+}
@@ -0,0 +1,173 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/services/apiClient"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient es un cliente de API que devuelve una respuesta fija y registra los parámetros recibidos
+type fakeClient struct {
+	response apiClient.Response
+	err      error
+	params   map[string]string
+}
+
+func (c *fakeClient) Get(ctx context.Context, path string, params map[string]string) (apiClient.Response, error) {
+	c.params = params
+	return c.response, c.err
+}
+
+// TestNew verifica la selección de la fuente según STOCK_PROVIDER
+func TestNew(t *testing.T) {
+	provider, err := New(&config.Config{StockProvider: ProviderAPI}, &fakeClient{})
+	assert.NoError(t, err)
+	assert.Equal(t, ProviderAPI, provider.Name())
+
+	provider, err = New(&config.Config{StockProvider: ProviderFile, StockProviderFile: "stocks.json"}, &fakeClient{})
+	assert.NoError(t, err)
+	assert.Equal(t, ProviderFile, provider.Name())
+
+	_, err = New(&config.Config{StockProvider: "ftp"}, &fakeClient{})
+	assert.Error(t, err)
+}
+
+// TestAPIProvider_FetchPage verifica la solicitud con el token next_page y la lectura del sobre items/next_page
+func TestAPIProvider_FetchPage(t *testing.T) {
+	client := &fakeClient{response: apiClient.Response{
+		Body:     []byte(`{"items": [{"ticker": "AAPL"}], "next_page": "page-3"}`),
+		Attempts: 2,
+	}}
+	provider := NewAPIProvider(client)
+
+	page, err := provider.FetchPage(context.Background(), "page-2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"next_page": "page-2"}, client.params)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "AAPL", page.Items[0]["ticker"])
+	assert.Equal(t, "page-3", page.NextPage)
+	assert.Equal(t, 2, page.Attempts)
+
+	// La primera página no envía el token
+	_, err = provider.FetchPage(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Empty(t, client.params)
+}
+
+// TestAPIProvider_FetchPageErrors verifica que los errores informen los intentos realizados
+func TestAPIProvider_FetchPageErrors(t *testing.T) {
+	provider := NewAPIProvider(&fakeClient{
+		response: apiClient.Response{Attempts: 3},
+		err:      errors.New("status 503"),
+	})
+	page, err := provider.FetchPage(context.Background(), "")
+	assert.Error(t, err)
+	assert.Equal(t, 3, page.Attempts)
+
+	provider = NewAPIProvider(&fakeClient{response: apiClient.Response{Body: []byte(`{invalid`), Attempts: 1}})
+	page, err = provider.FetchPage(context.Background(), "")
+	assert.ErrorContains(t, err, "error parseando JSON")
+	assert.Equal(t, 1, page.Attempts)
+}
+
+// TestFileProvider_FetchPage verifica la paginación de un archivo local
+func TestFileProvider_FetchPage(t *testing.T) {
+	items := make([]string, 25)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"ticker": "T%d", "target_from": "$1.00", "target_to": "$2.00"}`, i)
+	}
+	path := filepath.Join(t.TempDir(), "stocks.json")
+	assert.NoError(t, os.WriteFile(path, []byte("["+strings.Join(items, ",")+"]"), 0o644))
+
+	provider := NewFileProvider(path)
+
+	var cursors []string
+	var tickers []interface{}
+	cursor := ""
+	for {
+		page, err := provider.FetchPage(context.Background(), cursor)
+		assert.NoError(t, err)
+		for _, item := range page.Items {
+			tickers = append(tickers, item["ticker"])
+		}
+		if page.NextPage == "" {
+			break
+		}
+		cursor = page.NextPage
+		cursors = append(cursors, cursor)
+	}
+
+	assert.Equal(t, []string{"10", "20"}, cursors)
+	assert.Len(t, tickers, 25)
+	assert.Equal(t, "T0", tickers[0])
+	assert.Equal(t, "T24", tickers[24])
+
+	// Un cursor fuera de rango es una página vacía y uno inválido es un error
+	page, err := provider.FetchPage(context.Background(), "30")
+	assert.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.Empty(t, page.NextPage)
+
+	_, err = provider.FetchPage(context.Background(), "abc")
+	assert.Error(t, err)
+
+	// Los elementos se interpretan igual que los de la API
+	stock, err := provider.ParseItem(map[string]interface{}{"ticker": "AAPL", "target_from": "$1,000.00", "target_to": "1200"})
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", stock.Ticker)
+	assert.Equal(t, 1000.0, stock.TargetFrom)
+	assert.Equal(t, "USD", stock.Currency)
+}
+
+// TestFileProvider_FetchPageResume verifica que un cursor que no continúa la lectura en curso,
+// como al reanudar una sincronización, vuelva a posicionarse en el elemento indicado
+func TestFileProvider_FetchPageResume(t *testing.T) {
+	items := make([]string, 15)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"ticker": "T%d"}`, i)
+	}
+	path := filepath.Join(t.TempDir(), "stocks.json")
+	assert.NoError(t, os.WriteFile(path, []byte("["+strings.Join(items, ",")+"]"), 0o644))
+
+	provider := NewFileProvider(path)
+
+	page, err := provider.FetchPage(context.Background(), "12")
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 3)
+	assert.Equal(t, "T12", page.Items[0]["ticker"])
+	assert.Empty(t, page.NextPage)
+
+	// Después del final, la primera página vuelve a leer el archivo desde el inicio
+	page, err = provider.FetchPage(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "T0", page.Items[0]["ticker"])
+	assert.Equal(t, "10", page.NextPage)
+}
+
+// TestFileProvider_FetchPageNotArray verifica que se rechace un archivo que no contiene un arreglo
+func TestFileProvider_FetchPageNotArray(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stocks.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"items": []}`), 0o644))
+
+	_, err := NewFileProvider(path).FetchPage(context.Background(), "")
+
+	assert.Error(t, err)
+}
+
+// TestFileProvider_FetchPageMissingFile verifica el error de un archivo inexistente
+func TestFileProvider_FetchPageMissingFile(t *testing.T) {
+	provider := NewFileProvider(filepath.Join(t.TempDir(), "missing.json"))
+
+	_, err := provider.FetchPage(context.Background(), "")
+
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
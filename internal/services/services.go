@@ -2,6 +2,8 @@ package services
 
 import (
 	"github.com/julianloaiza/stock-advisor/internal/services/apiClient"
+	"github.com/julianloaiza/stock-advisor/internal/services/factors"
+	"github.com/julianloaiza/stock-advisor/internal/services/providers"
 	"github.com/julianloaiza/stock-advisor/internal/services/stocks"
 	"go.uber.org/fx"
 )
@@ -9,5 +11,7 @@ import (
 // Module registra los servicios.
 var Module = fx.Module("services", fx.Provide(
 	apiClient.New, // Servicio API para comunicación con servicios externos
+	providers.New, // Fuente de datos de la sincronización (STOCK_PROVIDER)
+	factors.New,   // Servicio de factores de recomendación
 	stocks.New,    // Servicio de stocks
 ))
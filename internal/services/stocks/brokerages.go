@@ -0,0 +1,30 @@
+package stocks
+
+import (
+	"strings"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// GetBrokerages obtiene el resumen de comportamiento por brokerage, con el factor configurado de cada uno.
+func (s *service) GetBrokerages(filter domain.StockFilter) ([]domain.BrokerageSummary, int64, error) {
+	summaries, total, err := s.repo.GetBrokerageSummaries(s.withRatingBuckets(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	factors := s.recommendationFactors()
+	for i := range summaries {
+		if factor, exists := factors.Brokerages[summaries[i].Brokerage]; exists {
+			summaries[i].Factor = &factor
+		}
+	}
+
+	return summaries, total, nil
+}
+
+// GetBrokerageStocks obtiene las acciones de un brokerage con los filtros y la paginación indicados.
+func (s *service) GetBrokerageStocks(name string, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+	filter.Brokerage = strings.TrimSpace(name)
+	return s.repo.GetStocks(s.withRatingBuckets(filter))
+}
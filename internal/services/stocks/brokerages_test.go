@@ -0,0 +1,51 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetBrokerages_AddsConfiguredFactor prueba que cada brokerage incluya su factor configurado, si lo tiene
+func TestGetBrokerages_AddsConfiguredFactor(t *testing.T) {
+	filter := domain.StockFilter{Page: 1, Size: 10}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetBrokerageSummaries", filter).Return([]domain.BrokerageSummary{
+		{Brokerage: "Citigroup", Actions: 4},
+		{Brokerage: "Morgan Stanley", Actions: 2},
+	}, int64(2), nil)
+
+	factors := config.DefaultRecommendationFactors()
+	factors.Brokerages = map[string]float64{"Citigroup": 5}
+	cfg := &config.Config{}
+	cfg.SetRecommendationFactors(factors)
+
+	svc := &service{repo: mockRepo, cfg: cfg}
+	summaries, total, err := svc.GetBrokerages(filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	if assert.NotNil(t, summaries[0].Factor) {
+		assert.Equal(t, 5.0, *summaries[0].Factor)
+	}
+	assert.Nil(t, summaries[1].Factor)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetBrokerageStocks prueba que las acciones se filtren por el brokerage indicado
+func TestGetBrokerageStocks(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStocks", domain.StockFilter{Page: 1, Size: 10, Brokerage: "Citigroup"}).
+		Return([]domain.Stock{{ID: 1, Brokerage: "Citigroup"}}, int64(1), nil)
+
+	svc := &service{repo: mockRepo, cfg: &config.Config{}}
+	stocks, total, err := svc.GetBrokerageStocks(" Citigroup ", domain.StockFilter{Page: 1, Size: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, stocks, 1)
+	mockRepo.AssertExpectations(t)
+}
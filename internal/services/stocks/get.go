@@ -1,18 +1,19 @@
 package stocks
 
 import (
+	"context"
 	"log"
 
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 )
 
 // GetStocks maneja la búsqueda, paginación y recomendaciones.
-func (s *service) GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error) {
+func (s *service) GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error) {
 	log.Println("Ejecutando búsqueda de stocks")
 
 	// Obtener stocks paginados desde la base de datos
 	// El repositorio ya se encarga de ordenar por RecommendScore si recommends es true
-	stocks, total, err := s.repo.GetStocks(query, page, size, recommends, minTargetTo, maxTargetTo, currency)
+	stocks, total, err := s.repo.GetStocks(s.withRatingBuckets(filter))
 	if err != nil {
 		log.Printf("Error al obtener stocks: %v", err)
 		return nil, 0, err
@@ -20,3 +21,49 @@ func (s *service) GetStocks(query string, page, size int, recommends bool, minTa
 
 	return stocks, total, nil
 }
+
+// GetStocksByCursor obtiene una página de stocks con paginación por cursor (keyset).
+// El total de registros solo se calcula si el filtro lo solicita.
+func (s *service) GetStocksByCursor(filter domain.StockFilter) (domain.StockPage, error) {
+	log.Println("Ejecutando búsqueda de stocks por cursor")
+
+	filter = s.withRatingBuckets(filter)
+
+	stocks, nextCursor, err := s.repo.GetStocksByCursor(filter)
+	if err != nil {
+		log.Printf("Error al obtener stocks por cursor: %v", err)
+		return domain.StockPage{}, err
+	}
+
+	page := domain.StockPage{Stocks: stocks, NextCursor: nextCursor}
+	if filter.WithTotal {
+		total, err := s.repo.CountStocks(filter)
+		if err != nil {
+			log.Printf("Error al contar stocks: %v", err)
+			return domain.StockPage{}, err
+		}
+		page.Total = &total
+	}
+	return page, nil
+}
+
+// ExportStocks recorre todos los stocks que cumplen el filtro, sin paginación, y entrega cada uno a fn.
+// Los stocks se leen de la base de datos con un cursor, por lo que el resultado no se carga completo en memoria.
+func (s *service) ExportStocks(ctx context.Context, filter domain.StockFilter, fn func(domain.Stock) error) error {
+	log.Println("Ejecutando exportación de stocks")
+
+	if err := s.repo.StreamStocks(ctx, s.withRatingBuckets(filter), fn); err != nil {
+		log.Printf("Error al exportar stocks: %v", err)
+		return err
+	}
+	return nil
+}
+
+// withRatingBuckets completa en el filtro las calificaciones de cada categoría del modelo de puntuación
+// cuando se filtra por categoría de calificación
+func (s *service) withRatingBuckets(filter domain.StockFilter) domain.StockFilter {
+	if len(filter.RatingTo) > 0 || len(filter.RatingFrom) > 0 {
+		filter.RatingBuckets = ratingBuckets(s.recommendationFactors())
+	}
+	return filter
+}
@@ -1,28 +1,16 @@
 package stocks
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/julianloaiza/stock-advisor/config"
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-// Repositorio mock para pruebas
-type mockStockRepository struct {
-	mock.Mock
-}
-
-func (m *mockStockRepository) GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error) {
-	args := m.Called(query, page, size, recommends, minTargetTo, maxTargetTo, currency)
-	return args.Get(0).([]domain.Stock), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *mockStockRepository) ReplaceAllStocks(stocks []domain.Stock) error {
-	args := m.Called(stocks)
-	return args.Error(0)
-}
-
 // TestGetStocks_BasicQuery prueba una consulta básica sin recomendaciones
 func TestGetStocks_BasicQuery(t *testing.T) {
 	// Crear datos de prueba
@@ -54,14 +42,14 @@ func TestGetStocks_BasicQuery(t *testing.T) {
 	}
 
 	// Crear repositorio mock
-	mockRepo := new(mockStockRepository)
-	mockRepo.On("GetStocks", "tech", 1, 10, false, 0.0, 0.0, "USD").Return(mockStocks, int64(2), nil)
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStocks", domain.StockFilter{Query: "tech", Page: 1, Size: 10, Currency: "USD"}).Return(mockStocks, int64(2), nil)
 
 	// Crear el servicio con el repositorio mock
 	s := &service{repo: mockRepo}
 
 	// Ejecutar función del servicio
-	result, total, err := s.GetStocks("tech", 1, 10, false, 0.0, 0.0, "USD")
+	result, total, err := s.GetStocks(domain.StockFilter{Query: "tech", Page: 1, Size: 10, Currency: "USD"})
 
 	// Verificar resultados
 	assert.NoError(t, err)
@@ -107,15 +95,15 @@ func TestGetStocks_WithRecommendations(t *testing.T) {
 	}
 
 	// Crear repositorio mock
-	mockRepo := new(mockStockRepository)
+	mockRepo := new(MockRepository)
 	// El repositorio ya ordenó los datos por RecommendScore porque recommends=true
-	mockRepo.On("GetStocks", "invest", 1, 10, true, 0.0, 0.0, "USD").Return(mockStocks, int64(2), nil)
+	mockRepo.On("GetStocks", domain.StockFilter{Query: "invest", Page: 1, Size: 10, Recommends: true, Currency: "USD"}).Return(mockStocks, int64(2), nil)
 
 	// Crear el servicio con el repositorio mock
 	s := &service{repo: mockRepo}
 
 	// Ejecutar función del servicio con recommends = true
-	result, total, err := s.GetStocks("invest", 1, 10, true, 0.0, 0.0, "USD")
+	result, total, err := s.GetStocks(domain.StockFilter{Query: "invest", Page: 1, Size: 10, Recommends: true, Currency: "USD"})
 
 	// Verificar resultados
 	assert.NoError(t, err)
@@ -149,14 +137,14 @@ func TestGetStocks_WithFilters(t *testing.T) {
 	}
 
 	// Crear repositorio mock
-	mockRepo := new(mockStockRepository)
-	mockRepo.On("GetStocks", "", 1, 20, false, 50.0, 100.0, "EUR").Return(mockStocks, int64(1), nil)
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStocks", domain.StockFilter{Page: 1, Size: 20, MinTargetTo: 50.0, MaxTargetTo: 100.0, Currency: "EUR"}).Return(mockStocks, int64(1), nil)
 
 	// Crear el servicio con el repositorio mock
 	s := &service{repo: mockRepo}
 
 	// Ejecutar función del servicio con filtros
-	result, total, err := s.GetStocks("", 1, 20, false, 50.0, 100.0, "EUR")
+	result, total, err := s.GetStocks(domain.StockFilter{Page: 1, Size: 20, MinTargetTo: 50.0, MaxTargetTo: 100.0, Currency: "EUR"})
 
 	// Verificar resultados
 	assert.NoError(t, err)
@@ -168,3 +156,70 @@ func TestGetStocks_WithFilters(t *testing.T) {
 	// Verificar que se llamó al método del repositorio con los parámetros correctos
 	mockRepo.AssertExpectations(t)
 }
+
+// TestGetStocksByCursor verifica que el total solo se cuente cuando el filtro lo solicita
+func TestGetStocksByCursor(t *testing.T) {
+	filter := domain.StockFilter{Size: 2, Currency: "USD", UseCursor: true, Cursor: "abc"}
+	stocks := []domain.Stock{{ID: 1, Ticker: "AAPL"}, {ID: 2, Ticker: "MSFT"}}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStocksByCursor", filter).Return(stocks, "next", nil)
+
+	s := &service{repo: mockRepo}
+
+	page, err := s.GetStocksByCursor(filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stocks, page.Stocks)
+	assert.Equal(t, "next", page.NextCursor)
+	assert.Nil(t, page.Total)
+	mockRepo.AssertNotCalled(t, "CountStocks", mock.Anything)
+
+	// Con WithTotal se agrega el conteo
+	filter.WithTotal = true
+	mockRepo.On("GetStocksByCursor", filter).Return(stocks, "", nil)
+	mockRepo.On("CountStocks", filter).Return(int64(2), nil)
+
+	page, err = s.GetStocksByCursor(filter)
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.NextCursor)
+	if assert.NotNil(t, page.Total) {
+		assert.Equal(t, int64(2), *page.Total)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+// TestExportStocks verifica que la exportación recorra el repositorio con el filtro recibido y propague sus errores
+func TestExportStocks(t *testing.T) {
+	filter := domain.StockFilter{Query: "tech", Page: 1, Size: 10, Currency: "USD"}
+	fn := func(domain.Stock) error { return nil }
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("StreamStocks", mock.Anything, filter, mock.Anything).Return(nil).Once()
+	mockRepo.On("StreamStocks", mock.Anything, filter, mock.Anything).Return(errors.New("db error")).Once()
+
+	s := &service{repo: mockRepo}
+
+	assert.NoError(t, s.ExportStocks(context.Background(), filter, fn))
+	assert.EqualError(t, s.ExportStocks(context.Background(), filter, fn), "db error")
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetStocks_RatingBuckets verifica que el filtro por categoría de calificación reciba las calificaciones del modelo
+func TestGetStocks_RatingBuckets(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStocks", mock.MatchedBy(func(filter domain.StockFilter) bool {
+		return len(filter.RatingBuckets.Buy) > 0 && len(filter.RatingBuckets.Sell) > 0
+	})).Return([]domain.Stock{}, int64(0), nil)
+
+	s := &service{repo: mockRepo, cfg: &config.Config{}}
+
+	_, _, err := s.GetStocks(domain.StockFilter{Page: 1, Size: 10, RatingTo: []string{domain.RatingBucketBuy}})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+
+	// Sin filtro por categoría el filtro llega sin cambios
+	assert.Empty(t, s.withRatingBuckets(domain.StockFilter{}).RatingBuckets.Buy)
+}
@@ -0,0 +1,260 @@
+package stocks
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/providers"
+)
+
+// maxImportLineSize es el tamaño máximo de una línea de un archivo NDJSON
+const maxImportLineSize = 1 << 20
+
+// importRow es una fila de un archivo importado con el formato de un elemento de la API externa
+type importRow struct {
+	line int
+	item map[string]interface{}
+	err  error // Error de lectura de la fila; se registra como rechazo
+}
+
+// ImportStocks importa stocks desde un archivo CSV o NDJSON con el formato de los elementos de la API externa.
+// Cada fila se convierte y puntúa como en la sincronización y las filas inválidas se registran como rechazos.
+// Los stocks se guardan a medida que se leen, en lotes de SYNC_BATCH_SIZE con una transacción por lote ligada a ctx;
+// una fila repetida se guarda solo la primera vez. Con replace, los stocks que no están en el archivo se eliminan
+// después del último lote, y solo si el archivo se leyó y guardó completo.
+// Cada importación con stocks válidos se registra como una ejecución con origen import; si falla, los lotes ya
+// guardados se conservan y la ejecución registra el error.
+// No se ejecuta en paralelo con una sincronización.
+func (s *service) ImportStocks(ctx context.Context, r io.Reader, opts domain.ImportOptions) (domain.ImportResult, error) {
+	if opts.Mode == "" {
+		opts.Mode = domain.ImportModeAppend
+	}
+	if opts.Mode != domain.ImportModeAppend && opts.Mode != domain.ImportModeReplace {
+		return domain.ImportResult{}, fmt.Errorf("%w: modo %q desconocido, debe ser append o replace", domain.ErrInvalidImport, opts.Mode)
+	}
+
+	result := domain.ImportResult{Format: opts.Format, Mode: opts.Mode}
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	// La ejecución y el escritor se crean con el primer stock válido: un archivo sin filas válidas
+	// no modifica los stocks, ni siquiera con replace
+	var run *domain.SyncRun
+	var writer *syncWriter
+	var rejects []domain.SyncReject
+	batchSize := max(s.cfg.SyncBatchSize, 1)
+
+	saveRejects := func() error {
+		if err := s.repo.SaveSyncRejects(rejects); err != nil {
+			return fmt.Errorf("error guardando filas rechazadas: %w", err)
+		}
+		rejects = rejects[:0]
+		return nil
+	}
+
+	// Leer, convertir y guardar las filas a medida que se leen, sin conservar el archivo en memoria
+	err := readImportRows(r, opts.Format, func(row importRow) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result.RowsRead++
+
+		stock, reject, ok := s.processImportRow(row)
+		if !ok {
+			result.ItemsRejected++
+			rejects = append(rejects, reject)
+			if len(rejects) >= batchSize {
+				return saveRejects()
+			}
+			return nil
+		}
+
+		result.ItemsParsed++
+		if writer == nil {
+			// Registrar la importación como ejecución para que los stocks importados entren al historial de calificaciones
+			run = &domain.SyncRun{Source: domain.SyncRunSourceImport, StartedAt: time.Now()}
+			if err := s.repo.CreateSyncRun(run); err != nil {
+				run = nil
+				return fmt.Errorf("error registrando la ejecución de la importación: %w", err)
+			}
+			writer = s.newSyncWriter(run.ID)
+		}
+		return writer.add(ctx, []domain.Stock{stock})
+	})
+	if err == nil && len(rejects) > 0 {
+		err = saveRejects()
+	}
+	if err == nil && writer != nil {
+		err = s.finishImportWrites(ctx, writer, opts.Mode == domain.ImportModeReplace)
+	}
+
+	if writer != nil {
+		result.Inserted = writer.result.Inserted
+		result.Updated = writer.result.Updated
+		result.Unchanged = writer.result.Unchanged
+		result.Removed = writer.result.Removed
+	}
+	if run != nil {
+		progress := domain.SyncResult{ItemsParsed: result.ItemsParsed, ItemsRejected: result.ItemsRejected}
+		s.finishSyncRun(run, mergeSyncChanges(progress, writer.result), err)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if writer == nil {
+		log.Println("No se encontraron stocks para importar.")
+		return result, nil
+	}
+
+	log.Printf("Importación completada: %d filas, %d stocks, %d rechazadas", result.RowsRead, result.ItemsParsed, result.ItemsRejected)
+	return result, nil
+}
+
+// finishImportWrites guarda el último lote de una importación y, con replace, elimina los stocks que el archivo no incluyó
+func (s *service) finishImportWrites(ctx context.Context, writer *syncWriter, replace bool) error {
+	if err := writer.flush(ctx); err != nil {
+		return err
+	}
+	if !replace {
+		return nil
+	}
+
+	removed, err := s.repo.RemoveMissingStocks(writer.runID)
+	if err != nil {
+		return fmt.Errorf("error eliminando stocks ausentes: %w", err)
+	}
+	writer.result.Removed = removed
+	return nil
+}
+
+// processImportRow convierte una fila importada a stock y le asigna una puntuación de recomendación.
+// Si la fila no se puede leer o convertir, devuelve ok en false y el rechazo con la línea del archivo.
+func (s *service) processImportRow(row importRow) (domain.Stock, domain.SyncReject, bool) {
+	err := row.err
+	var stock domain.Stock
+	if err == nil {
+		stock, err = providers.ParseItem(row.item)
+	}
+	if err != nil {
+		log.Printf("Línea %d: error parseando stock: %v", row.line, err)
+		return domain.Stock{}, domain.SyncReject{
+			Source:  domain.RejectSourceImport,
+			Line:    row.line,
+			Error:   err.Error(),
+			RawItem: row.item,
+		}, false
+	}
+
+	stock.RecommendScore = s.recommendationScore(stock)
+	return stock, domain.SyncReject{}, true
+}
+
+// readImportRows lee las filas de un archivo en el formato indicado y entrega cada una a fn.
+// La lectura se detiene con el primer error devuelto por fn.
+func readImportRows(r io.Reader, format string, fn func(importRow) error) error {
+	switch format {
+	case domain.ImportFormatCSV:
+		return readCSVRows(r, fn)
+	case domain.ImportFormatNDJSON:
+		return readNDJSONRows(r, fn)
+	default:
+		return fmt.Errorf("%w: formato %q desconocido, debe ser csv o ndjson", domain.ErrInvalidImport, format)
+	}
+}
+
+// readCSVRows lee un archivo CSV con encabezado. Las columnas usan los nombres de los campos de la API externa
+// (ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, currency, time);
+// las demás se ignoran. Un error de sintaxis invalida el archivo completo.
+func readCSVRows(r io.Reader, fn func(importRow) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: el archivo CSV está vacío", domain.ErrInvalidImport)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+	}
+
+	columns := make([]string, len(header))
+	hasTicker := false
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		hasTicker = hasTicker || columns[i] == "ticker"
+	}
+	if !hasTicker {
+		return fmt.Errorf("%w: el encabezado del CSV no tiene la columna ticker", domain.ErrInvalidImport)
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		item := make(map[string]interface{}, len(columns))
+		for i, value := range record {
+			if i < len(columns) && columns[i] != "" {
+				item[columns[i]] = value
+			}
+		}
+		if err := fn(importRow{line: line, item: item}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readNDJSONRows lee un archivo con un objeto JSON por línea. Las líneas vacías se ignoran y las que
+// no son un objeto JSON válido se entregan como filas con error, con su contenido en el campo line.
+func readNDJSONRows(r io.Reader, fn func(importRow) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var item map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &item); err != nil || item == nil {
+			if err == nil {
+				err = errors.New("la línea no es un objeto JSON")
+			}
+			row := importRow{
+				line: line,
+				item: map[string]interface{}{"line": text},
+				err:  fmt.Errorf("error parseando JSON: %w", err),
+			}
+			if err := fn(row); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(importRow{line: line, item: item}); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+	}
+	return nil
+}
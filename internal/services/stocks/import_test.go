@@ -0,0 +1,221 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestImportStocks_CSV verifica la conversión de las filas CSV y el registro de las filas inválidas con su línea
+func TestImportStocks_CSV(t *testing.T) {
+	content := "Ticker, Company, Brokerage, Action, Rating_From, Rating_To, Target_From, Target_To, Extra\n" +
+		"AAPL,Apple Inc.,Example,upgraded by,Hold,Buy,$150.00,$180.00,x\n" +
+		"MSFT,Microsoft,Example,reiterated by,Buy,Buy,$300.00,n/a,x\n" +
+		"\"NVDA\",\"Nvidia, Corp\",Example,target raised by,Buy,Buy,\"$1,000.00\",\"$1,200.00\"\n"
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("SaveSyncRejects", mock.MatchedBy(func(rejects []domain.SyncReject) bool {
+		return len(rejects) == 1 && rejects[0].Source == domain.RejectSourceImport && rejects[0].Line == 3 && rejects[0].Iteration == 0 &&
+			rejects[0].RawItem["ticker"] == "MSFT" && strings.Contains(rejects[0].Error, "target_to")
+	})).Return(nil)
+	mockRepo.On("UpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool {
+		return len(stocks) == 2 && stocks[0].Ticker == "AAPL" && stocks[0].RecommendScore != 0 &&
+			stocks[1].Company == "Nvidia, Corp" && stocks[1].TargetTo == 1200
+	}), false, int64(1)).Return(domain.SyncResult{Inserted: 1, Updated: 1}, nil)
+	expectImportRun(mockRepo)
+
+	service := &service{repo: mockRepo, cfg: createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)}
+
+	result, err := service.ImportStocks(context.Background(), strings.NewReader(content), domain.ImportOptions{Format: domain.ImportFormatCSV})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportModeAppend, result.Mode)
+	assert.Equal(t, 3, result.RowsRead)
+	assert.Equal(t, 2, result.ItemsParsed)
+	assert.Equal(t, 1, result.ItemsRejected)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, 1, result.Updated)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportStocks_NDJSONReplace verifica la lectura NDJSON, las líneas inválidas y la eliminación de los ausentes con replace
+func TestImportStocks_NDJSONReplace(t *testing.T) {
+	content := `{"ticker": "AAPL", "brokerage": "Example", "target_from": "$150.00", "target_to": "$180.00"}
+
+not json
+{"ticker": "MSFT", "brokerage": "Example", "target_from": "$300.00", "target_to": "$350.00", "time": "2025-01-13T00:30:05Z"}
+`
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("SaveSyncRejects", mock.MatchedBy(func(rejects []domain.SyncReject) bool {
+		return len(rejects) == 1 && rejects[0].Line == 3 && rejects[0].Iteration == 0 && rejects[0].RawItem["line"] == "not json"
+	})).Return(nil)
+	mockRepo.On("UpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool {
+		return len(stocks) == 2 && !stocks[1].RatedAt.IsZero()
+	}), false, int64(1)).Return(domain.SyncResult{Inserted: 2}, nil)
+	mockRepo.On("RemoveMissingStocks", int64(1)).Return(5, nil)
+	expectImportRun(mockRepo)
+
+	service := &service{repo: mockRepo, cfg: createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)}
+
+	result, err := service.ImportStocks(context.Background(), strings.NewReader(content),
+		domain.ImportOptions{Format: domain.ImportFormatNDJSON, Mode: domain.ImportModeReplace})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.RowsRead)
+	assert.Equal(t, 2, result.ItemsParsed)
+	assert.Equal(t, 1, result.ItemsRejected)
+	assert.Equal(t, 5, result.Removed)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportStocks_Batches verifica que los stocks se guarden en lotes a medida que se leen, que una fila repetida
+// se guarde una sola vez y que replace elimine los ausentes después del último lote
+func TestImportStocks_Batches(t *testing.T) {
+	content := "ticker,brokerage,target_from,target_to\n" +
+		"AAPL,Example,$1.00,$2.00\n" +
+		"AAPL,Example,$1.00,$2.00\n" +
+		"MSFT,Example,$3.00,$4.00\n" +
+		"NVDA,Example,n/a,$4.00\n"
+
+	var calls []string
+	mockRepo := new(MockRepository)
+	mockRepo.On("UpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool { return len(stocks) == 1 }), false, int64(1)).
+		Run(func(args mock.Arguments) {
+			calls = append(calls, "upsert "+args.Get(0).([]domain.Stock)[0].Ticker)
+		}).
+		Return(domain.SyncResult{Inserted: 1}, nil)
+	mockRepo.On("RemoveMissingStocks", int64(1)).
+		Run(func(args mock.Arguments) { calls = append(calls, "remove") }).
+		Return(3, nil)
+	mockRepo.On("SaveSyncRejects", mock.Anything).Return(nil)
+	mockRepo.On("CreateSyncRun", mock.Anything).
+		Run(func(args mock.Arguments) { args.Get(0).(*domain.SyncRun).ID = 1 }).
+		Return(nil)
+	mockRepo.On("SaveSyncRun", mock.MatchedBy(func(run *domain.SyncRun) bool {
+		return run.Inserted == 2 && run.Removed == 3 && run.Error == ""
+	})).Return(nil)
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	cfg.SyncBatchSize = 1
+	service := &service{repo: mockRepo, cfg: cfg}
+
+	result, err := service.ImportStocks(context.Background(), strings.NewReader(content),
+		domain.ImportOptions{Format: domain.ImportFormatCSV, Mode: domain.ImportModeReplace})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"upsert AAPL", "upsert MSFT", "remove"}, calls)
+	assert.Equal(t, 3, result.ItemsParsed)
+	assert.Equal(t, 1, result.ItemsRejected)
+	assert.Equal(t, 2, result.Inserted)
+	assert.Equal(t, 3, result.Removed)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportStocks_BatchError verifica que, si falla un lote, se conserven los anteriores, no se eliminen
+// los stocks ausentes y la ejecución registre el error
+func TestImportStocks_BatchError(t *testing.T) {
+	content := "ticker,target_from,target_to\nAAPL,$1.00,$2.00\nMSFT,$3.00,$4.00\n"
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{Inserted: 1}, nil).Once()
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{}, errors.New("error de base de datos")).Once()
+	mockRepo.On("CreateSyncRun", mock.Anything).
+		Run(func(args mock.Arguments) { args.Get(0).(*domain.SyncRun).ID = 1 }).
+		Return(nil)
+	mockRepo.On("SaveSyncRun", mock.MatchedBy(func(run *domain.SyncRun) bool {
+		return run.Inserted == 1 && strings.Contains(run.Error, "error guardando stocks")
+	})).Return(nil)
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	cfg.SyncBatchSize = 1
+	service := &service{repo: mockRepo, cfg: cfg}
+
+	result, err := service.ImportStocks(context.Background(), strings.NewReader(content),
+		domain.ImportOptions{Format: domain.ImportFormatCSV, Mode: domain.ImportModeReplace})
+
+	assert.ErrorContains(t, err, "error guardando stocks")
+	assert.Equal(t, 1, result.Inserted)
+	mockRepo.AssertNotCalled(t, "RemoveMissingStocks", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestImportStocks_NoValidRows verifica que un archivo sin filas válidas no modifique los stocks, ni siquiera con replace
+func TestImportStocks_NoValidRows(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("SaveSyncRejects", mock.Anything).Return(nil)
+
+	service := &service{repo: mockRepo, cfg: createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)}
+
+	result, err := service.ImportStocks(context.Background(), strings.NewReader("ticker,target_to\nAAPL,n/a\n"),
+		domain.ImportOptions{Format: domain.ImportFormatCSV, Mode: domain.ImportModeReplace})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.ItemsRejected)
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateSyncRun", mock.Anything)
+}
+
+// TestImportStocks_Canceled verifica que una importación cancelada, por ejemplo porque el cliente se desconectó,
+// no modifique la base de datos
+func TestImportStocks_Canceled(t *testing.T) {
+	mockRepo := new(MockRepository)
+	service := &service{repo: mockRepo, cfg: createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := service.ImportStocks(ctx, strings.NewReader("ticker,target_from,target_to\nAAPL,$1.00,$2.00\n"),
+		domain.ImportOptions{Format: domain.ImportFormatCSV, Mode: domain.ImportModeReplace})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	mockRepo.AssertNotCalled(t, "SaveSyncRejects", mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestImportStocks_Invalid verifica que las opciones y los archivos inválidos se rechacen sin modificar la base de datos
+func TestImportStocks_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		opts    domain.ImportOptions
+	}{
+		{name: "modo desconocido", content: "ticker\nAAPL\n", opts: domain.ImportOptions{Format: domain.ImportFormatCSV, Mode: "merge"}},
+		{name: "formato desconocido", content: "ticker\nAAPL\n", opts: domain.ImportOptions{Format: "xml"}},
+		{name: "CSV vacío", content: "", opts: domain.ImportOptions{Format: domain.ImportFormatCSV}},
+		{name: "CSV sin columna ticker", content: "company\nApple\n", opts: domain.ImportOptions{Format: domain.ImportFormatCSV}},
+		{name: "CSV mal formado", content: "ticker\n\"AAPL\n", opts: domain.ImportOptions{Format: domain.ImportFormatCSV}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			service := &service{repo: mockRepo, cfg: createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)}
+
+			_, err := service.ImportStocks(context.Background(), strings.NewReader(tt.content), tt.opts)
+
+			assert.True(t, errors.Is(err, domain.ErrInvalidImport), "error inesperado: %v", err)
+			mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "SaveSyncRejects", mock.Anything)
+		})
+	}
+}
+
+// expectImportRun configura el registro de la ejecución de una importación con ID 1 y verifica sus totales
+func expectImportRun(mockRepo *MockRepository) {
+	mockRepo.On("CreateSyncRun", mock.MatchedBy(func(run *domain.SyncRun) bool {
+		return run.Source == domain.SyncRunSourceImport
+	})).
+		Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.SyncRun).ID = 1
+		}).
+		Return(nil)
+	mockRepo.On("SaveSyncRun", mock.MatchedBy(func(run *domain.SyncRun) bool {
+		return run.FinishedAt != nil && run.ItemsParsed == 2 && run.ItemsRejected == 1 && run.Error == ""
+	})).Return(nil)
+}
@@ -0,0 +1,132 @@
+package stocks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// rescoreBatchSize es la cantidad de stocks que se recalculan y guardan por lote
+const rescoreBatchSize = 500
+
+// rescoreStocks recalcula el puntaje de recomendación de todos los stocks guardados
+// con los factores de recomendación actuales, sin volver a descargar los datos.
+// No se ejecuta en paralelo con una sincronización. Cada lote se guarda en su propia transacción:
+// si el recálculo se interrumpe, los lotes ya guardados conservan los puntajes nuevos y basta con repetirlo.
+func (s *service) rescoreStocks(ctx context.Context) (domain.RescoreResult, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	log.Println("🔄 Recalculando puntajes de recomendación")
+
+	var result domain.RescoreResult
+	var before, after []float64
+	var lastID int64
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		batch, err := s.repo.GetStocksAfterID(lastID, rescoreBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("error obteniendo stocks: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		// Recalcular el lote y guardar solo los puntajes que cambiaron
+		changed := make(map[int64]float64)
+		for _, stock := range batch {
+			score := s.recommendationScore(stock)
+			before = append(before, stock.RecommendScore)
+			after = append(after, score)
+			if score != stock.RecommendScore {
+				changed[stock.ID] = score
+			}
+		}
+
+		if err := s.repo.UpdateStockScores(changed); err != nil {
+			return result, fmt.Errorf("error guardando puntajes: %w", err)
+		}
+
+		result.Processed += len(batch)
+		result.Changed += len(changed)
+		result.Batches++
+		lastID = batch[len(batch)-1].ID
+	}
+
+	result.Before = scoreDistribution(before)
+	result.After = scoreDistribution(after)
+
+	log.Printf("✅ Recálculo completado: %d stocks evaluados, %d con puntaje modificado", result.Processed, result.Changed)
+	return result, nil
+}
+
+// queueRescore solicita un recálculo en segundo plano sin bloquear.
+// Si ya hay uno pendiente, la solicitud se combina con él.
+func (s *service) queueRescore() {
+	select {
+	case s.rescoreQueue <- struct{}{}:
+	default:
+	}
+}
+
+// runRescore ejecuta un recálculo en el worker aplicando el timeout de sincronización
+func (s *service) runRescore(ctx context.Context) (domain.RescoreResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.SyncTimeout)*time.Second)
+	defer cancel()
+
+	return s.rescoreStocks(ctx)
+}
+
+// runQueuedRescore ejecuta un recálculo automático solicitado con queueRescore
+func (s *service) runQueuedRescore(ctx context.Context) {
+	if _, err := s.runRescore(ctx); err != nil {
+		log.Printf("❌ Error en el recálculo automático de puntajes: %v", err)
+	}
+}
+
+// scoreDistribution calcula el resumen estadístico de un conjunto de puntajes
+func scoreDistribution(scores []float64) domain.ScoreDistribution {
+	if len(scores) == 0 {
+		return domain.ScoreDistribution{}
+	}
+
+	sorted := append([]float64(nil), scores...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, score := range sorted {
+		sum += score
+	}
+
+	return domain.ScoreDistribution{
+		Count:  len(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   sum / float64(len(sorted)),
+		P25:    percentile(sorted, 0.25),
+		Median: percentile(sorted, 0.50),
+		P75:    percentile(sorted, 0.75),
+	}
+}
+
+// percentile calcula el percentil p (0-1) de valores ordenados con interpolación lineal
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	pos := p * float64(len(sorted)-1)
+	lower := int(pos)
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	fraction := pos - float64(lower)
+	return sorted[lower] + (sorted[lower+1]-sorted[lower])*fraction
+}
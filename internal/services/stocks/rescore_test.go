@@ -0,0 +1,74 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRescoreStocks_UpdatesChangedScores prueba que solo se guarden los puntajes que cambian
+func TestRescoreStocks_UpdatesChangedScores(t *testing.T) {
+	svc := &service{cfg: &config.Config{}}
+
+	unchanged := domain.Stock{ID: 1, Ticker: "AAPL", Action: "upgraded by", RatingTo: "Buy", TargetFrom: 100, TargetTo: 120}
+	unchanged.RecommendScore = svc.recommendationScore(unchanged)
+	stale := domain.Stock{ID: 2, Ticker: "MSFT", Action: "reiterated by", RatingTo: "Hold", TargetFrom: 100, TargetTo: 105, RecommendScore: 99}
+	expected := svc.recommendationScore(stale)
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStocksAfterID", int64(0), rescoreBatchSize).Return([]domain.Stock{unchanged, stale}, nil)
+	mockRepo.On("GetStocksAfterID", int64(2), rescoreBatchSize).Return([]domain.Stock{}, nil)
+	mockRepo.On("UpdateStockScores", map[int64]float64{2: expected}).Return(nil)
+	svc.repo = mockRepo
+
+	result, err := svc.rescoreStocks(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Processed)
+	assert.Equal(t, 1, result.Changed)
+	assert.Equal(t, 1, result.Batches)
+	assert.Equal(t, 2, result.Before.Count)
+	assert.Equal(t, 99.0, result.Before.Max)
+	assert.Equal(t, unchanged.RecommendScore, result.Before.Min)
+	assert.InDelta(t, (unchanged.RecommendScore+expected)/2, result.After.Mean, 1e-9)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestRescoreStocks_RepositoryError prueba que un error al guardar detenga el recálculo
+func TestRescoreStocks_RepositoryError(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStocksAfterID", int64(0), rescoreBatchSize).Return([]domain.Stock{{ID: 1, TargetFrom: 10, TargetTo: 20}}, nil)
+	mockRepo.On("UpdateStockScores", mock.Anything).Return(errors.New("error de base de datos"))
+
+	svc := &service{repo: mockRepo, cfg: &config.Config{}}
+
+	_, err := svc.rescoreStocks(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error guardando puntajes")
+	mockRepo.AssertNumberOfCalls(t, "GetStocksAfterID", 1)
+}
+
+// TestScoreDistribution verifica el resumen estadístico de los puntajes
+func TestScoreDistribution(t *testing.T) {
+	distribution := scoreDistribution([]float64{4, 1, 3, 2, 5})
+
+	assert.Equal(t, domain.ScoreDistribution{Count: 5, Min: 1, Max: 5, Mean: 3, P25: 2, Median: 3, P75: 4}, distribution)
+	assert.Equal(t, 2.5, scoreDistribution([]float64{1, 2, 3, 4}).Median)
+	assert.Equal(t, domain.ScoreDistribution{}, scoreDistribution(nil))
+}
+
+// TestQueueRescore prueba que las solicitudes de recálculo pendientes se combinen en una sola
+func TestQueueRescore(t *testing.T) {
+	svc := &service{rescoreQueue: make(chan struct{}, 1)}
+
+	svc.queueRescore()
+	svc.queueRescore()
+
+	assert.Len(t, svc.rescoreQueue, 1)
+}
@@ -0,0 +1,17 @@
+package stocks
+
+import (
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// ExplainScore devuelve el desglose del puntaje de recomendación de un stock.
+// El cálculo usa los factores de recomendación actuales; stored_score conserva
+// el puntaje guardado en la última sincronización para poder compararlos.
+func (s *service) ExplainScore(id int64) (domain.ScoreBreakdown, error) {
+	stock, err := s.repo.GetStockByID(id)
+	if err != nil {
+		return domain.ScoreBreakdown{}, err
+	}
+
+	return s.explainScore(stock), nil
+}
@@ -0,0 +1,87 @@
+package stocks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExplainScore_Breakdown verifica que el desglose reproduzca el puntaje y registre cada paso
+func TestExplainScore_Breakdown(t *testing.T) {
+	stock := domain.Stock{
+		ID:             7,
+		Ticker:         "DOWN",
+		Brokerage:      "Example Broker",
+		Action:         "downgraded by",
+		RatingFrom:     "Buy",
+		RatingTo:       "Sell",
+		TargetFrom:     100.0,
+		TargetTo:       80.0,
+		RecommendScore: -1.5,
+	}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStockByID", int64(7)).Return(stock, nil)
+
+	factors := config.DefaultRecommendationFactors()
+	factors.Companies = map[string]float64{"DOWN": 10}
+	factors.Brokerages = map[string]float64{"Example Broker": -50}
+
+	cfg := &config.Config{}
+	cfg.SetRecommendationFactors(factors)
+
+	svc := &service{
+		repo: mockRepo,
+		cfg:  cfg,
+	}
+
+	breakdown, err := svc.ExplainScore(7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), breakdown.StockID)
+	assert.Equal(t, -1.5, breakdown.StoredScore)
+	assert.Equal(t, svc.recommendationScore(stock), breakdown.FinalScore)
+
+	// Componentes base y ajustados por los factores externos
+	assert.InDelta(t, -24.0, breakdown.Components.PercentDiff, 1e-9)
+	assert.Equal(t, -20.0, breakdown.Components.Rating)
+	assert.Equal(t, -12.0, breakdown.Components.Action)
+	assert.InDelta(t, -26.4, breakdown.Adjusted.PercentDiff, 1e-9)
+	assert.Equal(t, -10.0, breakdown.Adjusted.Rating)
+	assert.Len(t, breakdown.Factors, 2)
+	assert.Equal(t, "company", breakdown.Factors[0].Source)
+	assert.Equal(t, "brokerage", breakdown.Factors[1].Source)
+	assert.Equal(t, 0.5, breakdown.Factors[1].Multiplier)
+
+	// Ponderaciones y aportes
+	assert.Equal(t, factors.Weights.PercentDiff, breakdown.Weights.PercentDiff)
+	assert.InDelta(t, breakdown.WeightedScore,
+		breakdown.Contributions.PercentDiff+breakdown.Contributions.Rating+
+			breakdown.Contributions.Action+breakdown.Contributions.AbsoluteBonus, 1e-9)
+
+	// El precio objetivo baja, pero el puntaje es negativo: solo aplica el primer modificador
+	assert.Len(t, breakdown.Modifiers, 2)
+	assert.Equal(t, domain.ScoreModifierDecreasingTarget, breakdown.Modifiers[0].Name)
+	assert.True(t, breakdown.Modifiers[0].Applied)
+	assert.InDelta(t, breakdown.WeightedScore*factors.Modifiers.DecreasingTarget, breakdown.Modifiers[0].ScoreAfter, 1e-9)
+	assert.Equal(t, domain.ScoreModifierNegativeRating, breakdown.Modifiers[1].Name)
+	assert.False(t, breakdown.Modifiers[1].Applied)
+	assert.Equal(t, breakdown.FinalScore, breakdown.Modifiers[1].ScoreAfter)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// TestExplainScore_NotFound verifica que se propague el error del repositorio
+func TestExplainScore_NotFound(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetStockByID", int64(99)).Return(domain.Stock{}, domain.ErrNotFound)
+
+	svc := &service{repo: mockRepo, cfg: &config.Config{}}
+
+	_, err := svc.ExplainScore(99)
+
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
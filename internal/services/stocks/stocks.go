@@ -2,34 +2,119 @@ package stocks
 
 import (
 	"context"
+	"io"
+	"sync"
 
 	"github.com/julianloaiza/stock-advisor/config"
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 	repo "github.com/julianloaiza/stock-advisor/internal/repositories/stocks"
-	"github.com/julianloaiza/stock-advisor/internal/services/apiClient"
+	"github.com/julianloaiza/stock-advisor/internal/services/factors"
+	"github.com/julianloaiza/stock-advisor/internal/services/providers"
+	"go.uber.org/fx"
 )
 
 // Service define las operaciones relacionadas con stocks.
 type Service interface {
 	// SyncStocks sincroniza la base de datos con la API externa.
-	SyncStocks(ctx context.Context, limit int) error
+	// Devuelve el resumen de stocks insertados, actualizados, sin cambios y eliminados.
+	SyncStocks(ctx context.Context, opts domain.SyncOptions) (domain.SyncResult, error)
 
-	// GetStocks realiza una búsqueda con query y paginación.
-	GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error)
+	// ImportStocks importa stocks desde un archivo CSV o NDJSON, agregándolos o reemplazando los existentes.
+	ImportStocks(ctx context.Context, r io.Reader, opts domain.ImportOptions) (domain.ImportResult, error)
+
+	// EnqueueSync encola una sincronización, o su dry run, para ejecutarse en segundo plano.
+	EnqueueSync(opts domain.SyncOptions) (domain.SyncJob, error)
+
+	// GetSyncJob obtiene el estado de un job de sincronización.
+	GetSyncJob(id int64) (domain.SyncJob, error)
+
+	// GetSyncRejects obtiene los elementos rechazados durante las sincronizaciones, con paginación.
+	GetSyncRejects(page, size int) ([]domain.SyncReject, int64, error)
+
+	// GetSyncRuns obtiene el historial de ejecuciones de la sincronización, con paginación.
+	GetSyncRuns(page, size int) ([]domain.SyncRun, int64, error)
+
+	// GetSyncRunDiff compara las acciones observadas por una ejecución con las de la ejecución anterior.
+	GetSyncRunDiff(id int64) (domain.SyncRunDiff, error)
+
+	// GetStocks realiza una búsqueda con filtros y paginación.
+	GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error)
+
+	// GetStocksByCursor obtiene una página de stocks con paginación por cursor y, si se solicita, el total.
+	GetStocksByCursor(filter domain.StockFilter) (domain.StockPage, error)
+
+	// ExportStocks recorre todos los stocks que cumplen el filtro, sin paginación, entregando cada uno a fn.
+	ExportStocks(ctx context.Context, filter domain.StockFilter, fn func(domain.Stock) error) error
+
+	// GetTickers obtiene el resumen de consenso por ticker de los stocks filtrados, con paginación.
+	GetTickers(filter domain.StockFilter) ([]domain.TickerSummary, int64, error)
+
+	// GetTicker obtiene el resumen de consenso de un ticker y las acciones de los brokerages que lo cubren.
+	GetTicker(ticker string, filter domain.StockFilter) (domain.TickerDetail, error)
+
+	// GetTickerHistory obtiene el historial de calificaciones de un ticker, opcionalmente de un solo brokerage, con paginación.
+	GetTickerHistory(ticker, brokerage string, page, size int) ([]domain.StockRatingHistory, int64, error)
+
+	// GetBrokerages obtiene el resumen de comportamiento por brokerage de los stocks filtrados, con paginación.
+	GetBrokerages(filter domain.StockFilter) ([]domain.BrokerageSummary, int64, error)
+
+	// GetBrokerageStocks obtiene las acciones de un brokerage, con filtros y paginación.
+	GetBrokerageStocks(name string, filter domain.StockFilter) ([]domain.Stock, int64, error)
+
+	// EnqueueRescore encola un recálculo del puntaje de recomendación de los stocks guardados con los factores actuales.
+	EnqueueRescore() (domain.SyncJob, error)
+
+	// ExplainScore devuelve el desglose del puntaje de recomendación de un stock.
+	ExplainScore(id int64) (domain.ScoreBreakdown, error)
 }
 
 // service implementa la interfaz Service.
 type service struct {
-	repo      repo.Repository
-	cfg       *config.Config
-	apiClient apiClient.Client
+	repo     repo.Repository
+	cfg      *config.Config
+	provider providers.Provider // Fuente de datos de la sincronización
+
+	// syncMu serializa las sincronizaciones (jobs y ejecuciones programadas)
+	syncMu sync.Mutex
+
+	// Cola y control del worker de jobs de sincronización
+	syncQueue  chan int64
+	stopWorker context.CancelFunc
+	workerDone chan struct{}
+
+	// rescoreQueue recibe las solicitudes de recálculo tras recargar los factores; varias seguidas se combinan en una
+	rescoreQueue chan struct{}
 }
 
 // New crea una nueva instancia del servicio de stocks.
-func New(repo repo.Repository, cfg *config.Config, apiClient apiClient.Client) Service {
-	return &service{
-		repo:      repo,
-		cfg:       cfg,
-		apiClient: apiClient,
+// El worker de sincronización se inicia y detiene junto con la aplicación.
+// Si RECOMMENDATION_FACTORS_AUTO_RESCORE está habilitado, los puntajes se recalculan cuando cambian los factores.
+func New(lc fx.Lifecycle, repo repo.Repository, cfg *config.Config, provider providers.Provider, factorsService factors.Service) Service {
+	s := &service{
+		repo:         repo,
+		cfg:          cfg,
+		provider:     provider,
+		syncQueue:    make(chan int64, syncQueueSize),
+		rescoreQueue: make(chan struct{}, 1),
 	}
+
+	factorsService.OnChange(func() {
+		if cfg.RecommendationFactorsAutoRescore {
+			s.queueRescore()
+		}
+	})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if cfg.SyncWorkerDisabled {
+				return nil
+			}
+			return s.startSyncWorker()
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.stopSyncWorker(ctx)
+		},
+	})
+
+	return s
 }
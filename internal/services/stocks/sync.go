@@ -2,7 +2,6 @@ package stocks
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"time"
@@ -11,94 +10,202 @@ import (
 )
 
 // SyncStocks sincroniza la base de datos con la API externa.
-func (s *service) SyncStocks(ctx context.Context, limit int) error {
+func (s *service) SyncStocks(ctx context.Context, opts domain.SyncOptions) (domain.SyncResult, error) {
+	return s.runSync(ctx, opts, nil)
+}
+
+// runSync ejecuta la sincronización aplicando el timeout configurado y la registra en el historial de ejecuciones.
+// Solo se ejecuta una sincronización a la vez; las demás esperan su turno.
+// onProgress, si no es nil, se invoca después de procesar cada página.
+func (s *service) runSync(ctx context.Context, opts domain.SyncOptions, onProgress func(domain.SyncResult)) (domain.SyncResult, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
 	// Crear un contexto con timeout
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.SyncTimeout)*time.Second)
 	defer cancel()
 
+	// Registrar la ejecución; el historial de calificaciones se asocia a ella
+	run := domain.SyncRun{
+		Source:         domain.SyncRunSourceSync,
+		RequestedLimit: opts.Limit,
+		Resume:         opts.Resume,
+		StartedAt:      time.Now(),
+	}
+	if err := s.repo.CreateSyncRun(&run); err != nil {
+		return domain.SyncResult{}, fmt.Errorf("error registrando la ejecución de la sincronización: %w", err)
+	}
+
 	// Ejecutar sincronización
-	return s.syncStocks(ctx, limit)
+	result, err := s.syncStocks(ctx, opts, &run, onProgress)
+	s.finishSyncRun(&run, result, err)
+	return result, err
 }
 
-// syncStocks es la implementación principal de la sincronización
-func (s *service) syncStocks(ctx context.Context, limit int) error {
+// syncState es el estado acumulado de una sincronización; puede reconstruirse desde los checkpoints.
+type syncState struct {
+	iteration  int // Última iteración completada
+	nextPage   string
+	seenTokens map[string]bool
+	stocks     []domain.Stock // Stocks recuperados de los checkpoints, pendientes de guardar
+	progress   domain.SyncResult
+	done       bool   // La paginación terminó y solo resta guardar los stocks
+	stopReason string // Motivo por el que terminó la paginación
+}
+
+// newSyncState crea el estado de una sincronización que empieza desde la primera página
+func newSyncState() *syncState {
+	return &syncState{
+		seenTokens: make(map[string]bool),
+	}
+}
+
+// apply incorpora al estado una página procesada
+func (st *syncState) apply(checkpoint domain.SyncCheckpoint) {
+	st.iteration = checkpoint.Iteration
+
+	st.progress.PagesFetched++
+	st.progress.ItemsParsed += len(checkpoint.Stocks)
+	st.progress.ItemsRejected += checkpoint.ItemsRejected
+
+	st.done = checkpoint.Final
+	switch {
+	case !st.done:
+		st.seenTokens[checkpoint.NextPage] = true
+		st.nextPage = checkpoint.NextPage
+	case checkpoint.NextPage == "":
+		st.stopReason = domain.SyncStopEmptyNextPage
+	default:
+		// Una página final con next_page solo se registra al detectar un ciclo
+		st.stopReason = domain.SyncStopCycle
+	}
+}
+
+// addAttempts registra las solicitudes HTTP realizadas para obtener una página
+func (st *syncState) addAttempts(attempts int) {
+	st.progress.APIAttempts += attempts
+	if attempts > 1 {
+		st.progress.APIRetries += attempts - 1
+	}
+}
+
+// syncStocks es la implementación principal de la sincronización.
+// Cada página procesada se guarda como checkpoint para poder reanudarla si falla, y sus stocks se guardan
+// en lotes a medida que avanza la paginación. Al reanudar, los stocks de los checkpoints se vuelven a guardar.
+// El límite efectivo y el motivo de fin de la paginación se registran en run.
+// En caso de error devuelve el progreso alcanzado hasta ese momento.
+func (s *service) syncStocks(ctx context.Context, opts domain.SyncOptions, run *domain.SyncRun, onProgress func(domain.SyncResult)) (domain.SyncResult, error) {
 	// Validar y ajustar el límite de iteraciones
-	limit = s.validateLimit(limit)
-	allStocks := make([]domain.Stock, 0, limit*10)
+	limit := s.validateLimit(opts.Limit)
+	run.EffectiveLimit = limit
 
-	log.Println("🔄 Iniciando sincronización con la API")
+	// Preparar el estado inicial (nuevo o reanudado)
+	state, err := s.prepareSyncState(opts.Resume)
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
 
-	// Variables para control de iteración
-	var nextPage string
-	seenTokens := make(map[string]bool)
+	writer := s.newSyncWriter(run.ID)
+	if err := writer.add(ctx, state.stocks); err != nil {
+		return state.progress, err
+	}
+	state.stocks = nil
 
-	// Iterar para obtener datos paginados
-	for i := 1; i <= limit; i++ {
-		// Obtener datos de la página actual
-		items, newNextPage, err := s.fetchPageData(ctx, nextPage, i)
-		if err != nil {
-			return err
+	// Registrar cada página como checkpoint antes de guardar sus stocks
+	record := func(checkpoint *domain.SyncCheckpoint, rejects []domain.SyncReject) error {
+		if err := s.repo.SaveSyncCheckpoint(checkpoint, rejects); err != nil {
+			return fmt.Errorf("error guardando checkpoint de la iteración %d: %w", checkpoint.Iteration, err)
 		}
+		return writer.add(ctx, checkpoint.Stocks)
+	}
 
-		// Procesar elementos
-		pageStocks := s.processPageItems(items, i)
-		allStocks = append(allStocks, pageStocks...)
+	if err := s.runSyncPipeline(ctx, state, limit, record, onProgress); err != nil {
+		return mergeSyncChanges(state.progress, writer.result), err
+	}
+	run.StopReason = state.stopReason
 
-		// Verificar si debemos terminar la sincronización
-		if s.shouldTerminateSync(newNextPage, seenTokens) {
-			break
-		}
+	// Guardar el último lote y eliminar los stocks ausentes si se recorrieron todas las páginas
+	changes, err := s.finishWrites(ctx, writer, state.done)
+	if err != nil {
+		return mergeSyncChanges(state.progress, writer.result), err
+	}
 
-		// Preparar siguiente iteración
-		seenTokens[newNextPage] = true
-		nextPage = newNextPage
+	// La sincronización terminó; los checkpoints ya no son necesarios
+	if err := s.repo.ClearSyncCheckpoints(); err != nil {
+		log.Printf("No se pudieron eliminar los checkpoints de sincronización: %v", err)
 	}
 
-	// Guardar en base de datos
-	return s.replaceAllStocks(allStocks)
+	return mergeSyncChanges(state.progress, changes), nil
 }
 
-// fetchPageData obtiene los datos de una página de la API
-func (s *service) fetchPageData(ctx context.Context, nextPage string, iteration int) ([]map[string]interface{}, string, error) {
-	// Preparar parámetros para la solicitud
-	params := make(map[string]string)
-	if nextPage != "" {
-		params["next_page"] = nextPage
+// prepareSyncState devuelve el estado inicial de la sincronización.
+// Si resume es true, reconstruye el estado desde los checkpoints pendientes;
+// en caso contrario descarta los checkpoints de sincronizaciones anteriores.
+func (s *service) prepareSyncState(resume bool) (*syncState, error) {
+	state := newSyncState()
+
+	if !resume {
+		if err := s.repo.ClearSyncCheckpoints(); err != nil {
+			return nil, fmt.Errorf("error eliminando checkpoints anteriores: %w", err)
+		}
+		return state, nil
 	}
 
-	// Realizar solicitud a la API
-	responseData, err := s.apiClient.Get(ctx, "", params)
+	checkpoints, err := s.repo.GetSyncCheckpoints()
 	if err != nil {
-		return nil, "", fmt.Errorf("error en iteración %d: %w", iteration, err)
+		return nil, fmt.Errorf("error obteniendo checkpoints: %w", err)
+	}
+
+	if len(checkpoints) == 0 {
+		log.Println("No hay una sincronización pendiente de reanudar. Se iniciará desde la primera página.")
+		return state, nil
 	}
 
-	// Definir estructura para la respuesta
-	var result struct {
-		Items    []map[string]interface{} `json:"items"`
-		NextPage string                   `json:"next_page"`
+	for _, checkpoint := range checkpoints {
+		state.apply(checkpoint)
+		state.stocks = append(state.stocks, checkpoint.Stocks...)
 	}
+	state.progress.PagesResumed = len(checkpoints)
 
-	// Deserializar la respuesta
-	if err := json.Unmarshal(responseData, &result); err != nil {
-		return nil, "", fmt.Errorf("error parseando JSON en iteración %d: %w", iteration, err)
+	log.Printf("⏩ Reanudando sincronización tras la iteración %d (%d stocks recuperados)",
+		state.iteration, len(state.stocks))
+	return state, nil
+}
+
+// fetchPageData obtiene los datos de una página de la fuente configurada.
+// También devuelve la cantidad de intentos realizados, incluso si la solicitud falla.
+func (s *service) fetchPageData(ctx context.Context, nextPage string, iteration int) ([]map[string]interface{}, string, int, error) {
+	page, err := s.provider.FetchPage(ctx, nextPage)
+	if err != nil {
+		return nil, "", page.Attempts, fmt.Errorf("error en iteración %d: %w", iteration, err)
 	}
 
-	log.Printf("Iteración %d: next_page value = %s", iteration, result.NextPage)
-	return result.Items, result.NextPage, nil
+	log.Printf("Iteración %d: next_page value = %s", iteration, page.NextPage)
+	return page.Items, page.NextPage, page.Attempts, nil
 }
 
-// processPageItems procesa los elementos de una página y los convierte a stocks
-func (s *service) processPageItems(items []map[string]interface{}, iteration int) []domain.Stock {
+// processPageItems convierte los elementos de una página a stocks con la fuente configurada y les asigna
+// una puntuación de recomendación. Los elementos que no se pueden convertir se devuelven como rechazos
+// con su contenido original.
+func (s *service) processPageItems(items []map[string]interface{}, iteration int) ([]domain.Stock, []domain.SyncReject) {
 	var pageStocks []domain.Stock
+	var rejects []domain.SyncReject
 	for _, item := range items {
-		stock, err := s.parseStock(item)
+		stock, err := s.provider.ParseItem(item)
 		if err != nil {
 			log.Printf("Iteración %d: error parseando stock: %v", iteration, err)
+			rejects = append(rejects, domain.SyncReject{
+				Source:    domain.RejectSourceSync,
+				Iteration: iteration,
+				Error:     err.Error(),
+				RawItem:   item,
+			})
 			continue // Continuar con el siguiente item en caso de error
 		}
+		stock.RecommendScore = s.recommendationScore(stock)
 		pageStocks = append(pageStocks, stock)
 	}
-	return pageStocks
+	return pageStocks, rejects
 }
 
 // validateLimit valida el parámetro limit y lo ajusta si es necesario.
@@ -133,17 +240,52 @@ func (s *service) shouldTerminateSync(nextPage string, seenTokens map[string]boo
 	return false
 }
 
-// replaceAllStocks reemplaza todos los stocks en la base de datos
-func (s *service) replaceAllStocks(allStocks []domain.Stock) error {
-	if len(allStocks) == 0 {
+// finishWrites guarda el último lote de stocks y, si SYNC_REMOVE_MISSING está habilitado,
+// elimina los stocks que la ejecución no observó. La eliminación solo se aplica si la ejecución llegó a
+// la última página (complete); si se detuvo por el límite, los stocks de las páginas no leídas se conservan.
+func (s *service) finishWrites(ctx context.Context, writer *syncWriter, complete bool) (domain.SyncResult, error) {
+	if err := writer.flush(ctx); err != nil {
+		return domain.SyncResult{}, err
+	}
+
+	if writer.written == 0 {
 		log.Println("No se encontraron stocks para sincronizar.")
-		return nil
+		return domain.SyncResult{}, nil
 	}
 
-	if err := s.repo.ReplaceAllStocks(allStocks); err != nil {
-		return fmt.Errorf("error reemplazando stocks: %w", err)
+	result := writer.result
+	if s.cfg.SyncRemoveMissing && !complete {
+		log.Println("La sincronización no llegó a la última página; no se eliminan los stocks ausentes.")
+	} else if s.cfg.SyncRemoveMissing {
+		removed, err := s.repo.RemoveMissingStocks(writer.runID)
+		if err != nil {
+			return result, fmt.Errorf("error eliminando stocks ausentes: %w", err)
+		}
+		result.Removed = removed
 	}
 
-	log.Printf("Sincronización completada exitosamente. %d stocks sincronizados.", len(allStocks))
-	return nil
+	log.Printf("Sincronización completada exitosamente. %d stocks procesados.", writer.written)
+	return result, nil
+}
+
+// finishSyncRun registra el fin de una ejecución de la sincronización o de una importación con sus totales, haya terminado bien o con error
+func (s *service) finishSyncRun(run *domain.SyncRun, result domain.SyncResult, err error) {
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.SyncResult = result
+	if err != nil {
+		run.Error = err.Error()
+	}
+	if err := s.repo.SaveSyncRun(run); err != nil {
+		log.Printf("No se pudo registrar el fin de la ejecución de sincronización %d: %v", run.ID, err)
+	}
+}
+
+// mergeSyncChanges combina el progreso de la sincronización con los cambios aplicados en la base de datos
+func mergeSyncChanges(progress, changes domain.SyncResult) domain.SyncResult {
+	progress.Inserted = changes.Inserted
+	progress.Updated = changes.Updated
+	progress.Unchanged = changes.Unchanged
+	progress.Removed = changes.Removed
+	return progress
 }
@@ -0,0 +1,215 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// syncQueueSize es la cantidad máxima de jobs de sincronización en espera
+const syncQueueSize = 10
+
+// errSyncJobInterrupted se asigna a los jobs que estaban en ejecución al reiniciar el servidor
+var errSyncJobInterrupted = errors.New("sincronización interrumpida por un reinicio del servidor")
+
+// EnqueueSync registra un job de sincronización y lo encola para ejecutarse en segundo plano.
+// Con opts.DryRun el job solo calcula lo que cambiaría y lo guarda en su resumen.
+func (s *service) EnqueueSync(opts domain.SyncOptions) (domain.SyncJob, error) {
+	job := domain.SyncJob{
+		Kind:   domain.SyncJobKindSync,
+		State:  domain.SyncJobQueued,
+		Limit:  opts.Limit,
+		Resume: opts.Resume,
+		DryRun: opts.DryRun,
+	}
+
+	if err := s.enqueueSyncJob(&job); err != nil {
+		return domain.SyncJob{}, err
+	}
+
+	log.Printf("Job de sincronización %d encolado (limit=%d, resume=%t, dryRun=%t)", job.ID, opts.Limit, opts.Resume, opts.DryRun)
+	return job, nil
+}
+
+// EnqueueRescore registra un job de recálculo de puntajes y lo encola para ejecutarse en segundo plano,
+// en la misma cola que las sincronizaciones.
+func (s *service) EnqueueRescore() (domain.SyncJob, error) {
+	job := domain.SyncJob{
+		Kind:  domain.SyncJobKindRescore,
+		State: domain.SyncJobQueued,
+	}
+
+	if err := s.enqueueSyncJob(&job); err != nil {
+		return domain.SyncJob{}, err
+	}
+
+	log.Printf("Job de recálculo %d encolado", job.ID)
+	return job, nil
+}
+
+// enqueueSyncJob registra un job y lo encola sin bloquear; si la cola está llena el job se marca como fallido
+func (s *service) enqueueSyncJob(job *domain.SyncJob) error {
+	if err := s.repo.CreateSyncJob(job); err != nil {
+		return err
+	}
+
+	if !s.queueSyncJob(job.ID) {
+		s.finishSyncJob(job, domain.ErrSyncQueueFull)
+		return domain.ErrSyncQueueFull
+	}
+	return nil
+}
+
+// GetSyncJob obtiene el estado de un job de sincronización.
+func (s *service) GetSyncJob(id int64) (domain.SyncJob, error) {
+	return s.repo.GetSyncJob(id)
+}
+
+// queueSyncJob intenta agregar un job a la cola sin bloquear
+func (s *service) queueSyncJob(id int64) bool {
+	select {
+	case s.syncQueue <- id:
+		return true
+	default:
+		return false
+	}
+}
+
+// startSyncWorker recupera los jobs pendientes tras un reinicio e inicia el procesamiento en segundo plano
+func (s *service) startSyncWorker() error {
+	if err := s.recoverSyncJobs(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopWorker = cancel
+	s.workerDone = make(chan struct{})
+
+	go s.runSyncWorker(ctx)
+	return nil
+}
+
+// stopSyncWorker cancela el job en curso y espera a que el worker termine
+func (s *service) stopSyncWorker(ctx context.Context) error {
+	if s.stopWorker == nil {
+		return nil
+	}
+
+	log.Println("🛑 Deteniendo worker de sincronización...")
+	s.stopWorker()
+
+	select {
+	case <-s.workerDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recoverSyncJobs marca como fallidos los jobs interrumpidos y vuelve a encolar los que estaban en espera
+func (s *service) recoverSyncJobs() error {
+	jobs, err := s.repo.FindSyncJobsByState(domain.SyncJobQueued, domain.SyncJobRunning)
+	if err != nil {
+		return err
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		switch {
+		case job.State == domain.SyncJobRunning:
+			s.finishSyncJob(job, errSyncJobInterrupted)
+		case !s.queueSyncJob(job.ID):
+			s.finishSyncJob(job, domain.ErrSyncQueueFull)
+		default:
+			log.Printf("Job de sincronización %d reencolado tras reinicio", job.ID)
+		}
+	}
+
+	return nil
+}
+
+// runSyncWorker procesa los jobs de la cola y los recálculos solicitados uno a la vez hasta que se cancele el contexto
+func (s *service) runSyncWorker(ctx context.Context) {
+	defer close(s.workerDone)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-s.syncQueue:
+			s.runSyncJob(ctx, id)
+		case <-s.rescoreQueue:
+			s.runQueuedRescore(ctx)
+		}
+	}
+}
+
+// runSyncJob ejecuta un job de sincronización, su dry run o un recálculo, registrando su progreso en la base de datos
+func (s *service) runSyncJob(ctx context.Context, id int64) {
+	job, err := s.repo.GetSyncJob(id)
+	if err != nil {
+		log.Printf("No se pudo obtener el job de sincronización %d: %v", id, err)
+		return
+	}
+
+	startedAt := time.Now()
+	job.StartedAt = &startedAt
+	s.markSyncJob(&job, domain.SyncJobRunning)
+
+	onProgress := func(progress domain.SyncResult) {
+		job.SyncResult = progress
+		if err := s.repo.SaveSyncJob(&job); err != nil {
+			log.Printf("No se pudo registrar el progreso del job %d: %v", job.ID, err)
+		}
+	}
+
+	log.Printf("Ejecutando job de sincronización %d", job.ID)
+	if job.Kind == domain.SyncJobKindRescore {
+		result, err := s.runRescore(ctx)
+		if err == nil {
+			job.Rescore = &result
+		}
+		s.finishSyncJob(&job, err)
+		return
+	}
+	if job.DryRun {
+		preview, err := s.runPreview(ctx, job.Options(), onProgress)
+		job.SyncResult = preview.SyncResult
+		if err == nil {
+			job.Preview = &preview
+		}
+		s.finishSyncJob(&job, err)
+		return
+	}
+
+	result, err := s.runSync(ctx, job.Options(), onProgress)
+	job.SyncResult = result
+	s.finishSyncJob(&job, err)
+}
+
+// finishSyncJob registra el resultado final de un job según el error recibido
+func (s *service) finishSyncJob(job *domain.SyncJob, err error) {
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+
+	if err != nil {
+		log.Printf("Job de sincronización %d fallido: %v", job.ID, err)
+		job.Error = err.Error()
+		s.markSyncJob(job, domain.SyncJobFailed)
+		return
+	}
+
+	log.Printf("Job de sincronización %d completado", job.ID)
+	s.markSyncJob(job, domain.SyncJobSucceeded)
+}
+
+// markSyncJob actualiza el estado de un job y lo persiste
+func (s *service) markSyncJob(job *domain.SyncJob, state string) {
+	job.State = state
+	if err := s.repo.SaveSyncJob(job); err != nil {
+		log.Printf("No se pudo actualizar el job de sincronización %d: %v", job.ID, err)
+	}
+}
@@ -0,0 +1,249 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestEnqueueSync_Success prueba que el job se registre y se encole
+func TestEnqueueSync_Success(t *testing.T) {
+	// Crear mock del repositorio que asigna un ID al job
+	mockRepo := new(MockRepository)
+	mockRepo.On("CreateSyncJob", mock.AnythingOfType("*domain.SyncJob")).
+		Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.SyncJob).ID = 7
+		}).
+		Return(nil)
+
+	s := &service{repo: mockRepo, syncQueue: make(chan int64, 1)}
+
+	// Ejecutar el método a probar
+	job, err := s.EnqueueSync(domain.SyncOptions{Limit: 5})
+
+	// Verificar el job creado y la cola
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), job.ID)
+	assert.Equal(t, domain.SyncJobQueued, job.State)
+	assert.Equal(t, 5, job.Limit)
+	assert.Equal(t, int64(7), <-s.syncQueue)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// TestEnqueueSync_QueueFull prueba que el job se marque como fallido si la cola está llena
+func TestEnqueueSync_QueueFull(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("CreateSyncJob", mock.AnythingOfType("*domain.SyncJob")).Return(nil)
+	mockRepo.On("SaveSyncJob", mock.MatchedBy(func(job *domain.SyncJob) bool {
+		return job.State == domain.SyncJobFailed && job.FinishedAt != nil
+	})).Return(nil)
+
+	// Cola sin capacidad disponible
+	s := &service{repo: mockRepo, syncQueue: make(chan int64)}
+
+	// Ejecutar el método a probar
+	_, err := s.EnqueueSync(domain.SyncOptions{Limit: 5})
+
+	// Verificar el error
+	assert.ErrorIs(t, err, domain.ErrSyncQueueFull)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestEnqueueRescore prueba que el recálculo se registre como job y se encole junto con las sincronizaciones
+func TestEnqueueRescore(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("CreateSyncJob", mock.MatchedBy(func(job *domain.SyncJob) bool {
+		return job.Kind == domain.SyncJobKindRescore && job.State == domain.SyncJobQueued
+	})).
+		Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.SyncJob).ID = 8
+		}).
+		Return(nil)
+
+	s := &service{repo: mockRepo, syncQueue: make(chan int64, 1)}
+
+	job, err := s.EnqueueRescore()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), job.ID)
+	assert.Equal(t, int64(8), <-s.syncQueue)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestRunSyncJob_Rescore prueba que un job de recálculo guarde su resumen sin sincronizar
+func TestRunSyncJob_Rescore(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncJob", int64(6)).Return(domain.SyncJob{ID: 6, Kind: domain.SyncJobKindRescore, State: domain.SyncJobQueued}, nil)
+	mockRepo.On("GetStocksAfterID", int64(0), rescoreBatchSize).Return([]domain.Stock{{ID: 3, TargetFrom: 10, TargetTo: 20}}, nil)
+	mockRepo.On("GetStocksAfterID", int64(3), rescoreBatchSize).Return([]domain.Stock{}, nil)
+	mockRepo.On("UpdateStockScores", mock.Anything).Return(nil)
+
+	var last domain.SyncJob
+	mockRepo.On("SaveSyncJob", mock.AnythingOfType("*domain.SyncJob")).
+		Run(func(args mock.Arguments) {
+			last = *args.Get(0).(*domain.SyncJob)
+		}).
+		Return(nil)
+
+	s := &service{repo: mockRepo, cfg: createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)}
+
+	s.runSyncJob(context.Background(), 6)
+
+	assert.Equal(t, domain.SyncJobSucceeded, last.State)
+	if assert.NotNil(t, last.Rescore) {
+		assert.Equal(t, 1, last.Rescore.Processed)
+		assert.Equal(t, 1, last.Rescore.Changed)
+	}
+	mockRepo.AssertNotCalled(t, "CreateSyncRun", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestRunSyncJob_Success prueba la ejecución completa de un job exitoso
+func TestRunSyncJob_Success(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncJob", int64(3)).Return(domain.SyncJob{ID: 3, State: domain.SyncJobQueued, Limit: 1}, nil)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{Inserted: 1}, nil)
+	expectCheckpoints(mockRepo)
+
+	// Registrar los estados persistidos del job
+	var states []string
+	var last domain.SyncJob
+	mockRepo.On("SaveSyncJob", mock.AnythingOfType("*domain.SyncJob")).
+		Run(func(args mock.Arguments) {
+			job := args.Get(0).(*domain.SyncJob)
+			states = append(states, job.State)
+			last = *job
+		}).
+		Return(nil)
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+		"items": [
+			{"ticker": "AAPL", "brokerage": "Example", "target_from": "$1.00", "target_to": "$2.00"},
+			{"ticker": "BAD", "target_from": "invalid", "target_to": "$2.00"}
+		],
+		"next_page": ""
+	}`), nil)
+
+	s := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	// Ejecutar el método a probar
+	s.runSyncJob(context.Background(), 3)
+
+	// Verificar la secuencia de estados y el resultado final
+	assert.Equal(t, domain.SyncJobRunning, states[0])
+	assert.Equal(t, domain.SyncJobSucceeded, last.State)
+	assert.NotNil(t, last.StartedAt)
+	assert.NotNil(t, last.FinishedAt)
+	assert.Equal(t, 1, last.PagesFetched)
+	assert.Equal(t, 1, last.ItemsParsed)
+	assert.Equal(t, 1, last.ItemsRejected)
+	assert.Equal(t, 1, last.Inserted)
+	assert.Empty(t, last.Error)
+}
+
+// TestRunSyncJob_DryRun prueba que un job de dry run guarde el resumen sin escribir stocks, checkpoints ni ejecuciones
+func TestRunSyncJob_DryRun(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncJob", int64(5)).Return(domain.SyncJob{ID: 5, State: domain.SyncJobQueued, Limit: 1, DryRun: true}, nil)
+	mockRepo.On("PreviewUpsertStocks", mock.Anything, previewSampleSize).
+		Return(domain.SyncResult{Inserted: 1}, []domain.SyncPreviewRow{}, nil)
+	mockRepo.On("CountMissingStocks", mock.Anything).Return(0, nil)
+
+	var last domain.SyncJob
+	mockRepo.On("SaveSyncJob", mock.AnythingOfType("*domain.SyncJob")).
+		Run(func(args mock.Arguments) {
+			last = *args.Get(0).(*domain.SyncJob)
+		}).
+		Return(nil)
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+		"items": [{"ticker": "AAPL", "brokerage": "Example", "target_from": "$1.00", "target_to": "$2.00"}],
+		"next_page": ""
+	}`), nil)
+
+	s := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	// Ejecutar el método a probar
+	s.runSyncJob(context.Background(), 5)
+
+	// Verificar el estado final y el resumen del dry run
+	assert.Equal(t, domain.SyncJobSucceeded, last.State)
+	assert.Equal(t, 1, last.Inserted)
+	if assert.NotNil(t, last.Preview) {
+		assert.Equal(t, 1, last.Preview.Inserted)
+		assert.Equal(t, domain.SyncStopEmptyNextPage, last.Preview.StopReason)
+	}
+	mockRepo.AssertNotCalled(t, "CreateSyncRun", mock.Anything)
+	mockRepo.AssertNotCalled(t, "SaveSyncCheckpoint", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRunSyncJob_Failure prueba que un error de la API marque el job como fallido
+func TestRunSyncJob_Failure(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncJob", int64(4)).Return(domain.SyncJob{ID: 4, State: domain.SyncJobQueued, Limit: 1}, nil)
+	expectCheckpoints(mockRepo)
+
+	var last domain.SyncJob
+	mockRepo.On("SaveSyncJob", mock.AnythingOfType("*domain.SyncJob")).
+		Run(func(args mock.Arguments) {
+			last = *args.Get(0).(*domain.SyncJob)
+		}).
+		Return(nil)
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte{}, errors.New("error de API externa"))
+
+	s := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	// Ejecutar el método a probar
+	s.runSyncJob(context.Background(), 4)
+
+	// Verificar el estado final
+	assert.Equal(t, domain.SyncJobFailed, last.State)
+	assert.Contains(t, last.Error, "error de API externa")
+	assert.NotNil(t, last.FinishedAt)
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRecoverSyncJobs prueba la recuperación de jobs tras un reinicio
+func TestRecoverSyncJobs(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("FindSyncJobsByState", []string{domain.SyncJobQueued, domain.SyncJobRunning}).Return([]domain.SyncJob{
+		{ID: 1, State: domain.SyncJobRunning},
+		{ID: 2, State: domain.SyncJobQueued},
+	}, nil)
+	mockRepo.On("SaveSyncJob", mock.MatchedBy(func(job *domain.SyncJob) bool {
+		return job.ID == 1 && job.State == domain.SyncJobFailed
+	})).Return(nil)
+
+	s := &service{repo: mockRepo, syncQueue: make(chan int64, 1)}
+
+	// Ejecutar el método a probar
+	err := s.recoverSyncJobs()
+
+	// El job interrumpido se marca como fallido y el pendiente vuelve a la cola
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), <-s.syncQueue)
+	mockRepo.AssertExpectations(t)
+}
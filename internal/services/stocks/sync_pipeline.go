@@ -0,0 +1,273 @@
+package stocks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	repo "github.com/julianloaiza/stock-advisor/internal/repositories/stocks"
+)
+
+// pageCursor es la posición desde la que el fetcher continúa la paginación
+type pageCursor struct {
+	iteration  int // Última iteración completada
+	nextPage   string
+	seenTokens map[string]bool
+	done       bool
+}
+
+// fetchedPage es una página obtenida de la API, pendiente de procesar
+type fetchedPage struct {
+	iteration int
+	items     []map[string]interface{}
+	nextPage  string
+	final     bool
+	attempts  int
+}
+
+// fetchTotals acumula las solicitudes HTTP realizadas por el fetcher, incluidas las de páginas que no llegaron a
+// guardarse por un error o una cancelación
+type fetchTotals struct {
+	attempts int
+	retries  int
+}
+
+// add registra las solicitudes realizadas para obtener una página
+func (t *fetchTotals) add(attempts int) {
+	t.attempts += attempts
+	if attempts > 1 {
+		t.retries += attempts - 1
+	}
+}
+
+// processedPage es una página con sus elementos convertidos a stocks y sus elementos rechazados
+type processedPage struct {
+	checkpoint domain.SyncCheckpoint
+	rejects    []domain.SyncReject
+	attempts   int
+}
+
+// runSyncPipeline obtiene y procesa las páginas de la API hasta que termine la paginación o se alcance el límite.
+// Un fetcher recorre los tokens next_page mientras un grupo de workers convierte y puntúa los elementos de las
+// páginas ya obtenidas. Las páginas procesadas se entregan a record en orden de iteración y luego se incorporan
+// al estado; onProgress, si no es nil, se invoca después de cada una.
+// record se ejecuta en la goroutine que llama a runSyncPipeline, no en una etapa propia: mientras guarda una página
+// no se reciben otras, y como los canales tienen capacidad limitada, el fetcher se detiene hasta que termine.
+func (s *service) runSyncPipeline(ctx context.Context, state *syncState, limit int, record func(*domain.SyncCheckpoint, []domain.SyncReject) error, onProgress func(domain.SyncResult)) error {
+	log.Println("🔄 Iniciando sincronización con la API")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := max(s.cfg.SyncWorkers, 1)
+	fetched := make(chan fetchedPage, workers)
+	processed := make(chan processedPage, workers)
+
+	// Fetcher: la paginación es secuencial porque cada página indica el token de la siguiente.
+	// Trabaja sobre una copia de la posición; el estado solo se modifica al recibir las páginas en orden.
+	cursor := pageCursor{
+		iteration:  state.iteration,
+		nextPage:   state.nextPage,
+		seenTokens: make(map[string]bool, len(state.seenTokens)),
+		done:       state.done,
+	}
+	for token := range state.seenTokens {
+		cursor.seenTokens[token] = true
+	}
+
+	// Las páginas entregadas suman sus intentos al progreso a medida que llegan; al terminar el pipeline
+	// los totales se reemplazan por los del fetcher, que también cuentan las páginas descartadas.
+	// fetchDone se cierra cuando el fetcher terminó: totals y fetchErr solo se leen después.
+	base := state.progress
+	var fetchErr error
+	var totals fetchTotals
+	fetchDone := make(chan struct{})
+	go func() {
+		defer close(fetchDone)
+		defer close(fetched)
+		totals, fetchErr = s.fetchPages(ctx, cursor, limit, fetched)
+	}()
+	settleAttempts := func() {
+		<-fetchDone
+		state.progress.APIAttempts = base.APIAttempts + totals.attempts
+		state.progress.APIRetries = base.APIRetries + totals.retries
+	}
+
+	// Workers: convierten y puntúan los elementos de cada página
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range fetched {
+				pageStocks, rejects := s.processPageItems(page.items, page.iteration)
+				result := processedPage{
+					checkpoint: domain.SyncCheckpoint{
+						Iteration:     page.iteration,
+						NextPage:      page.nextPage,
+						Final:         page.final,
+						Stocks:        pageStocks,
+						ItemsRejected: len(rejects),
+					},
+					rejects:  rejects,
+					attempts: page.attempts,
+				}
+				select {
+				case processed <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(processed)
+	}()
+
+	// Entregar las páginas en orden; ante un error se cancela el resto del pipeline y se espera a que termine,
+	// incluida la solicitud en curso del fetcher
+	if err := collectPages(processed, state, record, onProgress); err != nil {
+		cancel()
+		for range processed {
+		}
+		settleAttempts()
+		return err
+	}
+
+	// Si ctx se cancela, los workers pueden terminar antes que el fetcher: settleAttempts espera a que termine
+	settleAttempts()
+	if fetchErr != nil {
+		return fetchErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !state.done {
+		state.stopReason = domain.SyncStopLimit
+	}
+	return nil
+}
+
+// fetchPages recorre las páginas de la API desde cursor siguiendo los tokens next_page y las envía a pages.
+// Devuelve las solicitudes realizadas para todas las páginas obtenidas, también si una solicitud falla o
+// ctx se cancela antes de entregar una página.
+func (s *service) fetchPages(ctx context.Context, cursor pageCursor, limit int, pages chan<- fetchedPage) (fetchTotals, error) {
+	var totals fetchTotals
+	for i := cursor.iteration + 1; i <= limit && !cursor.done; i++ {
+		// Obtener datos de la página actual
+		items, newNextPage, attempts, err := s.fetchPageData(ctx, cursor.nextPage, i)
+		totals.add(attempts)
+		if err != nil {
+			return totals, err
+		}
+
+		cursor.done = s.shouldTerminateSync(newNextPage, cursor.seenTokens)
+		cursor.seenTokens[newNextPage] = true
+		cursor.nextPage = newNextPage
+
+		select {
+		case pages <- fetchedPage{iteration: i, items: items, nextPage: newNextPage, final: cursor.done, attempts: attempts}:
+		case <-ctx.Done():
+			return totals, ctx.Err()
+		}
+	}
+	return totals, nil
+}
+
+// collectPages recibe las páginas procesadas, que pueden llegar desordenadas, y las entrega a record
+// en orden de iteración antes de incorporarlas al estado
+func collectPages(processed <-chan processedPage, state *syncState, record func(*domain.SyncCheckpoint, []domain.SyncReject) error, onProgress func(domain.SyncResult)) error {
+	pending := make(map[int]processedPage)
+	for page := range processed {
+		pending[page.checkpoint.Iteration] = page
+
+		for {
+			next, ok := pending[state.iteration+1]
+			if !ok {
+				break
+			}
+			delete(pending, next.checkpoint.Iteration)
+
+			state.addAttempts(next.attempts)
+			if err := record(&next.checkpoint, next.rejects); err != nil {
+				return err
+			}
+
+			// Registrar progreso
+			state.apply(next.checkpoint)
+			if onProgress != nil {
+				onProgress(state.progress)
+			}
+		}
+	}
+	return nil
+}
+
+// syncWriter acumula los stocks procesados durante la sincronización y los guarda en lotes, para que la memoria
+// usada no dependa de la cantidad de páginas. Se usa desde record, en la goroutine que recibe las páginas.
+// Solo se guarda la primera aparición de cada clave natural en la ejecución, para que un stock repetido en
+// otra página no se cuente dos veces (por ejemplo, como insertado y luego como sin cambios).
+type syncWriter struct {
+	repo      repo.Repository
+	runID     int64
+	batchSize int
+	batch     []domain.Stock
+	seen      map[string]bool   // Claves naturales ya agregadas en la ejecución
+	written   int               // Stocks enviados a la base de datos
+	result    domain.SyncResult // Cambios acumulados de los lotes guardados
+}
+
+// newSyncWriter crea el escritor de stocks de una ejecución de la sincronización
+func (s *service) newSyncWriter(runID int64) *syncWriter {
+	batchSize := max(s.cfg.SyncBatchSize, 1)
+	return &syncWriter{
+		repo:      s.repo,
+		runID:     runID,
+		batchSize: batchSize,
+		batch:     make([]domain.Stock, 0, batchSize),
+		seen:      make(map[string]bool),
+	}
+}
+
+// add agrega stocks al lote actual y lo guarda cuando alcanza el tamaño configurado.
+// Los stocks cuya clave natural ya se agregó en la ejecución se descartan.
+func (w *syncWriter) add(ctx context.Context, stocks []domain.Stock) error {
+	for _, stock := range stocks {
+		stock.NaturalKey = stock.ComputeNaturalKey()
+		if w.seen[stock.NaturalKey] {
+			continue
+		}
+		w.seen[stock.NaturalKey] = true
+
+		w.batch = append(w.batch, stock)
+		if len(w.batch) >= w.batchSize {
+			if err := w.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flush guarda los stocks pendientes del lote actual; la escritura se cancela si ctx termina
+func (w *syncWriter) flush(ctx context.Context) error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+
+	result, err := w.repo.UpsertStocks(ctx, w.batch, false, w.runID)
+	if err != nil {
+		return fmt.Errorf("error guardando stocks: %w", err)
+	}
+
+	w.written += len(w.batch)
+	w.result.Inserted += result.Inserted
+	w.result.Updated += result.Updated
+	w.result.Unchanged += result.Unchanged
+	w.batch = w.batch[:0]
+	return nil
+}
@@ -0,0 +1,203 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// pageResponse construye la respuesta de la API para una página con un solo elemento
+func pageResponse(ticker, nextPage string) []byte {
+	return []byte(fmt.Sprintf(`{
+		"items": [{"ticker": %q, "brokerage": "Example Brokerage", "target_from": "$10.00", "target_to": "$12.00"}],
+		"next_page": %q
+	}`, ticker, nextPage))
+}
+
+// TestSyncStocks_PipelineKeepsPageOrder prueba que, con varios workers, los checkpoints y los stocks
+// se guarden en orden de iteración y que los stocks se escriban en lotes del tamaño configurado
+func TestSyncStocks_PipelineKeepsPageOrder(t *testing.T) {
+	tickers := []string{"AAA", "BBB", "CCC", "DDD"}
+
+	mockAPIClient := new(MockAPIClient)
+	for i, ticker := range tickers {
+		token, next := "", ""
+		if i > 0 {
+			token = fmt.Sprintf("page-%d", i+1)
+		}
+		if i < len(tickers)-1 {
+			next = fmt.Sprintf("page-%d", i+2)
+		}
+		params := map[string]string{}
+		if token != "" {
+			params["next_page"] = token
+		}
+		mockAPIClient.On("Get", mock.Anything, "", params).Return(pageResponse(ticker, next), nil).Once()
+	}
+
+	var iterations []int
+	var written []string
+	mockRepo := new(MockRepository)
+	expectSyncRun(mockRepo)
+	mockRepo.On("SaveSyncCheckpoint", mock.AnythingOfType("*domain.SyncCheckpoint"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			iterations = append(iterations, args.Get(0).(*domain.SyncCheckpoint).Iteration)
+		}).
+		Return(nil)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).
+		Run(func(args mock.Arguments) {
+			stocks := args.Get(0).([]domain.Stock)
+			assert.Len(t, stocks, 1, "Cada lote debería tener el tamaño configurado")
+			for _, stock := range stocks {
+				written = append(written, stock.Ticker)
+			}
+		}).
+		Return(domain.SyncResult{Inserted: 1}, nil)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil)
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	cfg.SyncWorkers = 3
+	cfg.SyncBatchSize = 1
+	service := &service{repo: mockRepo, cfg: cfg, provider: providers.NewAPIProvider(mockAPIClient)}
+
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, iterations)
+	assert.Equal(t, tickers, written)
+	assert.Equal(t, 4, result.PagesFetched)
+	assert.Equal(t, 4, result.ItemsParsed)
+	assert.Equal(t, 4, result.Inserted)
+	assert.Equal(t, 4, result.APIAttempts)
+	mockRepo.AssertNumberOfCalls(t, "UpsertStocks", 4)
+	mockRepo.AssertCalled(t, "RemoveMissingStocks", int64(1))
+	mockRepo.AssertExpectations(t)
+	mockAPIClient.AssertExpectations(t)
+}
+
+// TestSyncStocks_PipelineFlushesPartialBatch prueba que los stocks que no completan un lote se guarden al terminar
+func TestSyncStocks_PipelineFlushesPartialBatch(t *testing.T) {
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{}).Return(pageResponse("AAA", "page-2"), nil).Once()
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{"next_page": "page-2"}).Return(pageResponse("BBB", ""), nil).Once()
+
+	mockRepo := new(MockRepository)
+	expectCheckpoints(mockRepo)
+	mockRepo.On("UpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool {
+		return len(stocks) == 2 && stocks[0].Ticker == "AAA" && stocks[1].Ticker == "BBB"
+	}), false, int64(1)).Return(domain.SyncResult{Inserted: 1, Updated: 1}, nil).Once()
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	cfg.SyncBatchSize = 3
+	service := &service{repo: mockRepo, cfg: cfg, provider: providers.NewAPIProvider(mockAPIClient)}
+
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, 1, result.Updated)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSyncStocks_PipelineCancelled prueba que la cancelación del contexto detenga el pipeline sin guardar stocks
+func TestSyncStocks_PipelineCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, "", mock.Anything).
+		Run(func(args mock.Arguments) { cancel() }).
+		Return(pageResponse("AAA", "page-2"), nil)
+
+	mockRepo := new(MockRepository)
+	expectCheckpoints(mockRepo)
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	service := &service{repo: mockRepo, cfg: cfg, provider: providers.NewAPIProvider(mockAPIClient)}
+
+	result, err := service.SyncStocks(ctx, domain.SyncOptions{Limit: 10})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	// Las solicitudes de las páginas descartadas por la cancelación también se informan
+	assert.Equal(t, len(mockAPIClient.Calls), result.APIAttempts)
+	assert.Positive(t, result.APIAttempts)
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "RemoveMissingStocks", mock.Anything)
+	// Los checkpoints solo se eliminan al iniciar; se conservan para reanudar
+	mockRepo.AssertNumberOfCalls(t, "ClearSyncCheckpoints", 1)
+}
+
+// TestSyncStocks_PipelineSkipsRepeatedStocks prueba que un stock repetido en otra página, y por lo tanto en otro lote,
+// se guarde y se cuente una sola vez
+func TestSyncStocks_PipelineSkipsRepeatedStocks(t *testing.T) {
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{}).Return(pageResponse("AAA", "page-2"), nil).Once()
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{"next_page": "page-2"}).Return(pageResponse("AAA", ""), nil).Once()
+
+	mockRepo := new(MockRepository)
+	expectCheckpoints(mockRepo)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{Inserted: 1}, nil).Once()
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	cfg.SyncBatchSize = 1
+	service := &service{repo: mockRepo, cfg: cfg, provider: providers.NewAPIProvider(mockAPIClient)}
+
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ItemsParsed)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, 0, result.Unchanged)
+	mockRepo.AssertNumberOfCalls(t, "UpsertStocks", 1)
+}
+
+// TestSyncStocks_PipelineWaitsForFetcher prueba que, si falla el guardado de una página mientras el fetcher espera
+// una respuesta lenta, la sincronización no termine hasta que el fetcher lo haga (ejecutar con -race)
+func TestSyncStocks_PipelineWaitsForFetcher(t *testing.T) {
+	// Mientras se guarda la primera página, la segunda espera en el canal de páginas procesadas, el worker
+	// queda bloqueado entregando la tercera y el fetcher espera la respuesta de la cuarta. Al cancelarse
+	// el pipeline el worker termina por ctx.Done, antes que el fetcher.
+	var fetching atomic.Bool
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{}).Return(pageResponse("AAA", "page-2"), nil).Once()
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{"next_page": "page-2"}).Return(pageResponse("BBB", "page-3"), nil).Once()
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{"next_page": "page-3"}).Return(pageResponse("CCC", "page-4"), nil).Once()
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{"next_page": "page-4"}).
+		Run(func(args mock.Arguments) {
+			fetching.Store(true)
+			time.Sleep(100 * time.Millisecond)
+			fetching.Store(false)
+		}).
+		Return(pageResponse("DDD", ""), nil).Once()
+
+	mockRepo := new(MockRepository)
+	expectSyncRun(mockRepo)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil)
+	mockRepo.On("SaveSyncCheckpoint", mock.AnythingOfType("*domain.SyncCheckpoint"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			// Esperar a que la solicitud de la cuarta página esté en curso antes de fallar
+			for !fetching.Load() {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}).
+		Return(errors.New("error de base de datos"))
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	cfg.SyncWorkers = 1
+	service := &service{repo: mockRepo, cfg: cfg, provider: providers.NewAPIProvider(mockAPIClient)}
+
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 10})
+
+	assert.Error(t, err)
+	assert.False(t, fetching.Load(), "La solicitud en curso debe terminar antes de devolver el resultado")
+	assert.Equal(t, 4, result.APIAttempts)
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+}
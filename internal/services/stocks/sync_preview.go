@@ -0,0 +1,162 @@
+package stocks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	repo "github.com/julianloaiza/stock-advisor/internal/repositories/stocks"
+)
+
+// previewSampleSize es la cantidad máxima de stocks de muestra incluidos en un dry run
+const previewSampleSize = 10
+
+// runPreview ejecuta el dry run de un job: obtiene y procesa las páginas igual que la sincronización, pero en lugar
+// de guardar los stocks devuelve un resumen de lo que cambiaría. No guarda checkpoints, rechazos ni ejecuciones.
+// Los stocks se comparan con la base de datos en lotes a medida que avanza la paginación, igual que los guarda
+// la sincronización. onProgress, si no es nil, se invoca después de procesar cada página.
+func (s *service) runPreview(ctx context.Context, opts domain.SyncOptions, onProgress func(domain.SyncResult)) (domain.SyncPreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.SyncTimeout)*time.Second)
+	defer cancel()
+
+	limit := s.validateLimit(opts.Limit)
+
+	// Reanudar solo lee los checkpoints pendientes; sin reanudar se parte de cero sin eliminarlos
+	state := newSyncState()
+	if opts.Resume {
+		var err error
+		if state, err = s.prepareSyncState(true); err != nil {
+			return domain.SyncPreview{}, err
+		}
+	}
+
+	previewer := s.newSyncPreviewer()
+	if err := previewer.add(state.stocks); err != nil {
+		return domain.SyncPreview{SyncResult: state.progress}, err
+	}
+	state.stocks = nil
+
+	// Comparar los stocks y contar los rechazos por motivo en lugar de guardarlos
+	rejectReasons := make(map[string]int)
+	record := func(checkpoint *domain.SyncCheckpoint, rejects []domain.SyncReject) error {
+		for _, reject := range rejects {
+			rejectReasons[rejectReason(reject.Error)]++
+		}
+		return previewer.add(checkpoint.Stocks)
+	}
+
+	if err := s.runSyncPipeline(ctx, state, limit, record, onProgress); err != nil {
+		return domain.SyncPreview{SyncResult: mergeSyncChanges(state.progress, previewer.result)}, err
+	}
+
+	if err := previewer.flush(); err != nil {
+		return domain.SyncPreview{SyncResult: mergeSyncChanges(state.progress, previewer.result)}, err
+	}
+
+	// Igual que en la sincronización, los ausentes solo se eliminarían si se llegó a la última página
+	changes := previewer.result
+	if s.cfg.SyncRemoveMissing && state.done {
+		removed, err := s.repo.CountMissingStocks(previewer.keys())
+		if err != nil {
+			return domain.SyncPreview{SyncResult: mergeSyncChanges(state.progress, changes)}, fmt.Errorf("error contando stocks ausentes: %w", err)
+		}
+		changes.Removed = removed
+	}
+
+	preview := domain.SyncPreview{
+		SyncResult:    mergeSyncChanges(state.progress, changes),
+		StopReason:    state.stopReason,
+		Samples:       previewer.samples,
+		RejectReasons: rejectReasons,
+		Scores:        scoreDistribution(previewer.scores),
+	}
+
+	log.Printf("Dry run de sincronización: %d insertados, %d actualizados, %d sin cambios, %d eliminados",
+		preview.Inserted, preview.Updated, preview.Unchanged, preview.Removed)
+	return preview, nil
+}
+
+// syncPreviewer compara en lotes los stocks obtenidos por el dry run con los de la base de datos.
+// De cada stock solo conserva su clave natural y su puntaje, para que la memoria usada no dependa
+// del tamaño de los stocks; si una clave se repite, solo se evalúa su primera aparición.
+type syncPreviewer struct {
+	repo      repo.Repository
+	batchSize int
+	batch     []domain.Stock
+	seen      map[string]bool // Claves naturales evaluadas en el dry run
+	scores    []float64
+	samples   []domain.SyncPreviewRow
+	result    domain.SyncResult // Cambios acumulados de los lotes evaluados
+}
+
+// newSyncPreviewer crea el comparador de stocks de un dry run
+func (s *service) newSyncPreviewer() *syncPreviewer {
+	batchSize := max(s.cfg.SyncBatchSize, 1)
+	return &syncPreviewer{
+		repo:      s.repo,
+		batchSize: batchSize,
+		batch:     make([]domain.Stock, 0, batchSize),
+		seen:      make(map[string]bool),
+		samples:   make([]domain.SyncPreviewRow, 0, previewSampleSize),
+	}
+}
+
+// add agrega stocks al lote actual y lo evalúa cuando alcanza el tamaño configurado
+func (p *syncPreviewer) add(stocks []domain.Stock) error {
+	for _, stock := range stocks {
+		stock.NaturalKey = stock.ComputeNaturalKey()
+		if p.seen[stock.NaturalKey] {
+			continue
+		}
+		p.seen[stock.NaturalKey] = true
+		p.scores = append(p.scores, stock.RecommendScore)
+
+		p.batch = append(p.batch, stock)
+		if len(p.batch) >= p.batchSize {
+			if err := p.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flush compara los stocks pendientes del lote actual con la base de datos
+func (p *syncPreviewer) flush() error {
+	if len(p.batch) == 0 {
+		return nil
+	}
+
+	result, samples, err := p.repo.PreviewUpsertStocks(p.batch, previewSampleSize-len(p.samples))
+	if err != nil {
+		return fmt.Errorf("error calculando los cambios: %w", err)
+	}
+
+	p.result.Inserted += result.Inserted
+	p.result.Updated += result.Updated
+	p.result.Unchanged += result.Unchanged
+	p.samples = append(p.samples, samples...)
+	p.batch = p.batch[:0]
+	return nil
+}
+
+// keys devuelve las claves naturales de todos los stocks evaluados
+func (p *syncPreviewer) keys() []string {
+	keys := make([]string, 0, len(p.seen))
+	for key := range p.seen {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// rejectReason obtiene el motivo de un rechazo sin el detalle del valor recibido,
+// para agrupar los rechazos del mismo tipo
+func rejectReason(message string) string {
+	if i := strings.Index(message, ":"); i > 0 {
+		return message[:i]
+	}
+	return message
+}
@@ -0,0 +1,94 @@
+package stocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRunPreview prueba que el dry run procese las páginas sin guardar checkpoints, rechazos ni stocks
+func TestRunPreview(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("PreviewUpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool {
+		return len(stocks) == 2
+	}), previewSampleSize).Return(
+		domain.SyncResult{Inserted: 1, Unchanged: 1},
+		[]domain.SyncPreviewRow{{Change: domain.SyncChangeInsert, Stock: domain.Stock{Ticker: "AAPL"}}},
+		nil,
+	)
+	mockRepo.On("CountMissingStocks", mock.MatchedBy(func(keys []string) bool {
+		return len(keys) == 2
+	})).Return(3, nil)
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+		"items": [
+			{"ticker": "AAPL", "brokerage": "Example Brokerage", "target_from": "$150.00", "target_to": "$180.00", "rating_to": "Buy"},
+			{"ticker": "MSFT", "brokerage": "Example Brokerage", "target_from": "$300.00", "target_to": "$280.00", "rating_to": "Sell"},
+			{"ticker": "BAD", "brokerage": "Example Brokerage", "target_from": "$1.00", "target_to": "abc"}
+		],
+		"next_page": ""
+	}`), nil)
+
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	preview, err := service.runPreview(context.Background(), domain.SyncOptions{Limit: 5, DryRun: true}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, preview.PagesFetched)
+	assert.Equal(t, 2, preview.ItemsParsed)
+	assert.Equal(t, 1, preview.ItemsRejected)
+	assert.Equal(t, 1, preview.Inserted)
+	assert.Equal(t, 1, preview.Unchanged)
+	assert.Equal(t, 3, preview.Removed)
+	assert.Equal(t, domain.SyncStopEmptyNextPage, preview.StopReason)
+	assert.Len(t, preview.Samples, 1)
+	assert.Equal(t, map[string]int{"error convirtiendo target_to": 1}, preview.RejectReasons)
+	assert.Equal(t, 2, preview.Scores.Count)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "ClearSyncCheckpoints")
+	mockRepo.AssertNotCalled(t, "SaveSyncCheckpoint", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateSyncRun", mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSyncPreviewer_Batches prueba que el dry run compare los stocks en lotes, evalúe una sola vez cada clave
+// y limite la muestra al total configurado entre todos los lotes
+func TestSyncPreviewer_Batches(t *testing.T) {
+	apple := domain.Stock{Ticker: "AAPL", Brokerage: "Example Brokerage", RecommendScore: 10}
+	microsoft := domain.Stock{Ticker: "MSFT", Brokerage: "Example Brokerage", RecommendScore: 20}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("PreviewUpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool {
+		return len(stocks) == 1 && stocks[0].Ticker == "AAPL"
+	}), previewSampleSize).Return(
+		domain.SyncResult{Inserted: 1},
+		[]domain.SyncPreviewRow{{Change: domain.SyncChangeInsert, Stock: apple}},
+		nil,
+	).Once()
+	mockRepo.On("PreviewUpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool {
+		return len(stocks) == 1 && stocks[0].Ticker == "MSFT"
+	}), previewSampleSize-1).Return(domain.SyncResult{Unchanged: 1}, []domain.SyncPreviewRow{}, nil).Once()
+
+	cfg := createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30)
+	cfg.SyncBatchSize = 1
+	previewer := (&service{repo: mockRepo, cfg: cfg}).newSyncPreviewer()
+
+	assert.NoError(t, previewer.add([]domain.Stock{apple, microsoft, apple}))
+	assert.NoError(t, previewer.flush())
+
+	assert.Equal(t, domain.SyncResult{Inserted: 1, Unchanged: 1}, previewer.result)
+	assert.Len(t, previewer.samples, 1)
+	assert.Equal(t, []float64{10, 20}, previewer.scores)
+	assert.Len(t, previewer.keys(), 2)
+	mockRepo.AssertExpectations(t)
+}
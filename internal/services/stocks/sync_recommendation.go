@@ -3,165 +3,151 @@ package stocks
 import (
 	"strings"
 
+	"github.com/julianloaiza/stock-advisor/config"
 	"github.com/julianloaiza/stock-advisor/internal/domain"
 )
 
-// Mapas de puntuaciones preconfigurados
-var (
-	// Puntuaciones de calificaciones (incluyen valores negativos)
-	ratingScores = map[string]float64{
-		// Calificaciones muy positivas
-		"strong-buy": 25,
-		"strong buy": 25,
-		"buy":        20,
-		"outperform": 18,
-		"overweight": 15,
-
-		// Calificaciones positivas moderadas
-		"accumulate":        12,
-		"add":               12,
-		"sector outperform": 10,
-
-		// Calificaciones neutrales
-		"market perform": 5,
-		"sector perform": 5,
-		"equal weight":   5,
-		"in-line":        5,
-		"hold":           0,
-		"neutral":        0,
-
-		// Calificaciones negativas
-		"sector weight": -5,
-		"market weight": -5,
-		"underperform":  -10,
-		"underweight":   -10,
-		"reduce":        -15,
-		"sell":          -20,
-		"strong sell":   -25,
-	}
-
-	// Fragmentos de texto para acciones y sus puntuaciones
-	actionScorePatterns = map[string]float64{
-		"upgraded by":       15,  // Mejora en la calificación
-		"target raised by":  12,  // Aumento del precio objetivo
-		"initiated by":      8,   // Nueva cobertura
-		"reiterated by":     5,   // Reiteración
-		"target set by":     3,   // Establecimiento de precio objetivo
-		"target lowered by": -10, // Reducción del precio objetivo
-		"downgraded by":     -12, // Degradación en la calificación
-	}
-
-	// Calificaciones consideradas negativas para ajustes posteriores
-	negativeRatings = map[string]bool{
-		"underperform": true,
-		"underweight":  true,
-		"reduce":       true,
-		"sell":         true,
-		"strong sell":  true,
-	}
-)
-
-// Factores de ponderación para el cálculo de recomendación
-const (
-	percentDiffWeight   = 0.35 // Cambio porcentual en precio objetivo
-	ratingWeight        = 0.30 // Calificación del analista
-	actionWeight        = 0.20 // Tipo de acción tomada
-	absoluteBonusWeight = 0.15 // Magnitud absoluta del cambio
-
-	// Factores de ajuste
-	decreasingTargetFactor = 0.4 // Factor para precios objetivo decrecientes
-	negativeRatingFactor   = 0.6 // Factor para calificaciones negativas con score positivo
-)
-
-// Estructura para mantener los puntajes base
-type baseScoreComponents struct {
-	percentDiff   float64
-	ratingScore   float64
-	actionScore   float64
-	absoluteBonus float64
+// recommendationFactors devuelve el modelo de puntuación vigente
+func (s *service) recommendationFactors() *config.RecommendationFactors {
+	return s.cfg.RecommendationFactors()
 }
 
 // recommendationScore calcula un puntaje de recomendación para una acción.
 // Utiliza un enfoque balanceado para evaluar el potencial de inversión.
 func (s *service) recommendationScore(stock domain.Stock) float64 {
+	return s.explainScore(stock).FinalScore
+}
+
+// explainScore calcula el puntaje de recomendación registrando cada paso del cálculo.
+// Todos los pasos usan el mismo modelo de puntuación.
+func (s *service) explainScore(stock domain.Stock) domain.ScoreBreakdown {
+	factors := s.recommendationFactors()
+
+	breakdown := domain.ScoreBreakdown{
+		StockID:   stock.ID,
+		Ticker:    stock.Ticker,
+		Brokerage: stock.Brokerage,
+		Weights: domain.ScoreComponents{
+			PercentDiff:   factors.Weights.PercentDiff,
+			Rating:        factors.Weights.Rating,
+			Action:        factors.Weights.Action,
+			AbsoluteBonus: factors.Weights.AbsoluteBonus,
+		},
+		StoredScore: stock.RecommendScore,
+	}
+
 	// 1. Calcular componentes individuales
-	baseScores := s.calculateBaseScores(stock)
+	breakdown.Components = calculateBaseScores(factors, stock)
 
 	// 2. Aplicar factores externos (empresas, brokerages, etc.)
-	adjustedScores := s.applyExternalFactors(stock, baseScores)
+	breakdown.Adjusted, breakdown.Factors = applyExternalFactors(factors, stock, breakdown.Components)
 
 	// 3. Calcular puntuación ponderada
-	weightedScore := s.calculateWeightedScore(adjustedScores)
+	breakdown.Contributions = calculateContributions(breakdown.Weights, breakdown.Adjusted)
+	breakdown.WeightedScore = calculateWeightedScore(breakdown.Contributions)
 
 	// 4. Aplicar modificadores basados en contexto
-	finalScore := s.applyContextModifiers(stock, weightedScore)
+	breakdown.FinalScore, breakdown.Modifiers = applyContextModifiers(factors, stock, breakdown.WeightedScore)
 
-	return finalScore
+	return breakdown
 }
 
 // calculateBaseScores calcula los componentes individuales del puntaje
-func (s *service) calculateBaseScores(stock domain.Stock) baseScoreComponents {
-	return baseScoreComponents{
-		percentDiff:   calculatePercentDiff(stock.TargetFrom, stock.TargetTo),
-		ratingScore:   calculateRatingScore(stock.RatingTo),
-		actionScore:   calculateActionScore(stock.Action),
-		absoluteBonus: calculateAbsoluteBonus(stock.TargetFrom, stock.TargetTo),
+func calculateBaseScores(factors *config.RecommendationFactors, stock domain.Stock) domain.ScoreComponents {
+	return domain.ScoreComponents{
+		PercentDiff:   calculatePercentDiff(stock.TargetFrom, stock.TargetTo),
+		Rating:        calculateRatingScore(factors, stock.RatingTo),
+		Action:        calculateActionScore(factors, stock.Action),
+		AbsoluteBonus: calculateAbsoluteBonus(stock.TargetFrom, stock.TargetTo),
 	}
 }
 
-// applyExternalFactors aplica factores externos que pueden afectar los componentes del puntaje
-func (s *service) applyExternalFactors(stock domain.Stock, scores baseScoreComponents) baseScoreComponents {
+// applyExternalFactors aplica factores externos que pueden afectar los componentes del puntaje.
+// Devuelve los componentes ajustados y los factores que se aplicaron.
+func applyExternalFactors(factors *config.RecommendationFactors, stock domain.Stock, scores domain.ScoreComponents) (domain.ScoreComponents, []domain.ScoreFactor) {
 	// Copia los puntajes para no modificar los originales
 	adjusted := scores
+	applied := []domain.ScoreFactor{}
+
+	// Aplicar factor de empresa si existe para este ticker
+	if factor, exists := factors.Companies[stock.Ticker]; exists {
+		adjusted.PercentDiff = scores.PercentDiff * (1 + (factor / 100))
+		applied = append(applied, domain.ScoreFactor{
+			Source:     "company",
+			Key:        stock.Ticker,
+			Component:  "percent_diff",
+			Percent:    factor,
+			Multiplier: 1 + (factor / 100),
+		})
+	}
 
-	// Solo aplicar factores si están disponibles en la configuración
-	if s.cfg.RecommendationFactors != nil {
-		// Aplicar factor de empresa si existe para este ticker
-		if factor, exists := s.cfg.RecommendationFactors.Companies[stock.Ticker]; exists {
-			adjusted.percentDiff = scores.percentDiff * (1 + (factor / 100))
-		}
-
-		// Aplicar factor de brokerage si existe para este brokerage
-		if factor, exists := s.cfg.RecommendationFactors.Brokerages[stock.Brokerage]; exists {
-			adjusted.ratingScore = scores.ratingScore * (1 + (factor / 100))
-		}
+	// Aplicar factor de brokerage si existe para este brokerage
+	if factor, exists := factors.Brokerages[stock.Brokerage]; exists {
+		adjusted.Rating = scores.Rating * (1 + (factor / 100))
+		applied = append(applied, domain.ScoreFactor{
+			Source:     "brokerage",
+			Key:        stock.Brokerage,
+			Component:  "rating",
+			Percent:    factor,
+			Multiplier: 1 + (factor / 100),
+		})
 	}
 
-	return adjusted
+	return adjusted, applied
 }
 
-// calculateWeightedScore calcula el puntaje ponderado basado en los componentes
-func (s *service) calculateWeightedScore(scores baseScoreComponents) float64 {
-	return (scores.percentDiff * percentDiffWeight) +
-		(scores.ratingScore * ratingWeight) +
-		(scores.actionScore * actionWeight) +
-		(scores.absoluteBonus * absoluteBonusWeight)
+// calculateContributions calcula el aporte ponderado de cada componente
+func calculateContributions(weights, scores domain.ScoreComponents) domain.ScoreComponents {
+	return domain.ScoreComponents{
+		PercentDiff:   scores.PercentDiff * weights.PercentDiff,
+		Rating:        scores.Rating * weights.Rating,
+		Action:        scores.Action * weights.Action,
+		AbsoluteBonus: scores.AbsoluteBonus * weights.AbsoluteBonus,
+	}
 }
 
-// applyContextModifiers ajusta la puntuación basado en el contexto específico del stock
-func (s *service) applyContextModifiers(stock domain.Stock, score float64) float64 {
+// calculateWeightedScore suma los aportes ponderados de los componentes
+func calculateWeightedScore(contributions domain.ScoreComponents) float64 {
+	return contributions.PercentDiff +
+		contributions.Rating +
+		contributions.Action +
+		contributions.AbsoluteBonus
+}
+
+// applyContextModifiers ajusta la puntuación basado en el contexto específico del stock.
+// Devuelve la puntuación final y cada modificador evaluado, se haya aplicado o no.
+func applyContextModifiers(factors *config.RecommendationFactors, stock domain.Stock, score float64) (float64, []domain.ScoreModifier) {
 	adjustedScore := score
+	decreasingTargetFactor := factors.Modifiers.DecreasingTarget
+	negativeRatingFactor := factors.Modifiers.NegativeRating
 
 	// Reducir puntuación si el precio objetivo está disminuyendo
-	if stock.TargetTo < stock.TargetFrom {
+	decreasing := domain.ScoreModifier{
+		Name:        domain.ScoreModifierDecreasingTarget,
+		Factor:      decreasingTargetFactor,
+		Applied:     stock.TargetTo < stock.TargetFrom,
+		ScoreBefore: adjustedScore,
+	}
+	if decreasing.Applied {
 		adjustedScore = adjustedScore * decreasingTargetFactor
 	}
+	decreasing.ScoreAfter = adjustedScore
 
 	// Reducir puntuación para calificaciones negativas cuando el score es positivo
 	// Nota: Esto es necesario porque pueden existir casos donde otros factores
 	// compensan la calificación negativa, resultando en un score global positivo
-	if isNegativeRating(stock.RatingTo) && adjustedScore > 0 {
+	negative := domain.ScoreModifier{
+		Name:        domain.ScoreModifierNegativeRating,
+		Factor:      negativeRatingFactor,
+		Applied:     factors.IsNegativeRating(stock.RatingTo) && adjustedScore > 0,
+		ScoreBefore: adjustedScore,
+	}
+	if negative.Applied {
 		adjustedScore = adjustedScore * negativeRatingFactor
 	}
+	negative.ScoreAfter = adjustedScore
 
-	return adjustedScore
-}
-
-// isNegativeRating determina si una calificación es considerada negativa
-// Usado para aplicar ajustes adicionales a stocks con calificaciones negativas
-func isNegativeRating(rating string) bool {
-	normalizedRating := strings.ToLower(strings.TrimSpace(rating))
-	return negativeRatings[normalizedRating]
+	return adjustedScore, []domain.ScoreModifier{decreasing, negative}
 }
 
 // calculatePercentDiff calcula la diferencia porcentual entre los precios objetivo
@@ -244,11 +230,11 @@ func calculateAbsoluteBonus(from, to float64) float64 {
 }
 
 // calculateRatingScore evalúa la calificación final del analista
-func calculateRatingScore(rating string) float64 {
+func calculateRatingScore(factors *config.RecommendationFactors, rating string) float64 {
 	normalizedRating := strings.ToLower(strings.TrimSpace(rating))
 
 	// Buscar en el mapa de puntuaciones
-	if score, exists := ratingScores[normalizedRating]; exists {
+	if score, exists := factors.RatingScores[normalizedRating]; exists {
 		return score
 	}
 
@@ -256,13 +242,13 @@ func calculateRatingScore(rating string) float64 {
 }
 
 // calculateActionScore evalúa el tipo de acción realizada por el analista
-func calculateActionScore(action string) float64 {
+func calculateActionScore(factors *config.RecommendationFactors, action string) float64 {
 	normalizedAction := strings.ToLower(strings.TrimSpace(action))
 
-	// Buscar en patrones de acción
-	for pattern, score := range actionScorePatterns {
+	// Buscar en patrones de acción, empezando por los más específicos
+	for _, pattern := range factors.SortedActionPatterns() {
 		if strings.Contains(normalizedAction, pattern) {
-			return score
+			return factors.ActionPatterns[pattern]
 		}
 	}
 
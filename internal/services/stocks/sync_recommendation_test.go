@@ -186,3 +186,27 @@ func TestNegativeScenarios(t *testing.T) {
 		})
 	}
 }
+
+// TestRecommendationScore_CustomModel verifica que el puntaje use el modelo configurado
+func TestRecommendationScore_CustomModel(t *testing.T) {
+	stock := domain.Stock{
+		Ticker:     "CUST",
+		Action:     "upgraded by",
+		RatingTo:   "Buy",
+		TargetFrom: 100.0,
+		TargetTo:   110.0,
+	}
+
+	defaultScore := (&service{cfg: &config.Config{}}).recommendationScore(stock)
+
+	// Solo la calificación cuenta en el modelo personalizado
+	factors := config.DefaultRecommendationFactors()
+	factors.Weights = config.ScoreWeights{Rating: 1}
+	factors.RatingScores = map[string]float64{"buy": 42}
+	cfg := &config.Config{}
+	cfg.SetRecommendationFactors(factors)
+	custom := &service{cfg: cfg}
+
+	assert.Equal(t, 42.0, custom.recommendationScore(stock))
+	assert.NotEqual(t, defaultScore, custom.recommendationScore(stock))
+}
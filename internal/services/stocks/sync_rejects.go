@@ -0,0 +1,18 @@
+package stocks
+
+import (
+	"log"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// GetSyncRejects obtiene los elementos rechazados durante las sincronizaciones, con paginación.
+func (s *service) GetSyncRejects(page, size int) ([]domain.SyncReject, int64, error) {
+	rejects, total, err := s.repo.GetSyncRejects(page, size)
+	if err != nil {
+		log.Printf("Error al obtener elementos rechazados: %v", err)
+		return nil, 0, err
+	}
+
+	return rejects, total, nil
+}
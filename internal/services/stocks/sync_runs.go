@@ -0,0 +1,123 @@
+package stocks
+
+import (
+	"sort"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// GetSyncRuns obtiene el historial de ejecuciones de la sincronización, de la más reciente a la más antigua.
+func (s *service) GetSyncRuns(page, size int) ([]domain.SyncRun, int64, error) {
+	return s.repo.GetSyncRuns(page, size)
+}
+
+// GetSyncRunDiff compara las acciones observadas por una ejecución con las de la última ejecución
+// anterior que registró historial. Si no existe, todos los tickers de la ejecución se consideran nuevos.
+func (s *service) GetSyncRunDiff(id int64) (domain.SyncRunDiff, error) {
+	if _, err := s.repo.GetSyncRun(id); err != nil {
+		return domain.SyncRunDiff{}, err
+	}
+
+	current, err := s.repo.GetSyncRunRatings(id)
+	if err != nil {
+		return domain.SyncRunDiff{}, err
+	}
+
+	previousID, err := s.repo.GetPreviousSyncRunID(id)
+	if err != nil {
+		return domain.SyncRunDiff{}, err
+	}
+
+	var previous []domain.StockRatingHistory
+	if previousID != 0 {
+		if previous, err = s.repo.GetSyncRunRatings(previousID); err != nil {
+			return domain.SyncRunDiff{}, err
+		}
+	}
+
+	diff := diffSyncRuns(previous, current)
+	diff.RunID = id
+	if previousID != 0 {
+		diff.PreviousRunID = &previousID
+	}
+	return diff, nil
+}
+
+// ratingKey identifica la opinión de un brokerage sobre un ticker
+type ratingKey struct {
+	ticker    string
+	brokerage string
+}
+
+// diffSyncRuns calcula los tickers agregados y eliminados entre dos ejecuciones, y los cambios de
+// calificación o precio objetivo de los brokerages presentes en ambas.
+func diffSyncRuns(previous, current []domain.StockRatingHistory) domain.SyncRunDiff {
+	before := latestRatings(previous)
+	after := latestRatings(current)
+
+	diff := domain.SyncRunDiff{
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []domain.SyncRatingChange{},
+	}
+
+	beforeTickers := tickerSet(before)
+	afterTickers := tickerSet(after)
+	for ticker := range afterTickers {
+		if !beforeTickers[ticker] {
+			diff.Added = append(diff.Added, ticker)
+		}
+	}
+	for ticker := range beforeTickers {
+		if !afterTickers[ticker] {
+			diff.Removed = append(diff.Removed, ticker)
+		}
+	}
+
+	for key, rating := range after {
+		old, ok := before[key]
+		if !ok || (old.RatingTo == rating.RatingTo && old.TargetTo == rating.TargetTo) {
+			continue
+		}
+		diff.Changed = append(diff.Changed, domain.SyncRatingChange{
+			Ticker:         rating.Ticker,
+			Brokerage:      rating.Brokerage,
+			PreviousRating: old.RatingTo,
+			CurrentRating:  rating.RatingTo,
+			PreviousTarget: old.TargetTo,
+			CurrentTarget:  rating.TargetTo,
+		})
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		if diff.Changed[i].Ticker != diff.Changed[j].Ticker {
+			return diff.Changed[i].Ticker < diff.Changed[j].Ticker
+		}
+		return diff.Changed[i].Brokerage < diff.Changed[j].Brokerage
+	})
+	return diff
+}
+
+// latestRatings conserva la acción más reciente de cada brokerage sobre cada ticker
+func latestRatings(history []domain.StockRatingHistory) map[ratingKey]domain.StockRatingHistory {
+	latest := make(map[ratingKey]domain.StockRatingHistory, len(history))
+	for _, rating := range history {
+		key := ratingKey{ticker: rating.Ticker, brokerage: rating.Brokerage}
+		if existing, ok := latest[key]; ok && existing.RatedAt.After(rating.RatedAt) {
+			continue
+		}
+		latest[key] = rating
+	}
+	return latest
+}
+
+// tickerSet obtiene los tickers presentes en un conjunto de acciones
+func tickerSet(ratings map[ratingKey]domain.StockRatingHistory) map[string]bool {
+	tickers := make(map[string]bool, len(ratings))
+	for key := range ratings {
+		tickers[key.ticker] = true
+	}
+	return tickers
+}
@@ -0,0 +1,70 @@
+package stocks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestDiffSyncRuns verifica los tickers agregados, eliminados y los cambios de calificación y precio objetivo
+func TestDiffSyncRuns(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	previous := []domain.StockRatingHistory{
+		{Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingTo: "Hold", TargetTo: 150, RatedAt: day},
+		{Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingTo: "Buy", TargetTo: 200, RatedAt: day},
+		{Ticker: "MSFT", Brokerage: "Goldman Sachs", RatingTo: "Buy", TargetTo: 400, RatedAt: day},
+	}
+	current := []domain.StockRatingHistory{
+		// La acción más reciente del brokerage es la que se compara
+		{Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingTo: "Buy", TargetTo: 180, RatedAt: day.AddDate(0, 0, 2)},
+		{Ticker: "AAPL", Brokerage: "Goldman Sachs", RatingTo: "Hold", TargetTo: 150, RatedAt: day},
+		{Ticker: "AAPL", Brokerage: "Morgan Stanley", RatingTo: "Buy", TargetTo: 200, RatedAt: day},
+		{Ticker: "NVDA", Brokerage: "Goldman Sachs", RatingTo: "Buy", TargetTo: 900, RatedAt: day},
+	}
+
+	diff := diffSyncRuns(previous, current)
+
+	assert.Equal(t, []string{"NVDA"}, diff.Added)
+	assert.Equal(t, []string{"MSFT"}, diff.Removed)
+	assert.Equal(t, []domain.SyncRatingChange{{
+		Ticker:         "AAPL",
+		Brokerage:      "Goldman Sachs",
+		PreviousRating: "Hold",
+		CurrentRating:  "Buy",
+		PreviousTarget: 150,
+		CurrentTarget:  180,
+	}}, diff.Changed)
+}
+
+// TestGetSyncRunDiff_FirstRun prueba que sin una ejecución anterior todos los tickers se consideren nuevos
+func TestGetSyncRunDiff_FirstRun(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncRun", int64(1)).Return(domain.SyncRun{ID: 1}, nil)
+	mockRepo.On("GetSyncRunRatings", int64(1)).Return([]domain.StockRatingHistory{{Ticker: "AAPL", Brokerage: "Goldman Sachs"}}, nil)
+	mockRepo.On("GetPreviousSyncRunID", int64(1)).Return(int64(0), nil)
+
+	svc := &service{repo: mockRepo}
+	diff, err := svc.GetSyncRunDiff(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), diff.RunID)
+	assert.Nil(t, diff.PreviousRunID)
+	assert.Equal(t, []string{"AAPL"}, diff.Added)
+	assert.Empty(t, diff.Changed)
+	mockRepo.AssertNumberOfCalls(t, "GetSyncRunRatings", 1)
+}
+
+// TestGetSyncRunDiff_NotFound prueba que una ejecución inexistente devuelva ErrNotFound
+func TestGetSyncRunDiff_NotFound(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncRun", int64(9)).Return(domain.SyncRun{}, domain.ErrNotFound)
+
+	svc := &service{repo: mockRepo}
+	_, err := svc.GetSyncRunDiff(9)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "GetSyncRunRatings", mock.Anything)
+}
@@ -3,10 +3,13 @@ package stocks
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/julianloaiza/stock-advisor/config"
 	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/julianloaiza/stock-advisor/internal/services/apiClient"
+	"github.com/julianloaiza/stock-advisor/internal/services/providers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -16,24 +19,187 @@ type MockRepository struct {
 	mock.Mock
 }
 
-func (m *MockRepository) ReplaceAllStocks(stocks []domain.Stock) error {
-	args := m.Called(stocks)
-	return args.Error(0)
+func (m *MockRepository) UpsertStocks(ctx context.Context, stocks []domain.Stock, removeMissing bool, runID int64) (domain.SyncResult, error) {
+	args := m.Called(stocks, removeMissing, runID)
+	return args.Get(0).(domain.SyncResult), args.Error(1)
 }
 
-func (m *MockRepository) GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error) {
-	args := m.Called(query, page, size, recommends, minTargetTo, maxTargetTo, currency)
+func (m *MockRepository) GetStocks(filter domain.StockFilter) ([]domain.Stock, int64, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]domain.Stock), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockRepository) GetStocksByCursor(filter domain.StockFilter) ([]domain.Stock, string, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.Stock), args.String(1), args.Error(2)
+}
+
+func (m *MockRepository) CountStocks(filter domain.StockFilter) (int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) StreamStocks(ctx context.Context, filter domain.StockFilter, fn func(domain.Stock) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetTickerSummaries(filter domain.StockFilter, buckets domain.RatingBuckets) ([]domain.TickerSummary, int64, error) {
+	args := m.Called(filter, buckets)
+	return args.Get(0).([]domain.TickerSummary), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) GetTickerSummary(ticker string, filter domain.StockFilter, buckets domain.RatingBuckets) (domain.TickerSummary, error) {
+	args := m.Called(ticker, filter, buckets)
+	return args.Get(0).(domain.TickerSummary), args.Error(1)
+}
+
+func (m *MockRepository) GetTickerStocks(ticker string, filter domain.StockFilter) ([]domain.Stock, error) {
+	args := m.Called(ticker, filter)
+	return args.Get(0).([]domain.Stock), args.Error(1)
+}
+
+func (m *MockRepository) GetBrokerageSummaries(filter domain.StockFilter) ([]domain.BrokerageSummary, int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.BrokerageSummary), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) RemoveMissingStocks(runID int64) (int, error) {
+	args := m.Called(runID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) PreviewUpsertStocks(stocks []domain.Stock, sampleSize int) (domain.SyncResult, []domain.SyncPreviewRow, error) {
+	args := m.Called(stocks, sampleSize)
+	return args.Get(0).(domain.SyncResult), args.Get(1).([]domain.SyncPreviewRow), args.Error(2)
+}
+
+func (m *MockRepository) CountMissingStocks(keys []string) (int, error) {
+	args := m.Called(keys)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) GetRatingHistory(ticker, brokerage string, page, size int) ([]domain.StockRatingHistory, int64, error) {
+	args := m.Called(ticker, brokerage, page, size)
+	return args.Get(0).([]domain.StockRatingHistory), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) CreateSyncRun(run *domain.SyncRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveSyncRun(run *domain.SyncRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetSyncRuns(page, size int) ([]domain.SyncRun, int64, error) {
+	args := m.Called(page, size)
+	return args.Get(0).([]domain.SyncRun), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) GetSyncRun(id int64) (domain.SyncRun, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.SyncRun), args.Error(1)
+}
+
+func (m *MockRepository) GetPreviousSyncRunID(runID int64) (int64, error) {
+	args := m.Called(runID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetSyncRunRatings(runID int64) ([]domain.StockRatingHistory, error) {
+	args := m.Called(runID)
+	return args.Get(0).([]domain.StockRatingHistory), args.Error(1)
+}
+
+func (m *MockRepository) GetStockByID(id int64) (domain.Stock, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Stock), args.Error(1)
+}
+
+func (m *MockRepository) GetStocksAfterID(afterID int64, limit int) ([]domain.Stock, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]domain.Stock), args.Error(1)
+}
+
+func (m *MockRepository) UpdateStockScores(scores map[int64]float64) error {
+	args := m.Called(scores)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateSyncJob(job *domain.SyncJob) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveSyncJob(job *domain.SyncJob) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetSyncJob(id int64) (domain.SyncJob, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.SyncJob), args.Error(1)
+}
+
+func (m *MockRepository) FindSyncJobsByState(states ...string) ([]domain.SyncJob, error) {
+	args := m.Called(states)
+	return args.Get(0).([]domain.SyncJob), args.Error(1)
+}
+
+func (m *MockRepository) SaveSyncCheckpoint(checkpoint *domain.SyncCheckpoint, rejects []domain.SyncReject) error {
+	args := m.Called(checkpoint, rejects)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetSyncCheckpoints() ([]domain.SyncCheckpoint, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SyncCheckpoint), args.Error(1)
+}
+
+func (m *MockRepository) ClearSyncCheckpoints() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveSyncRejects(rejects []domain.SyncReject) error {
+	args := m.Called(rejects)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetSyncRejects(page, size int) ([]domain.SyncReject, int64, error) {
+	args := m.Called(page, size)
+	return args.Get(0).([]domain.SyncReject), args.Get(1).(int64), args.Error(2)
+}
+
+// expectCheckpoints configura el mock para aceptar el registro de la ejecución y el registro y la limpieza de checkpoints
+func expectCheckpoints(mockRepo *MockRepository) {
+	expectSyncRun(mockRepo)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil)
+	mockRepo.On("SaveSyncCheckpoint", mock.AnythingOfType("*domain.SyncCheckpoint"), mock.Anything).Return(nil)
+}
+
+// expectSyncRun configura el mock para aceptar el registro de la ejecución con el ID 1
+// y la eliminación de los stocks que no observó
+func expectSyncRun(mockRepo *MockRepository) {
+	mockRepo.On("CreateSyncRun", mock.AnythingOfType("*domain.SyncRun")).
+		Run(func(args mock.Arguments) { args.Get(0).(*domain.SyncRun).ID = 1 }).
+		Return(nil)
+	mockRepo.On("SaveSyncRun", mock.AnythingOfType("*domain.SyncRun")).Return(nil)
+	mockRepo.On("RemoveMissingStocks", int64(1)).Return(0, nil).Maybe()
+}
+
 // MockAPIClient es un mock del cliente de API para las pruebas
 type MockAPIClient struct {
 	mock.Mock
 }
 
-func (m *MockAPIClient) Get(ctx context.Context, endpoint string, params map[string]string) ([]byte, error) {
+// Get devuelve el cuerpo configurado como respuesta obtenida en un solo intento
+func (m *MockAPIClient) Get(ctx context.Context, endpoint string, params map[string]string) (apiClient.Response, error) {
 	args := m.Called(ctx, endpoint, params)
-	return args.Get(0).([]byte), args.Error(1)
+	return apiClient.Response{Body: args.Get(0).([]byte), Attempts: 1}, args.Error(1)
 }
 
 // createMockConfig crea una configuración mock para las pruebas
@@ -45,6 +211,9 @@ func createMockConfig(apiURL, authtkn string, maxIterations, timeout int) *confi
 		SyncTimeout:        timeout,
 		Address:            ":8080",
 		DatabaseURL:        "mock-db-url",
+		SyncRemoveMissing:  true,
+		SyncWorkers:        2,
+		SyncBatchSize:      100,
 		CORSAllowedOrigins: "*",
 	}
 }
@@ -53,7 +222,8 @@ func createMockConfig(apiURL, authtkn string, maxIterations, timeout int) *confi
 func TestSyncStocks_Success(t *testing.T) {
 	// Crear mock del repositorio
 	mockRepo := new(MockRepository)
-	mockRepo.On("ReplaceAllStocks", mock.Anything).Return(nil)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{Inserted: 1}, nil)
+	expectCheckpoints(mockRepo)
 
 	// Crear mock del cliente API
 	mockAPIClient := new(MockAPIClient)
@@ -80,16 +250,17 @@ func TestSyncStocks_Success(t *testing.T) {
 
 	// Crear el servicio con el repositorio mock, configuración mock y cliente API mock
 	service := &service{
-		repo:      mockRepo,
-		cfg:       mockCfg,
-		apiClient: mockAPIClient,
+		repo:     mockRepo,
+		cfg:      mockCfg,
+		provider: providers.NewAPIProvider(mockAPIClient),
 	}
 
 	// Ejecutar el método a probar
-	err := service.SyncStocks(context.Background(), 1)
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 1})
 
 	// Verificar que no hay error
 	assert.NoError(t, err, "La sincronización debería ser exitosa")
+	assert.Equal(t, 1, result.Inserted)
 
 	// Verificar que el repositorio fue llamado correctamente
 	mockRepo.AssertExpectations(t)
@@ -100,6 +271,7 @@ func TestSyncStocks_Success(t *testing.T) {
 func TestSyncStocks_ExternalAPIError(t *testing.T) {
 	// Crear mock del repositorio
 	mockRepo := new(MockRepository)
+	expectCheckpoints(mockRepo)
 
 	// Crear mock del cliente API que devuelve un error
 	mockAPIClient := new(MockAPIClient)
@@ -110,13 +282,13 @@ func TestSyncStocks_ExternalAPIError(t *testing.T) {
 
 	// Crear el servicio con el repositorio mock, configuración mock y cliente API mock
 	service := &service{
-		repo:      mockRepo,
-		cfg:       mockCfg,
-		apiClient: mockAPIClient,
+		repo:     mockRepo,
+		cfg:      mockCfg,
+		provider: providers.NewAPIProvider(mockAPIClient),
 	}
 
 	// Ejecutar el método a probar
-	err := service.SyncStocks(context.Background(), 1)
+	_, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 1})
 
 	// Verificar que hay un error
 	assert.Error(t, err, "La sincronización debería fallar")
@@ -130,7 +302,8 @@ func TestSyncStocks_ExternalAPIError(t *testing.T) {
 func TestSyncStocks_RepositoryError(t *testing.T) {
 	// Crear mock del repositorio que devuelve error
 	mockRepo := new(MockRepository)
-	mockRepo.On("ReplaceAllStocks", mock.Anything).Return(errors.New("error al reemplazar stocks"))
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{}, errors.New("error al guardar stocks"))
+	expectCheckpoints(mockRepo)
 
 	// Crear mock del cliente API
 	mockAPIClient := new(MockAPIClient)
@@ -157,25 +330,296 @@ func TestSyncStocks_RepositoryError(t *testing.T) {
 
 	// Crear el servicio con el repositorio mock, configuración mock y cliente API mock
 	service := &service{
-		repo:      mockRepo,
-		cfg:       mockCfg,
-		apiClient: mockAPIClient,
+		repo:     mockRepo,
+		cfg:      mockCfg,
+		provider: providers.NewAPIProvider(mockAPIClient),
 	}
 
 	// Ejecutar el método a probar
-	err := service.SyncStocks(context.Background(), 1)
+	_, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 1})
 
 	// Verificar que hay un error
 	assert.Error(t, err, "La sincronización debería fallar")
-	assert.Contains(t, err.Error(), "error reemplazando stocks")
+	assert.Contains(t, err.Error(), "error guardando stocks")
 
 	// Verificar que el repositorio y el cliente API fueron llamados
 	mockRepo.AssertExpectations(t)
 	mockAPIClient.AssertExpectations(t)
 }
 
-// Repository define la interfaz del repositorio
-type Repository interface {
-	GetStocks(query string, page, size int, recommends bool, minTargetTo, maxTargetTo float64, currency string) ([]domain.Stock, int64, error)
-	ReplaceAllStocks(stocks []domain.Stock) error
+// TestSyncStocks_NoItems prueba que no se modifique la base de datos si la API no devuelve stocks
+func TestSyncStocks_NoItems(t *testing.T) {
+	// Crear mock del repositorio (solo debería registrar checkpoints)
+	mockRepo := new(MockRepository)
+	expectCheckpoints(mockRepo)
+
+	// Crear mock del cliente API que devuelve una página vacía
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{"items": [], "next_page": ""}`), nil)
+
+	// Crear el servicio con el repositorio mock, configuración mock y cliente API mock
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	// Ejecutar el método a probar
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 1})
+
+	// Verificar que no hay error ni cambios
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SyncResult{PagesFetched: 1, APIAttempts: 1}, result)
+
+	// Verificar que no se intentó escribir en la base de datos
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSyncStocks_ResumeFromCheckpoint prueba que una sincronización reanudada continúe desde el último checkpoint
+func TestSyncStocks_ResumeFromCheckpoint(t *testing.T) {
+	// Checkpoint de una sincronización anterior que falló tras la primera página
+	resumed := domain.Stock{Ticker: "MSFT", Brokerage: "Example Brokerage", TargetFrom: 300, TargetTo: 350}
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncCheckpoints").Return([]domain.SyncCheckpoint{
+		{Iteration: 1, NextPage: "page-2", Stocks: []domain.Stock{resumed}, ItemsRejected: 1},
+	}, nil)
+	mockRepo.On("SaveSyncCheckpoint", mock.MatchedBy(func(checkpoint *domain.SyncCheckpoint) bool {
+		return checkpoint.Iteration == 2 && checkpoint.Final
+	}), mock.Anything).Return(nil)
+	mockRepo.On("UpsertStocks", mock.MatchedBy(func(stocks []domain.Stock) bool {
+		return len(stocks) == 2 && stocks[0].Ticker == "MSFT" && stocks[1].Ticker == "AAPL"
+	}), false, int64(1)).Return(domain.SyncResult{Inserted: 2}, nil)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil).Once()
+	expectSyncRun(mockRepo)
+
+	// La API solo debe recibir la solicitud de la segunda página
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, "", map[string]string{"next_page": "page-2"}).Return([]byte(`{
+		"items": [{"ticker": "AAPL", "brokerage": "Example Brokerage", "target_from": "$150.00", "target_to": "$180.00"}],
+		"next_page": ""
+	}`), nil)
+
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	// Ejecutar el método a probar
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 5, Resume: true})
+
+	// Verificar el progreso combinado
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.PagesFetched)
+	assert.Equal(t, 1, result.PagesResumed)
+	assert.Equal(t, 2, result.ItemsParsed)
+	assert.Equal(t, 1, result.ItemsRejected)
+	assert.Equal(t, 1, result.APIAttempts)
+	assert.Equal(t, 2, result.Inserted)
+
+	mockRepo.AssertExpectations(t)
+	mockAPIClient.AssertExpectations(t)
+}
+
+// TestSyncStocks_ResumeAfterLastPage prueba que, si la paginación ya había terminado, solo se guarden los stocks
+func TestSyncStocks_ResumeAfterLastPage(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetSyncCheckpoints").Return([]domain.SyncCheckpoint{
+		{Iteration: 1, NextPage: "", Final: true, Stocks: []domain.Stock{{Ticker: "AAPL"}}},
+	}, nil)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{Updated: 1}, nil)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil)
+	expectSyncRun(mockRepo)
+
+	mockAPIClient := new(MockAPIClient)
+
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 5, Resume: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+	mockAPIClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSyncStocks_CheckpointError prueba que un error al guardar el checkpoint detenga la sincronización
+func TestSyncStocks_CheckpointError(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil)
+	expectSyncRun(mockRepo)
+	mockRepo.On("SaveSyncCheckpoint", mock.Anything, mock.Anything).Return(errors.New("error de base de datos"))
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+		"items": [{"ticker": "AAPL", "brokerage": "Example Brokerage", "target_from": "$150.00", "target_to": "$180.00"}],
+		"next_page": "page-2"
+	}`), nil)
+
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	_, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 5})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error guardando checkpoint de la iteración 1")
+	mockRepo.AssertNotCalled(t, "UpsertStocks", mock.Anything, mock.Anything, mock.Anything)
+	// El fetcher puede haber obtenido la página siguiente antes de que fallara el checkpoint, pero no más
+	assert.LessOrEqual(t, len(mockAPIClient.Calls), 2)
+}
+
+// TestSyncStocks_QuarantinesRejects prueba que los elementos que no se pueden parsear se guarden con el checkpoint
+func TestSyncStocks_QuarantinesRejects(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil)
+	expectSyncRun(mockRepo)
+	mockRepo.On("SaveSyncCheckpoint", mock.MatchedBy(func(checkpoint *domain.SyncCheckpoint) bool {
+		return checkpoint.Iteration == 1 && len(checkpoint.Stocks) == 1 && checkpoint.ItemsRejected == 1
+	}), mock.MatchedBy(func(rejects []domain.SyncReject) bool {
+		return len(rejects) == 1 &&
+			rejects[0].Iteration == 1 &&
+			rejects[0].RawItem["ticker"] == "BAD" &&
+			rejects[0].RawItem["target_to"] == "n/a" &&
+			strings.Contains(rejects[0].Error, "target_to")
+	})).Return(nil)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{Inserted: 1}, nil)
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+		"items": [
+			{"ticker": "AAPL", "brokerage": "Example Brokerage", "target_from": "$150.00", "target_to": "$180.00"},
+			{"ticker": "BAD", "brokerage": "Example Brokerage", "target_from": "$10.00", "target_to": "n/a"}
+		],
+		"next_page": ""
+	}`), nil)
+
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.ItemsParsed)
+	assert.Equal(t, 1, result.ItemsRejected)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSyncStocks_RecordsSyncRun prueba que la ejecución se registre con sus parámetros, motivo de fin y totales,
+// y que su ID se asocie al historial
+func TestSyncStocks_RecordsSyncRun(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ClearSyncCheckpoints").Return(nil)
+	mockRepo.On("SaveSyncCheckpoint", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateSyncRun", mock.AnythingOfType("*domain.SyncRun")).
+		Run(func(args mock.Arguments) { args.Get(0).(*domain.SyncRun).ID = 42 }).
+		Return(nil)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(42)).Return(domain.SyncResult{Inserted: 1}, nil)
+	mockRepo.On("RemoveMissingStocks", int64(42)).Return(2, nil)
+	mockRepo.On("SaveSyncRun", mock.MatchedBy(func(run *domain.SyncRun) bool {
+		return run.ID == 42 && run.FinishedAt != nil &&
+			run.RequestedLimit == 50 && run.EffectiveLimit == 10 &&
+			run.StopReason == domain.SyncStopEmptyNextPage &&
+			run.PagesFetched == 1 && run.Inserted == 1 && run.Removed == 2 && run.Error == ""
+	})).Return(nil)
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+		"items": [{"ticker": "AAPL", "brokerage": "Example Brokerage", "target_from": "$150.00", "target_to": "$180.00"}],
+		"next_page": ""
+	}`), nil)
+
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	_, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 50})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSyncStocks_KeepsMissingWhenLimitReached prueba que, si la sincronización se detiene por el límite,
+// no se eliminen los stocks de las páginas que no se leyeron
+func TestSyncStocks_KeepsMissingWhenLimitReached(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("UpsertStocks", mock.Anything, false, int64(1)).Return(domain.SyncResult{Inserted: 1}, nil)
+	expectCheckpoints(mockRepo)
+
+	mockAPIClient := new(MockAPIClient)
+	mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+		"items": [{"ticker": "AAPL", "brokerage": "Example Brokerage", "target_from": "$150.00", "target_to": "$180.00"}],
+		"next_page": "page-2"
+	}`), nil)
+
+	service := &service{
+		repo:     mockRepo,
+		cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+		provider: providers.NewAPIProvider(mockAPIClient),
+	}
+
+	result, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Removed)
+	mockRepo.AssertNotCalled(t, "RemoveMissingStocks", mock.Anything)
+}
+
+// TestSyncStocks_RecordsStopReason prueba el motivo de fin registrado según la paginación
+func TestSyncStocks_RecordsStopReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		nextPage string
+		limit    int
+		expected string
+	}{
+		{name: "límite alcanzado", nextPage: "page-2", limit: 1, expected: domain.SyncStopLimit},
+		{name: "ciclo detectado", nextPage: "page-1", limit: 3, expected: domain.SyncStopCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			mockRepo.On("ClearSyncCheckpoints").Return(nil)
+			mockRepo.On("SaveSyncCheckpoint", mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("CreateSyncRun", mock.Anything).Return(nil)
+			mockRepo.On("UpsertStocks", mock.Anything, false, mock.Anything).Return(domain.SyncResult{}, nil)
+			mockRepo.On("RemoveMissingStocks", mock.Anything).Return(0, nil).Maybe()
+			mockRepo.On("SaveSyncRun", mock.MatchedBy(func(run *domain.SyncRun) bool {
+				return run.StopReason == tt.expected
+			})).Return(nil)
+
+			// La primera página apunta a page-1 y las siguientes a tt.nextPage
+			mockAPIClient := new(MockAPIClient)
+			mockAPIClient.On("Get", mock.Anything, mock.Anything, map[string]string{}).Return([]byte(`{
+				"items": [{"ticker": "AAPL", "brokerage": "Example Brokerage", "target_from": "$150.00", "target_to": "$180.00"}],
+				"next_page": "page-1"
+			}`), nil)
+			mockAPIClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{
+				"items": [], "next_page": "`+tt.nextPage+`"
+			}`), nil)
+
+			service := &service{
+				repo:     mockRepo,
+				cfg:      createMockConfig("http://test-api.com", "test-auth-tkn", 10, 30),
+				provider: providers.NewAPIProvider(mockAPIClient),
+			}
+
+			_, err := service.SyncStocks(context.Background(), domain.SyncOptions{Limit: tt.limit})
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
 }
@@ -0,0 +1,70 @@
+package stocks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+)
+
+// ratingBuyThreshold es la puntuación mínima de una calificación para considerarla de compra
+const ratingBuyThreshold = 10
+
+// GetTickers obtiene el resumen de consenso por ticker de los stocks filtrados.
+func (s *service) GetTickers(filter domain.StockFilter) ([]domain.TickerSummary, int64, error) {
+	return s.repo.GetTickerSummaries(s.withRatingBuckets(filter), ratingBuckets(s.recommendationFactors()))
+}
+
+// GetTicker obtiene el resumen de consenso de un ticker y las acciones de los brokerages que lo cubren.
+func (s *service) GetTicker(ticker string, filter domain.StockFilter) (domain.TickerDetail, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	filter = s.withRatingBuckets(filter)
+
+	summary, err := s.repo.GetTickerSummary(ticker, filter, ratingBuckets(s.recommendationFactors()))
+	if err != nil {
+		return domain.TickerDetail{}, err
+	}
+
+	stocks, err := s.repo.GetTickerStocks(ticker, filter)
+	if err != nil {
+		return domain.TickerDetail{}, err
+	}
+
+	return domain.TickerDetail{TickerSummary: summary, Stocks: stocks}, nil
+}
+
+// ratingBuckets clasifica las calificaciones del modelo de puntuación: las negativas son de venta,
+// las que alcanzan ratingBuyThreshold son de compra y el resto se consideran neutrales.
+func ratingBuckets(factors *config.RecommendationFactors) domain.RatingBuckets {
+	var buckets domain.RatingBuckets
+	for rating, score := range factors.RatingScores {
+		switch {
+		case factors.IsNegativeRating(rating):
+			buckets.Sell = append(buckets.Sell, rating)
+		case score >= ratingBuyThreshold:
+			buckets.Buy = append(buckets.Buy, rating)
+		default:
+			buckets.Hold = append(buckets.Hold, rating)
+		}
+	}
+
+	// Las calificaciones negativas sin puntuación también se clasifican como venta
+	for _, rating := range factors.NegativeRatings {
+		if _, scored := factors.RatingScores[rating]; !scored {
+			buckets.Sell = append(buckets.Sell, rating)
+		}
+	}
+
+	// Orden estable para generar siempre la misma consulta
+	sort.Strings(buckets.Buy)
+	sort.Strings(buckets.Hold)
+	sort.Strings(buckets.Sell)
+	return buckets
+}
+
+// GetTickerHistory obtiene el historial de calificaciones de un ticker registrado en cada sincronización.
+func (s *service) GetTickerHistory(ticker, brokerage string, page, size int) ([]domain.StockRatingHistory, int64, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	return s.repo.GetRatingHistory(ticker, strings.TrimSpace(brokerage), page, size)
+}
@@ -0,0 +1,68 @@
+package stocks
+
+import (
+	"testing"
+
+	"github.com/julianloaiza/stock-advisor/config"
+	"github.com/julianloaiza/stock-advisor/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRatingBuckets verifica la clasificación de las calificaciones del modelo por defecto
+func TestRatingBuckets(t *testing.T) {
+	buckets := ratingBuckets(config.DefaultRecommendationFactors())
+
+	assert.Contains(t, buckets.Buy, "buy")
+	assert.Contains(t, buckets.Buy, "sector outperform")
+	assert.Contains(t, buckets.Hold, "market perform")
+	assert.Contains(t, buckets.Hold, "market weight")
+	assert.ElementsMatch(t, []string{"reduce", "sell", "strong sell", "underperform", "underweight"}, buckets.Sell)
+}
+
+// TestGetTicker prueba que el ticker se normalice y se combinen el resumen y sus acciones
+func TestGetTicker(t *testing.T) {
+	filter := domain.StockFilter{Currency: "USD"}
+	stocks := []domain.Stock{{ID: 1, Ticker: "AAPL"}, {ID: 2, Ticker: "AAPL"}}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetTickerSummary", "AAPL", filter, mock.AnythingOfType("domain.RatingBuckets")).
+		Return(domain.TickerSummary{Ticker: "AAPL", Actions: 2}, nil)
+	mockRepo.On("GetTickerStocks", "AAPL", filter).Return(stocks, nil)
+
+	svc := &service{repo: mockRepo, cfg: &config.Config{}}
+	detail, err := svc.GetTicker(" aapl ", filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", detail.Ticker)
+	assert.Equal(t, stocks, detail.Stocks)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestGetTicker_NotFound prueba que un ticker sin acciones devuelva ErrNotFound
+func TestGetTicker_NotFound(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetTickerSummary", "ZZZZ", domain.StockFilter{}, mock.Anything).Return(domain.TickerSummary{}, domain.ErrNotFound)
+
+	svc := &service{repo: mockRepo, cfg: &config.Config{}}
+	_, err := svc.GetTicker("zzzz", domain.StockFilter{})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "GetTickerStocks", mock.Anything, mock.Anything)
+}
+
+// TestGetTickerHistory prueba que el ticker y el brokerage se normalicen antes de consultar el historial
+func TestGetTickerHistory(t *testing.T) {
+	history := []domain.StockRatingHistory{{ID: 1, SyncRunID: 1, Ticker: "AAPL", RatingTo: "Buy"}}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetRatingHistory", "AAPL", "Goldman Sachs", 2, 5).Return(history, int64(6), nil)
+
+	svc := &service{repo: mockRepo, cfg: &config.Config{}}
+	result, total, err := svc.GetTickerHistory(" aapl ", " Goldman Sachs ", 2, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, history, result)
+	assert.Equal(t, int64(6), total)
+	mockRepo.AssertExpectations(t)
+}
@@ -5,11 +5,18 @@
 // @BasePath /
 // @tag.name Stocks
 // @tag.description Operaciones con acciones bursátiles
+// @tag.name Factors
+// @tag.description Administración de los factores de recomendación de empresas y brokerages
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Token de administración (ADMIN_TOKEN) con el formato "Bearer <token>"
 package main
 
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/julianloaiza/stock-advisor/config"
@@ -19,11 +26,11 @@ import (
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/handlers"
 	"github.com/julianloaiza/stock-advisor/internal/httpapi/middleware"
 	"github.com/julianloaiza/stock-advisor/internal/repositories"
+	"github.com/julianloaiza/stock-advisor/internal/scheduler"
 	"github.com/julianloaiza/stock-advisor/internal/services"
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"go.uber.org/fx"
-	"gorm.io/gorm"
 )
 
 // Params inyecta dependencias en el ciclo de vida de la aplicación.
@@ -32,23 +39,29 @@ type Params struct {
 
 	Lc       fx.Lifecycle
 	Config   *config.Config
-	DB       *gorm.DB
 	Echo     *echo.Echo
 	Handlers []handlers.Handler `group:"handlers"`
 }
 
 // main inicia la aplicación con Uber FX.
+// Con el argumento import ejecuta la importación de un archivo y termina (ver runImport).
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:]))
+	}
+
 	app := fx.New(
 		fx.Provide(
 			context.Background,
 			config.New,
+			config.NewFactorsWatcher,
 			database.New,
 			echo.New,
 		),
 		repositories.Module,
 		services.Module,
 		httpapi.Module,
+		scheduler.Module,
 		fx.Invoke(setLifeCycle),
 	)
 
@@ -95,11 +108,6 @@ func setLifeCycle(p Params) {
 				log.Printf("Error al detener el servidor: %v", err)
 			}
 
-			// Cerrar conexión a la base de datos
-			if err := closeDatabase(p.DB); err != nil {
-				log.Printf("Error al cerrar la base de datos: %v", err)
-			}
-
 			log.Println("✅ Aplicación detenida correctamente")
 			return nil
 		},
@@ -111,13 +119,3 @@ func shutdownServer(ctx context.Context, e *echo.Echo) error {
 	log.Println("🛑 Deteniendo servidor HTTP...")
 	return e.Shutdown(ctx)
 }
-
-// closeDatabase cierra la conexión a la base de datos.
-func closeDatabase(db *gorm.DB) error {
-	log.Println("🛑 Cerrando conexión a la base de datos...")
-	sqlDB, err := db.DB()
-	if err != nil {
-		return err
-	}
-	return sqlDB.Close()
-}